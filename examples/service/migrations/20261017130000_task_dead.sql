@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE goque_task_dead (
+    id              UUID        PRIMARY KEY,
+    type            TEXT        NOT NULL,
+    external_id     TEXT        NOT NULL,
+    payload         JSONB       NOT NULL,
+    status          TEXT        NOT NULL,
+    attempts        INT         NOT NULL,
+    errors          TEXT,
+    metadata        JSONB,
+    created_at      TIMESTAMPTZ NOT NULL    DEFAULT now(),
+    updated_at      TIMESTAMPTZ,
+    next_attempt_at TIMESTAMPTZ NOT NULL    DEFAULT now(),
+    priority        INT         NOT NULL    DEFAULT 0
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX goque_task_dead_type_updated_at_idx ON goque_task_dead (type, updated_at ASC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE goque_task_dead;
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE goque_task ADD COLUMN result JSONB;
+ALTER TABLE goque_task_dead ADD COLUMN result JSONB;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE goque_task_dead DROP COLUMN result;
+ALTER TABLE goque_task DROP COLUMN result;
+-- +goose StatementEnd
@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE goque_task_batch (
+    id                 UUID        PRIMARY KEY,
+    total              BIGINT      NOT NULL,
+    completion_task_id UUID,
+    created_at         TIMESTAMPTZ NOT NULL DEFAULT now(),
+    completed_at       TIMESTAMPTZ
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE goque_task ADD COLUMN batch_id UUID;
+ALTER TABLE goque_task_dead ADD COLUMN batch_id UUID;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX goque_task_batch_id_status_idx ON goque_task (batch_id, status) WHERE batch_id IS NOT NULL;
+CREATE INDEX goque_task_dead_batch_id_idx ON goque_task_dead (batch_id) WHERE batch_id IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX goque_task_dead_batch_id_idx;
+DROP INDEX goque_task_batch_id_status_idx;
+ALTER TABLE goque_task_dead DROP COLUMN batch_id;
+ALTER TABLE goque_task DROP COLUMN batch_id;
+DROP TABLE goque_task_batch;
+-- +goose StatementEnd
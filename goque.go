@@ -6,10 +6,12 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/ruko1202/xlog"
 	"github.com/ruko1202/xlog/xfield"
 
+	"github.com/ruko1202/goque/internal/metrics"
 	"github.com/ruko1202/goque/internal/utils/xtracer"
 
 	"github.com/ruko1202/goque/internal/processors/periodicprocessor"
@@ -22,6 +24,8 @@ type Goque struct {
 	taskQueueManager      TaskQueueManager
 	processors            map[string]*queueprocessor.GoqueProcessor
 	periodicJobProcessors map[string]*periodicprocessor.Processor
+	statsCollector        *metrics.StatsCollector
+	middlewares           []Middleware
 }
 
 // NewGoque creates a new Goque instance with the specified task storage.
@@ -49,6 +53,25 @@ func (g *Goque) RegisterProcessor(
 	)
 }
 
+// SetWorkersCount changes the number of workers of the processor registered for processorType at runtime
+// and returns the number set. With WithWorkersAutoscale the count is kept within the autoscale range
+// and may be changed again by the autoscaler.
+func (g *Goque) SetWorkersCount(processorType string, count int) (int, error) {
+	p, ok := g.processors[processorType]
+	if !ok {
+		return 0, fmt.Errorf("processor '%s' is not registered", processorType)
+	}
+
+	return p.SetWorkersCount(count)
+}
+
+// Use adds middlewares wrapping every registered processor, outside of its own middlewares
+// set by WithMiddlewares. The first one is the outermost. Should be called before Run: the processors
+// are wrapped on every Run, so the middlewares added while they run apply only after Stop and the next Run.
+func (g *Goque) Use(middlewares ...Middleware) {
+	g.middlewares = append(g.middlewares, middlewares...)
+}
+
 // RegisterPeriodicJob registers a periodic job processor.
 // Should be called before Run.
 func (g *Goque) RegisterPeriodicJob(job *PeriodicJob) {
@@ -62,6 +85,15 @@ func (g *Goque) RegisterPeriodicJob(job *PeriodicJob) {
 	)
 }
 
+// RegisterStatsCollector enables exporting the queue stats (see TaskQueueManager.Stats)
+// as Prometheus gauges, refreshed every period.
+// Should be called before Run.
+func (g *Goque) RegisterStatsCollector(period time.Duration) {
+	g.statsCollector = metrics.NewStatsCollector(func(ctx context.Context) ([]*TaskStats, error) {
+		return g.taskQueueManager.Stats(ctx, nil)
+	}, period)
+}
+
 // Run starts all registered processors in separate goroutines.
 func (g *Goque) Run(ctx context.Context) error {
 	ctx = xlog.ContextWithTracer(ctx, xtracer.GetTracer())
@@ -80,12 +112,17 @@ func (g *Goque) Run(ctx context.Context) error {
 		return fmt.Errorf("failed to run periodic processors: %w", err)
 	}
 
+	if g.statsCollector != nil {
+		g.statsCollector.Run(ctx)
+	}
+
 	return nil
 }
 
 func (g *Goque) runProcessors(ctx context.Context) error {
 	var runErr error
 	for _, p := range g.processors {
+		p.SetGlobalMiddlewares(g.middlewares...)
 		err := p.Run(ctx)
 		if err != nil {
 			xlog.Error(ctx, "failed to run processor", xfield.Error(err), xfield.String("processor", p.Name()))
@@ -119,6 +156,10 @@ func (g *Goque) runPeriodicProcessors(ctx context.Context) error {
 // after Stop() returns — without it a late async write hits a closed
 // connection pool.
 func (g *Goque) Stop() {
+	if g.statsCollector != nil {
+		g.statsCollector.Stop()
+	}
+
 	g.stopPeriodicProcessors()
 
 	g.stopProcessors()
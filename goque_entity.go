@@ -15,7 +15,8 @@ type TaskStatus = entity.TaskStatus
 // Task status constants define the possible states a task can be in.
 const (
 	TaskStatusNew          = entity.TaskStatusNew          // Task is ready to be picked up
-	TaskStatusPending      = entity.TaskStatusPending      // Task is scheduled for future processing
+	TaskStatusPending      = entity.TaskStatusPending      // Task is fetched by a processor and waits for a worker
+	TaskStatusWaiting      = entity.TaskStatusWaiting      // Task waits for the tasks it depends on to be done
 	TaskStatusProcessing   = entity.TaskStatusProcessing   // Task is currently being processed
 	TaskStatusDone         = entity.TaskStatusDone         // Task completed successfully
 	TaskStatusCanceled     = entity.TaskStatusCanceled     // Task was manually canceled
@@ -23,6 +24,9 @@ const (
 	TaskStatusAttemptsLeft = entity.TaskStatusAttemptsLeft // Task failed and exhausted all retries
 )
 
+// MaxTaskProgress is the progress of a done task, see SaveProgress.
+const MaxTaskProgress = entity.MaxTaskProgress
+
 type (
 	// Task represents a unit of work to be processed by the queue system.
 	Task = entity.Task
@@ -30,11 +34,37 @@ type (
 	TypedTask[T any] = entity.TypedTask[T]
 	// Metadata represents arbitrary key-value data associated with a task for tracking and context.
 	Metadata = entity.Metadata
+	// TaskOpts configures optional task attributes on creation.
+	TaskOpts = entity.TaskOpts
+	// TaskStats is a snapshot of the queue state for a single task type.
+	TaskStats = entity.TaskStats
+	// TaskLease describes the ownership a processor takes on the tasks it fetches.
+	TaskLease = entity.TaskLease
+	// RateLimit is a token bucket limiting how many tasks are fetched per interval.
+	RateLimit = entity.RateLimit
+	// Workflow is a graph of tasks added to the queue at once, see TaskQueueManager.AddWorkflowToQueue.
+	Workflow = entity.Workflow
+	// Batch is a group of tasks followed by a completion task, see TaskQueueManager.AddBatch.
+	Batch = entity.Batch
 )
 
 // TaskFilter represents filtering criteria for querying tasks from the queue.
 type TaskFilter = dbentity.GetTasksFilter
 
+// DeadTaskFilter represents filtering criteria for querying dead-lettered tasks.
+type DeadTaskFilter = dbentity.DeadTasksFilter
+
+// TaskCursor points to a task for keyset pagination via TaskFilter.After.
+type TaskCursor = dbentity.TaskCursor
+
+// Keyset pagination helpers.
+var (
+	// NewTaskCursor returns a cursor pointing to the task; pass the last task of a page to get the next page.
+	NewTaskCursor = dbentity.NewTaskCursor
+	// ParseTaskCursor decodes a cursor produced by TaskCursor.String.
+	ParseTaskCursor = dbentity.ParseTaskCursor
+)
+
 // Task creation functions for adding new tasks to the queue.
 var (
 	// NoTaskPayload represents an empty task payload.
@@ -43,14 +73,30 @@ var (
 	NewTask = entity.NewTask
 	// NewTaskWithExternalID creates a new task with an external identifier for idempotency.
 	NewTaskWithExternalID = entity.NewTaskWithExternalID
+	// WithTaskPriority sets the task priority. Tasks with a higher priority are fetched first.
+	WithTaskPriority = entity.WithTaskPriority
+	// WithTaskTimeout overrides the processing timeout of the processor for the task.
+	WithTaskTimeout = entity.WithTaskTimeout
+	// WithTaskMaxAttempts overrides the maximum number of attempts of the processor for the task.
+	WithTaskMaxAttempts = entity.WithTaskMaxAttempts
+	// WithTaskConcurrencyKey sets the concurrency key limited by the processor concurrency limit per key.
+	WithTaskConcurrencyKey = entity.WithTaskConcurrencyKey
+	// WithTaskRunAt schedules the task to be processed not earlier than the given time.
+	WithTaskRunAt = entity.WithTaskRunAt
+	// WithTaskDelay schedules the task to be processed after the given delay.
+	WithTaskDelay = entity.WithTaskDelay
+	// WithTaskDependsOn makes the task wait until the tasks with the given IDs are done.
+	WithTaskDependsOn = entity.WithTaskDependsOn
+	// NewWorkflow creates an empty workflow.
+	NewWorkflow = entity.NewWorkflow
 )
 
 // NewTaskWithPayload creates a new task with a typed payload marshaled as JSON.
-func NewTaskWithPayload[T any](taskType TaskType, payload T) (*Task, error) {
-	return entity.NewTaskWithPayload[T](taskType, payload)
+func NewTaskWithPayload[T any](taskType TaskType, payload T, opts ...TaskOpts) (*Task, error) {
+	return entity.NewTaskWithPayload[T](taskType, payload, opts...)
 }
 
 // NewTaskWithPayloadAndExternalID creates a new task with a typed payload and custom external ID.
-func NewTaskWithPayloadAndExternalID[T any](taskType TaskType, payload T, externalID string) (*Task, error) {
-	return entity.NewTaskWithPayloadAndExternalID[T](taskType, payload, externalID)
+func NewTaskWithPayloadAndExternalID[T any](taskType TaskType, payload T, externalID string, opts ...TaskOpts) (*Task, error) {
+	return entity.NewTaskWithPayloadAndExternalID[T](taskType, payload, externalID, opts...)
 }
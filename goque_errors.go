@@ -3,6 +3,7 @@ package goque
 
 import (
 	"github.com/ruko1202/goque/internal/entity"
+	"github.com/ruko1202/goque/internal/storages/dbentity"
 )
 
 var (
@@ -14,8 +15,72 @@ var (
 	ErrPayloadMarshal = entity.ErrPayloadMarshal
 	// ErrPayloadUnmarshal is returned when a typed task payload cannot be unmarshaled from JSON.
 	ErrPayloadUnmarshal = entity.ErrPayloadUnmarshal
+	// ErrResultMarshal is returned when a task result cannot be marshaled to JSON.
+	ErrResultMarshal = entity.ErrResultMarshal
+	// ErrResultUnmarshal is returned when a typed task result cannot be unmarshaled from JSON.
+	ErrResultUnmarshal = entity.ErrResultUnmarshal
+	// ErrCheckpointMarshal is returned by SaveCheckpoint when the checkpoint cannot be marshaled to JSON.
+	ErrCheckpointMarshal = entity.ErrCheckpointMarshal
+	// ErrCheckpointUnmarshal is returned by LoadCheckpoint when the task checkpoint cannot be unmarshaled from JSON.
+	ErrCheckpointUnmarshal = entity.ErrCheckpointUnmarshal
+	// ErrInvalidSchedule is returned when a task cannot be scheduled for the requested time.
+	ErrInvalidSchedule = entity.ErrInvalidSchedule
+	// ErrEmptyFilter is returned by bulk operations for a filter without criteria.
+	ErrEmptyFilter = dbentity.ErrEmptyFilter
+	// ErrInvalidCursor is returned when a pagination cursor can't be parsed.
+	ErrInvalidCursor = dbentity.ErrInvalidCursor
 	// ErrTaskCancel is returned when a task is canceled during processing.
 	ErrTaskCancel = entity.ErrTaskCancel
+	// ErrTaskCancelRequested is the cause of the processing context (see context.Cause)
+	// when the task is canceled by a user while it is being processed.
+	ErrTaskCancelRequested = entity.ErrTaskCancelRequested
+	// ErrTaskStateConflict is returned when a task was changed concurrently since it was read,
+	// e.g. by the healer, a user or another processor, so the state transition is rejected.
+	// It also matches ErrTaskCancelRequested when the task has been canceled in the meantime.
+	ErrTaskStateConflict = entity.ErrTaskStateConflict
 	// ErrTaskTimeout is returned when task processing exceeds the timeout limit.
 	ErrTaskTimeout = entity.ErrTaskTimeout
+	// ErrTaskPanic is returned when task processing panics and RecoveryMiddleware recovers it.
+	ErrTaskPanic = entity.ErrTaskPanic
+	// ErrNoTaskInContext is returned by SaveCheckpoint, SaveProgress and LoadCheckpoint
+	// called without the context of a task being processed.
+	ErrNoTaskInContext = entity.ErrNoTaskInContext
+	// ErrTaskFailed is returned by GetTaskResult for a task that ran out of attempts
+	// or failed permanently. The error text carries the last processing error.
+	ErrTaskFailed = entity.ErrTaskFailed
+	// ErrTaskDependencyNotFound is returned when a task depends on a task that is not in the queue.
+	ErrTaskDependencyNotFound = entity.ErrTaskDependencyNotFound
+	// ErrTaskDependencyCycle is returned when the tasks added at once depend on each other in a cycle.
+	ErrTaskDependencyCycle = entity.ErrTaskDependencyCycle
+	// ErrTaskDependencyFailed is returned when a task depends on a canceled or failed task.
+	// A waiting task whose dependency fails later is canceled with it in the task errors.
+	ErrTaskDependencyFailed = entity.ErrTaskDependencyFailed
+	// ErrInvalidBatch is returned by AddBatch when the completion task is a task of the batch,
+	// depends on other tasks or is depended on by the tasks of the batch.
+	ErrInvalidBatch = entity.ErrInvalidBatch
 )
+
+// Processing results a TaskProcessor may return to control what happens to the task.
+var (
+	// Permanent wraps err so the task fails right away without retries.
+	Permanent = entity.Permanent
+	// RetryAfter wraps err so the task is retried after the delay instead of the processor backoff.
+	// The attempt is counted.
+	RetryAfter = entity.RetryAfter
+	// Snooze reschedules the task after the delay without counting an attempt.
+	Snooze = entity.Snooze
+)
+
+// Processing result types, to be inspected with errors.As, e.g. in WithHooksAfterProcessing.
+type (
+	// PermanentError is returned by Permanent.
+	PermanentError = entity.PermanentError
+	// RetryAfterError is returned by RetryAfter.
+	RetryAfterError = entity.RetryAfterError
+	// SnoozeError is returned by Snooze.
+	SnoozeError = entity.SnoozeError
+)
+
+// TasksError reports per-task failures of a batch operation such as AddTasksToQueue.
+// It unwraps to the per-task errors, so errors.Is(err, ErrDuplicateTask) works on it.
+type TasksError = entity.TasksError
@@ -2,7 +2,10 @@ package goque
 
 import (
 	"context"
+	"fmt"
+	"time"
 
+	"github.com/goccy/go-json"
 	"github.com/google/uuid"
 
 	"github.com/ruko1202/goque/internal/queuemanager"
@@ -26,27 +29,193 @@ type TaskQueueManager interface {
 	// caller's tx and is rolled back if the caller rolls back.
 	AddTaskToQueue(ctx context.Context, task *Task) error
 
+	// AddTaskOrGet is the idempotent form of AddTaskToQueue: if a
+	// task with the same type and external ID already exists, it
+	// is returned instead of ErrDuplicateTask, and created is
+	// false. Otherwise task is inserted and returned with created
+	// set to true. Honors a tx attached to ctx via WithTx.
+	AddTaskOrGet(ctx context.Context, task *Task) (stored *Task, created bool, err error)
+
+	// AddTasksToQueue enqueues tasks with multi-row INSERTs (COPY
+	// on PostgreSQL for large batches) in a single transaction and
+	// honors a tx attached to ctx via WithTx. The batch is atomic:
+	// an invalid payload or a duplicate fails it as a whole, and
+	// no task is inserted. With WithSkipDuplicates duplicates are
+	// skipped, the rest is inserted, and the skipped tasks are
+	// reported via *TasksError with ErrDuplicateTask.
+	AddTasksToQueue(ctx context.Context, tasks []*Task, opts ...AddTasksOpts) error
+
+	// AddWorkflowToQueue enqueues all the tasks of the workflow
+	// atomically, same as AddTasksToQueue without skipping
+	// duplicates. A task depending on others is inserted with
+	// status=waiting and becomes new once all of them are done; it
+	// is canceled with ErrTaskDependencyFailed if any of them is
+	// canceled or runs out of attempts. Dependencies on missing or
+	// failed tasks and cycles fail the workflow via *TasksError.
+	// Honors a tx attached to ctx via WithTx.
+	AddWorkflowToQueue(ctx context.Context, workflow *Workflow) error
+
+	// AddBatch enqueues the tasks atomically as a batch, same as
+	// AddTasksToQueue without skipping duplicates, and returns it.
+	// The completion task onComplete, if not nil, is inserted with
+	// status=waiting and becomes new exactly once, when the last
+	// task of the batch reaches a terminal status — done, canceled,
+	// attempts_left or moved to the dead letters. The completion
+	// task can't be a task of the batch, depend on other tasks or
+	// be depended on by the tasks of the batch (ErrInvalidBatch),
+	// the tasks are left intact then. A batch without tasks is
+	// completed at once. Honors a tx attached to ctx via WithTx.
+	// A batch is completed in the transaction finishing its last
+	// task, e.g. the caller's tx of CancelTask, so the completion
+	// task is queued only once that tx commits; on MySQL the check
+	// is made with locking reads and doesn't depend on the reads
+	// made earlier in the tx.
+	AddBatch(ctx context.Context, tasks []*Task, onComplete *Task) (*Batch, error)
+
+	// GetBatch returns the batch with the given ID along with the
+	// number of its done and failed tasks (see Batch.Progress) or
+	// an error if it is not found. The tasks removed from the queue
+	// or the dead letters are not counted. Honors a tx attached to
+	// ctx via WithTx.
+	GetBatch(ctx context.Context, batchID uuid.UUID) (*Batch, error)
+
+	// AddTaskToQueueAt enqueues task to be processed not earlier
+	// than runAt. A task scheduled in the future is inserted with
+	// status=new and next_attempt_at=runAt and becomes fetchable
+	// once runAt has passed; the healer does not touch it while it
+	// waits. A task with dependencies stays waiting and is processed
+	// once they are done and runAt has passed. Returns
+	// ErrInvalidSchedule if runAt is zero or the task is not new or
+	// waiting. Honors a tx attached to ctx via WithTx, same as
+	// AddTaskToQueue.
+	AddTaskToQueueAt(ctx context.Context, task *Task, runAt time.Time) error
+
+	// AddTaskToQueueAfter is AddTaskToQueueAt with runAt = now +
+	// delay. Returns ErrInvalidSchedule for a negative delay.
+	AddTaskToQueueAfter(ctx context.Context, task *Task, delay time.Duration) error
+
 	// GetTask returns the task with the given ID or an error if it
-	// is not found. Honors a tx attached to ctx via WithTx — read
-	// goes through the caller's tx if present.
+	// is not found. The task carries the progress and the checkpoint
+	// saved while it is processed. Honors a tx attached to ctx via
+	// WithTx — read goes through the caller's tx if present.
 	GetTask(ctx context.Context, taskID uuid.UUID) (*Task, error)
 
-	// GetTasks returns tasks matching filter up to limit. Honors a
-	// tx attached to ctx via WithTx.
+	// WaitForTask blocks until the task reaches a terminal status
+	// (done, canceled or attempts_left) and returns it; a task
+	// moved to the dead letters is found there. The task is polled
+	// every second, on PostgreSQL the wait is woken up by
+	// LISTEN/NOTIFY as soon as the processor finishes the task —
+	// all waiters of a manager share one listening connection.
+	// Returns ctx.Err() when ctx is done first and the not found
+	// error if the task doesn't exist.
+	WaitForTask(ctx context.Context, taskID uuid.UUID) (*Task, error)
+
+	// GetTaskResult waits for the task like WaitForTask and returns
+	// the JSON result stored by a ResultTaskProcessor, empty if the
+	// processor returned none. A task that ran out of attempts
+	// returns ErrTaskFailed and a canceled task ErrTaskCancel, both
+	// with the last task error. See GetTypedTaskResult.
+	GetTaskResult(ctx context.Context, taskID uuid.UUID) (string, error)
+
+	// GetTasks returns tasks matching filter up to limit, ordered
+	// by (created_at, id) — descending with filter.Desc. Pass
+	// NewTaskCursor(lastTask) as filter.After to fetch the next
+	// page. Honors a tx attached to ctx via WithTx.
 	GetTasks(ctx context.Context, filter *TaskFilter, limit int64) ([]*Task, error)
 
+	// Stats returns a per-type snapshot of the queue: task counts
+	// by status, the oldest next_attempt_at among tasks ready to
+	// be fetched (queue lag), the oldest task in processing and the
+	// average progress of the tasks in processing.
+	// The filter narrows the tasks taken into account (e.g. by
+	// type); nil means all tasks. Computed with a single GROUP BY
+	// query. Paused task types are flagged with TaskStats.Paused
+	// and listed even without tasks, unless the filter is for
+	// another type. Honors a tx attached to ctx via WithTx.
+	Stats(ctx context.Context, filter *TaskFilter) ([]*TaskStats, error)
+
 	// ResetAttempts clears the retry counter and sets the task back
 	// to status=new so it can be picked up again. Runs in its own
-	// internal tx and therefore ignores any tx in ctx.
+	// internal tx and therefore ignores any tx in ctx. A reset racing
+	// a concurrent state transition is retried on the latest state;
+	// ErrTaskStateConflict is returned if the task keeps changing.
 	ResetAttempts(ctx context.Context, taskID uuid.UUID) error
 
 	// CancelTask moves a non-terminal task to status=canceled.
 	// No-op if the task is already in a terminal state. Honors a
 	// tx attached to ctx via WithTx: both the read and the write
 	// participate in the caller's tx, so a rollback unwinds the
-	// cancel.
+	// cancel. A task being processed keeps the canceled status:
+	// its processing context is canceled with ErrTaskCancelRequested
+	// as the cause (see WithTaskCancelCheckPeriod). If the task changes
+	// concurrently, e.g. it is finished by a processor, the cancel is
+	// decided again on its latest state; ErrTaskStateConflict is
+	// returned if the task keeps changing.
 	CancelTask(ctx context.Context, taskID uuid.UUID) error
 
+	// CancelTasks moves non-terminal tasks matching filter to
+	// status=canceled with a single UPDATE and returns their
+	// number. Tasks being processed are interrupted the same way
+	// as with CancelTask.
+	// Returns ErrEmptyFilter for a filter without criteria.
+	// Honors a tx attached to ctx via WithTx.
+	CancelTasks(ctx context.Context, filter *TaskFilter) (int64, error)
+
+	// RetryTasks resets the retry counter of tasks matching filter
+	// and sets them back to status=new with next_attempt_at=now,
+	// with a single UPDATE, and returns their number. Tasks in
+	// flight (pending, processing) are skipped. Returns
+	// ErrEmptyFilter for a filter without criteria. Honors a tx
+	// attached to ctx via WithTx.
+	RetryTasks(ctx context.Context, filter *TaskFilter) (int64, error)
+
+	// DeleteTasks removes tasks matching filter with a single
+	// DELETE and returns their number. Returns ErrEmptyFilter for
+	// a filter without criteria. Honors a tx attached to ctx via
+	// WithTx.
+	DeleteTasks(ctx context.Context, filter *TaskFilter) (int64, error)
+
+	// GetDeadTasks returns tasks moved to the dead letters table
+	// (see WithDeadLetterQueue) matching filter up to limit, the
+	// most recently moved first; nil filter matches all dead tasks.
+	// Honors a tx attached to ctx via WithTx.
+	GetDeadTasks(ctx context.Context, filter *DeadTaskFilter, limit int64) ([]*Task, error)
+
+	// GetDeadTask returns the dead-lettered task with the given ID,
+	// including its errors history, or an error if it is not found.
+	GetDeadTask(ctx context.Context, taskID uuid.UUID) (*Task, error)
+
+	// RequeueDeadTasks moves dead-lettered tasks matching filter
+	// back to the queue with status=new and fresh attempts and
+	// returns them; nil filter matches all dead tasks. The move is
+	// atomic: if the queue already has a task with the same type
+	// and external ID, it fails with ErrDuplicateTask and nothing
+	// is requeued. Honors a tx attached to ctx via WithTx.
+	RequeueDeadTasks(ctx context.Context, filter *DeadTaskFilter) ([]*Task, error)
+
+	// PurgeDeadTasks deletes dead-lettered tasks matching filter
+	// and returns them; nil filter purges all dead tasks. Honors a
+	// tx attached to ctx via WithTx.
+	PurgeDeadTasks(ctx context.Context, filter *DeadTaskFilter) ([]*Task, error)
+
+	// PauseTaskType stops fetching tasks of the type by every
+	// processor in the cluster until ResumeTaskType is called. The
+	// state is persisted, so it survives restarts. Tasks already
+	// fetched are processed as usual, and new tasks can still be
+	// added. Pausing a paused type is a no-op. Honors a tx attached
+	// to ctx via WithTx.
+	PauseTaskType(ctx context.Context, taskType TaskType) error
+
+	// ResumeTaskType lets processors fetch tasks of the paused type
+	// again; they pick it up on their next fetch tick. Resuming a
+	// type that isn't paused is a no-op. Honors a tx attached to
+	// ctx via WithTx.
+	ResumeTaskType(ctx context.Context, taskType TaskType) error
+
+	// IsPaused reports whether the task type is paused. Honors a tx
+	// attached to ctx via WithTx.
+	IsPaused(ctx context.Context, taskType TaskType) (bool, error)
+
 	// WaitAsyncEnqueues blocks until every in-flight goroutine
 	// spawned by AsyncAddTaskToQueue has returned. Called
 	// automatically by Goque.Stop(); direct users of
@@ -56,6 +225,27 @@ type TaskQueueManager interface {
 	WaitAsyncEnqueues()
 }
 
+// GetTypedTaskResult waits for the task and decodes its result, see TaskQueueManager.GetTaskResult.
+// A task without a result yields the zero value of R.
+func GetTypedTaskResult[R any](ctx context.Context, manager TaskQueueManager, taskID uuid.UUID) (R, error) {
+	var result R
+	data, err := manager.GetTaskResult(ctx, taskID)
+	if err != nil || data == "" {
+		return result, err
+	}
+	if err := json.Unmarshal([]byte(data), &result); err != nil {
+		return result, fmt.Errorf("%w: task %s result: %w", ErrResultUnmarshal, taskID, err)
+	}
+
+	return result, nil
+}
+
+// AddTasksOpts is a functional option for configuring AddTasksToQueue.
+type AddTasksOpts = queuemanager.AddTasksOpts
+
+// WithSkipDuplicates makes AddTasksToQueue skip duplicate tasks instead of failing the whole batch.
+var WithSkipDuplicates = queuemanager.WithSkipDuplicates
+
 // NewTaskQueueManager creates a new TaskQueueManager instance with the specified task storage.
 func NewTaskQueueManager(taskStorage TaskStorage) TaskQueueManager {
 	return queuemanager.NewTaskQueueManager(taskStorage)
@@ -1,6 +1,8 @@
 package goque
 
 import (
+	"context"
+
 	"github.com/ruko1202/goque/internal/processors/queueprocessor"
 )
 
@@ -13,5 +15,82 @@ type TaskProcessorFunc = queueprocessor.TaskProcessorFunc
 // NoopTaskProcessor is a no-op task processor that does nothing and returns nil.
 var NoopTaskProcessor = queueprocessor.NoopTaskProcessor
 
+// ResultTaskProcessor defines the interface for processing tasks that return a result.
+// The result is stored with the done task, see TaskQueueManager.GetTaskResult.
+type ResultTaskProcessor = queueprocessor.ResultTaskProcessor
+
+// ResultTaskProcessorFunc is a function type that implements the ResultTaskProcessor interface.
+type ResultTaskProcessorFunc = queueprocessor.ResultTaskProcessorFunc
+
+// NewResultTaskProcessor wraps a result task processor for use with RegisterProcessor.
+var NewResultTaskProcessor = queueprocessor.NewResultTaskProcessor
+
+// Checkpoints of the task being processed, called with the context passed to ProcessTask.
+var (
+	// SaveCheckpoint saves the state of the processing to resume from on the next attempt
+	// along with the progress percentage of the task.
+	SaveCheckpoint = queueprocessor.SaveCheckpoint
+	// SaveProgress saves the progress percentage of the task, keeping its checkpoint.
+	SaveProgress = queueprocessor.SaveProgress
+)
+
+// LoadCheckpoint decodes the checkpoint saved by SaveCheckpoint during this or a previous attempt
+// of the task being processed. Reports false if the task has no checkpoint.
+func LoadCheckpoint[T any](ctx context.Context) (T, bool, error) {
+	return queueprocessor.LoadCheckpoint[T](ctx)
+}
+
 // ProcessorOpts is a function type for configuring GoqueProcessor options.
 type ProcessorOpts = queueprocessor.GoqueProcessorOpts
+
+// DeadLetterHandler is called once a task runs out of attempts, err is the last processing error.
+type DeadLetterHandler = queueprocessor.DeadLetterHandler
+
+// NextAttemptAtFunc calculates the next retry time based on the attempt number.
+// It implements BackoffStrategy.
+type NextAttemptAtFunc = queueprocessor.NextAttemptAtFunc
+
+// BackoffStrategy calculates the next retry time of a failed task from the task and its error.
+type BackoffStrategy = queueprocessor.BackoffStrategy
+
+// BackoffStrategyFunc is a function type that implements the BackoffStrategy interface.
+type BackoffStrategyFunc = queueprocessor.BackoffStrategyFunc
+
+// Middleware wraps a task processor, e.g. to change the processing context, short-circuit
+// the processing or handle its result. See Goque.Use and WithMiddlewares.
+type Middleware = queueprocessor.Middleware
+
+// Built-in middlewares.
+var (
+	// RecoveryMiddleware turns a panic of the processing into a task error wrapping ErrTaskPanic.
+	RecoveryMiddleware = queueprocessor.RecoveryMiddleware
+	// LoggingMiddleware logs the start and the end of the processing with its duration.
+	LoggingMiddleware = queueprocessor.LoggingMiddleware
+	// TracingMiddleware runs the processing in its own span with the task attributes, recording the error.
+	TracingMiddleware = queueprocessor.TracingMiddleware
+)
+
+// Built-in backoff strategies.
+var (
+	// ExponentialJitterBackoff creates an exponential backoff strategy with full jitter capped by maxDelay.
+	ExponentialJitterBackoff = queueprocessor.ExponentialJitterBackoff
+	// DecorrelatedJitterBackoff creates a backoff strategy with a delay random between base
+	// and three times the previous delay of the task, capped by maxDelay.
+	DecorrelatedJitterBackoff = queueprocessor.DecorrelatedJitterBackoff
+	// ExponentialRangeJitterBackoff creates an exponential backoff strategy with jitter above base capped by maxDelay.
+	ExponentialRangeJitterBackoff = queueprocessor.ExponentialRangeJitterBackoff
+)
+
+// SharedWorkerPool is a worker pool shared by the processors of several task types, see WithSharedWorkerPool.
+type SharedWorkerPool = queueprocessor.SharedWorkerPool
+
+// SharedWorkerPoolOpts is a function type for configuring SharedWorkerPool options.
+type SharedWorkerPoolOpts = queueprocessor.SharedWorkerPoolOpts
+
+// Shared worker pool constructor and options.
+var (
+	// NewSharedWorkerPool creates a worker pool of the given size to be shared by processors.
+	NewSharedWorkerPool = queueprocessor.NewSharedWorkerPool
+	// WithSharedWorkerPoolFetchTick sets how often the scheduler fetches tasks for the free workers.
+	WithSharedWorkerPoolFetchTick = queueprocessor.WithSharedWorkerPoolFetchTick
+)
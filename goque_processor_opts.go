@@ -12,20 +12,42 @@ var (
 	WithTaskFetcherTick = queueprocessor.WithTaskFetcherTick
 	// WithTaskFetcherTimeout sets the timeout for fetching tasks from storage.
 	WithTaskFetcherTimeout = queueprocessor.WithTaskFetcherTimeout
+	// WithTaskFetcherListenNotify enables immediate fetching of new tasks via PostgreSQL LISTEN/NOTIFY.
+	WithTaskFetcherListenNotify = queueprocessor.WithTaskFetcherListenNotify
+	// WithTaskFetcherRateLimit limits fetching to tokens tasks per interval across all processors of the task type.
+	WithTaskFetcherRateLimit = queueprocessor.WithTaskFetcherRateLimit
+	// WithConcurrencyLimitPerKey limits the number of tasks in flight per concurrency key across all processors.
+	WithConcurrencyLimitPerKey = queueprocessor.WithConcurrencyLimitPerKey
+	// WithTaskLeaseDuration sets how long the processor owns the fetched tasks without renewal.
+	WithTaskLeaseDuration = queueprocessor.WithTaskLeaseDuration
+	// WithTaskLeaseOwner sets the lease owner stamped on the fetched tasks.
+	WithTaskLeaseOwner = queueprocessor.WithTaskLeaseOwner
 )
 
 // Worker and task processing configuration options.
 var (
 	// WithWorkersCount sets the number of concurrent workers for processing tasks.
 	WithWorkersCount = queueprocessor.WithWorkersCount
+	// WithWorkersAutoscale tunes the number of workers between minWorkers and maxWorkers by the load.
+	WithWorkersAutoscale = queueprocessor.WithWorkersAutoscale
+	// WithSharedWorkerPool runs the processor on a worker pool shared with other task types, weighted for fairness.
+	WithSharedWorkerPool = queueprocessor.WithSharedWorkerPool
 	// WithWorkersPanicHandler sets a custom panic handler for worker goroutines.
 	WithWorkersPanicHandler = queueprocessor.WithWorkersPanicHandler
+	// WithMiddlewares wraps the task processor with middlewares, the first one being the outermost.
+	WithMiddlewares = queueprocessor.WithMiddlewares
 	// WithTaskProcessingTimeout sets the timeout for processing a single task.
+	// A task created with WithTaskTimeout overrides it.
 	WithTaskProcessingTimeout = queueprocessor.WithTaskProcessingTimeout
 	// WithTaskProcessingMaxAttempts sets the maximum number of retry attempts for failed tasks.
+	// A task created with WithTaskMaxAttempts overrides it.
 	WithTaskProcessingMaxAttempts = queueprocessor.WithTaskProcessingMaxAttempts
 	// WithTaskProcessingNextAttemptAtFunc sets a custom function to calculate the next retry time.
 	WithTaskProcessingNextAttemptAtFunc = queueprocessor.WithTaskProcessingNextAttemptAtFunc
+	// WithTaskProcessingBackoff sets a backoff strategy to calculate the next retry time from the task and its error.
+	WithTaskProcessingBackoff = queueprocessor.WithTaskProcessingBackoff
+	// WithTaskCancelCheckPeriod sets how often in-flight tasks are checked for cancel requests.
+	WithTaskCancelCheckPeriod = queueprocessor.WithTaskCancelCheckPeriod
 )
 
 // Hook configuration options for task processing.
@@ -36,6 +58,14 @@ var (
 	WithHooksAfterProcessing = queueprocessor.WithHooksAfterProcessing
 )
 
+// Dead letter configuration options for tasks that ran out of attempts.
+var (
+	// WithDeadLetterQueue moves tasks that ran out of attempts to the dead letters table.
+	WithDeadLetterQueue = queueprocessor.WithDeadLetterQueue
+	// WithDeadLetterHandler sets a callback called once a task runs out of attempts.
+	WithDeadLetterHandler = queueprocessor.WithDeadLetterHandler
+)
+
 // Cleaner configuration options for removing old tasks.
 var (
 	// WithCleanerUpdatedAtTimeAgo sets the age threshold for tasks to be cleaned.
@@ -48,7 +78,7 @@ var (
 
 // Healer configuration options for fixing stuck tasks.
 var (
-	// WithHealerUpdatedAtTimeAgo sets the age threshold for tasks to be healed.
+	// WithHealerUpdatedAtTimeAgo sets the age threshold for tasks fetched without a lease to be healed.
 	WithHealerUpdatedAtTimeAgo = queueprocessor.WithHealerUpdatedAtTimeAgo
 	// WithHealerTimeout sets the timeout for the healer operation.
 	WithHealerTimeout = queueprocessor.WithHealerTimeout
@@ -15,3 +15,15 @@ type TypedTaskProcessorOpt[T any] = queueprocessor.GoqueTypedProcessorOpts[T]
 func NewTypedTaskProcessor[T any](processor TypedTaskProcessor[T], opts ...TypedTaskProcessorOpt[T]) TaskProcessor {
 	return queueprocessor.NewTypedTaskProcessor[T](processor, opts...)
 }
+
+// TypedResultTaskProcessor defines the interface for processing typed task payloads returning a typed result.
+type TypedResultTaskProcessor[T, R any] = queueprocessor.TypedResultTaskProcessor[T, R]
+
+// TypedResultTaskProcessorFunc is a function type that implements the TypedResultTaskProcessor interface.
+type TypedResultTaskProcessorFunc[T, R any] = queueprocessor.TypedResultTaskProcessorFunc[T, R]
+
+// NewTypedResultTaskProcessor wraps a typed result task processor for use with RegisterProcessor.
+// The result is stored with the done task, see GetTypedTaskResult.
+func NewTypedResultTaskProcessor[T, R any](processor TypedResultTaskProcessor[T, R], opts ...TypedTaskProcessorOpt[T]) TaskProcessor {
+	return queueprocessor.NewTypedResultTaskProcessor[T, R](processor, opts...)
+}
@@ -0,0 +1,100 @@
+package entity
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/ruko1202/goque/internal/utils/xtime"
+)
+
+// Batch is a group of tasks added to the queue at once along with a completion task,
+// which is queued exactly once when the last task of the batch reaches a terminal status.
+type Batch struct {
+	ID uuid.UUID
+	// Total is the number of tasks in the batch.
+	Total int64
+	// Done is the number of tasks of the batch processed successfully.
+	Done int64
+	// Failed is the number of tasks of the batch canceled or run out of attempts, including the dead-lettered ones.
+	Failed int64
+	// CompletionTaskID is the task queued when the batch is completed, nil if there is none.
+	CompletionTaskID *uuid.UUID
+	CreatedAt        time.Time
+	// CompletedAt is the moment the last task of the batch reached a terminal status.
+	CompletedAt *time.Time
+}
+
+// NewBatch creates a batch of the tasks and assigns them to it. The completion task onComplete,
+// if any, waits in the waiting status until the batch is completed. A batch without tasks
+// is completed at once and its completion task is ready to run.
+// The completion task can't be a task of the batch, depend on other tasks or be depended on
+// by the tasks of the batch; the tasks are left intact then.
+func NewBatch(tasks []*Task, onComplete *Task) (*Batch, error) {
+	if err := validateBatch(tasks, onComplete); err != nil {
+		return nil, err
+	}
+
+	now := xtime.Now()
+	batch := &Batch{
+		ID:        newUUID(),
+		Total:     int64(len(tasks)),
+		CreatedAt: now,
+	}
+	if len(tasks) == 0 {
+		batch.CompletedAt = &now
+	}
+
+	for _, task := range tasks {
+		task.BatchID = &batch.ID
+	}
+	if onComplete != nil {
+		batch.CompletionTaskID = &onComplete.ID
+		if len(tasks) > 0 {
+			onComplete.Status = TaskStatusWaiting
+		}
+	}
+
+	return batch, nil
+}
+
+// validateBatch checks that the completion task can run once the batch is completed.
+func validateBatch(tasks []*Task, onComplete *Task) error {
+	if onComplete == nil {
+		return nil
+	}
+	if len(onComplete.DependsOn) > 0 {
+		return fmt.Errorf("%w: completion task %s depends on other tasks", ErrInvalidBatch, onComplete.ID)
+	}
+	for _, task := range tasks {
+		if task.ID == onComplete.ID {
+			return fmt.Errorf("%w: completion task %s is a task of the batch", ErrInvalidBatch, onComplete.ID)
+		}
+		if slices.Contains(task.DependsOn, onComplete.ID) {
+			return fmt.Errorf("%w: task %s depends on completion task %s", ErrInvalidBatch, task.ID, onComplete.ID)
+		}
+	}
+
+	return nil
+}
+
+// IsCompleted reports whether all the tasks of the batch reached a terminal status.
+func (b *Batch) IsCompleted() bool {
+	return b.CompletedAt != nil
+}
+
+// Finished returns the number of tasks of the batch in a terminal status.
+func (b *Batch) Finished() int64 {
+	return b.Done + b.Failed
+}
+
+// Progress returns the percentage of the finished tasks of the batch, from 0 to MaxTaskProgress.
+func (b *Batch) Progress() float64 {
+	if b.Total == 0 || b.IsCompleted() {
+		return MaxTaskProgress
+	}
+
+	return min(float64(b.Finished())*MaxTaskProgress/float64(b.Total), MaxTaskProgress)
+}
@@ -0,0 +1,97 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBatch(t *testing.T) {
+	t.Parallel()
+
+	t.Run("with tasks", func(t *testing.T) {
+		t.Parallel()
+
+		tasks := []*Task{NewTask("resize", `{}`), NewTask("resize", `{}`)}
+		onComplete := NewTask("notify", `{}`)
+
+		batch, err := NewBatch(tasks, onComplete)
+		require.NoError(t, err)
+		require.EqualValues(t, 2, batch.Total)
+		require.Equal(t, &onComplete.ID, batch.CompletionTaskID)
+		require.False(t, batch.IsCompleted())
+		for _, task := range tasks {
+			require.Equal(t, &batch.ID, task.BatchID)
+			require.Equal(t, TaskStatusNew, task.Status)
+		}
+		require.Nil(t, onComplete.BatchID)
+		require.Equal(t, TaskStatusWaiting, onComplete.Status)
+	})
+
+	t.Run("without tasks", func(t *testing.T) {
+		t.Parallel()
+
+		onComplete := NewTask("notify", `{}`)
+
+		batch, err := NewBatch(nil, onComplete)
+		require.NoError(t, err)
+		require.True(t, batch.IsCompleted())
+		require.Equal(t, TaskStatusNew, onComplete.Status)
+		require.InDelta(t, MaxTaskProgress, batch.Progress(), 0.001)
+	})
+
+	t.Run("without completion task", func(t *testing.T) {
+		t.Parallel()
+
+		batch, err := NewBatch([]*Task{NewTask("resize", `{}`)}, nil)
+		require.NoError(t, err)
+		require.Nil(t, batch.CompletionTaskID)
+	})
+
+	t.Run("invalid completion task", func(t *testing.T) {
+		t.Parallel()
+
+		onComplete := NewTask("notify", `{}`)
+		testCases := map[string]struct {
+			tasks      []*Task
+			onComplete *Task
+		}{
+			"completion task with dependencies": {
+				tasks:      []*Task{NewTask("resize", `{}`)},
+				onComplete: NewTask("notify", `{}`, WithTaskDependsOn(NewTask("resize", `{}`).ID)),
+			},
+			"completion task in the batch": {
+				tasks:      []*Task{NewTask("resize", `{}`), onComplete},
+				onComplete: onComplete,
+			},
+			"task depending on the completion task": {
+				tasks:      []*Task{NewTask("resize", `{}`), NewTask("resize", `{}`, WithTaskDependsOn(onComplete.ID))},
+				onComplete: onComplete,
+			},
+		}
+
+		for name, tt := range testCases {
+			t.Run(name, func(t *testing.T) {
+				t.Parallel()
+
+				batch, err := NewBatch(tt.tasks, tt.onComplete)
+				require.ErrorIs(t, err, ErrInvalidBatch)
+				require.Nil(t, batch)
+				for _, task := range tt.tasks {
+					require.Nil(t, task.BatchID, "the tasks are left intact")
+				}
+			})
+		}
+	})
+}
+
+func TestBatch_Progress(t *testing.T) {
+	t.Parallel()
+
+	batch := &Batch{Total: 8, Done: 3, Failed: 1}
+	require.EqualValues(t, 4, batch.Finished())
+	require.InDelta(t, 50, batch.Progress(), 0.001)
+
+	batch.CompletedAt = &batch.CreatedAt
+	require.InDelta(t, MaxTaskProgress, batch.Progress(), 0.001)
+}
@@ -13,9 +13,40 @@ var (
 	ErrPayloadUnmarshal = errors.New("payload unmarshal")
 	// ErrPayloadMarshal is returned when a typed task payload cannot be marshaled to JSON.
 	ErrPayloadMarshal = errors.New("payload marshal")
+	// ErrResultMarshal is returned when a task result cannot be marshaled to JSON.
+	ErrResultMarshal = errors.New("result marshal")
+	// ErrResultUnmarshal is returned when a typed task result cannot be unmarshaled from JSON.
+	ErrResultUnmarshal = errors.New("result unmarshal")
+	// ErrCheckpointMarshal is returned when a task checkpoint cannot be marshaled to JSON.
+	ErrCheckpointMarshal = errors.New("checkpoint marshal")
+	// ErrCheckpointUnmarshal is returned when a task checkpoint cannot be unmarshaled from JSON.
+	ErrCheckpointUnmarshal = errors.New("checkpoint unmarshal")
+	// ErrInvalidSchedule is returned when a task cannot be scheduled for the requested time.
+	ErrInvalidSchedule = errors.New("invalid task schedule")
+	// ErrTaskDependencyNotFound is returned when a task depends on a task that is not in the queue.
+	ErrTaskDependencyNotFound = errors.New("task dependency not found")
+	// ErrTaskDependencyCycle is returned when the tasks added at once depend on each other in a cycle.
+	ErrTaskDependencyCycle = errors.New("task dependency cycle")
+	// ErrTaskDependencyFailed is the reason a waiting task is canceled with:
+	// a task it depends on was canceled or ran out of attempts.
+	ErrTaskDependencyFailed = errors.New("task dependency failed")
+	// ErrInvalidBatch is returned when a task batch can't be added to the queue.
+	ErrInvalidBatch = errors.New("invalid task batch")
 
 	// ErrTaskCancel is returned when a task is canceled during processing.
 	ErrTaskCancel = errors.New("task canceled")
+	// ErrTaskCancelRequested is the cause of the processing context when the task is canceled
+	// by a user while it is being processed.
+	ErrTaskCancelRequested = errors.New("task cancel requested")
+	// ErrTaskStateConflict is returned when a task was changed concurrently since it was read,
+	// so the requested state transition is rejected.
+	ErrTaskStateConflict = errors.New("task state conflict")
 	// ErrTaskTimeout is returned when task processing exceeds the timeout limit.
 	ErrTaskTimeout = errors.New("task processing timeout")
+	// ErrTaskPanic is returned when task processing panics and the panic is recovered.
+	ErrTaskPanic = errors.New("task processing panic")
+	// ErrNoTaskInContext is returned when a processing-only operation is called without a task being processed.
+	ErrNoTaskInContext = errors.New("no task in context")
+	// ErrTaskFailed is returned for a task waited for that ran out of attempts.
+	ErrTaskFailed = errors.New("task failed")
 )
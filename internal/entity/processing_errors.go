@@ -0,0 +1,65 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+)
+
+// PermanentError marks a processing error that retrying can't fix.
+// The task fails right away regardless of the attempts left.
+type PermanentError struct {
+	Err error
+}
+
+// Permanent wraps err so the task fails right away without retries.
+func Permanent(err error) error {
+	return &PermanentError{Err: err}
+}
+
+// Error implements the error interface.
+func (e *PermanentError) Error() string {
+	return fmt.Sprintf("permanent: %v", e.Err)
+}
+
+// Unwrap returns the wrapped error.
+func (e *PermanentError) Unwrap() error {
+	return e.Err
+}
+
+// RetryAfterError marks a processing error that should be retried after Delay
+// instead of the delay computed by the processor backoff. The attempt is counted.
+type RetryAfterError struct {
+	Err   error
+	Delay time.Duration
+}
+
+// RetryAfter wraps err so the task is retried after the delay, e.g. taken from a Retry-After header.
+func RetryAfter(err error, delay time.Duration) error {
+	return &RetryAfterError{Err: err, Delay: delay}
+}
+
+// Error implements the error interface.
+func (e *RetryAfterError) Error() string {
+	return fmt.Sprintf("%v (retry after %s)", e.Err, e.Delay)
+}
+
+// Unwrap returns the wrapped error.
+func (e *RetryAfterError) Unwrap() error {
+	return e.Err
+}
+
+// SnoozeError reschedules the task to be processed again after Delay.
+// It is not a failure: the attempt is not counted.
+type SnoozeError struct {
+	Delay time.Duration
+}
+
+// Snooze reschedules the task after the delay without counting an attempt.
+func Snooze(delay time.Duration) error {
+	return &SnoozeError{Delay: delay}
+}
+
+// Error implements the error interface.
+func (e *SnoozeError) Error() string {
+	return fmt.Sprintf("snoozed for %s", e.Delay)
+}
@@ -0,0 +1,46 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcessingErrors(t *testing.T) {
+	t.Parallel()
+
+	cause := errors.New("boom")
+
+	t.Run("permanent", func(t *testing.T) {
+		t.Parallel()
+		err := Permanent(cause)
+
+		var permanentErr *PermanentError
+		require.ErrorAs(t, err, &permanentErr)
+		require.ErrorIs(t, err, cause)
+		require.EqualError(t, err, "permanent: boom")
+	})
+
+	t.Run("retry after", func(t *testing.T) {
+		t.Parallel()
+		err := RetryAfter(cause, time.Minute)
+
+		var retryAfterErr *RetryAfterError
+		require.ErrorAs(t, err, &retryAfterErr)
+		require.Equal(t, time.Minute, retryAfterErr.Delay)
+		require.ErrorIs(t, err, cause)
+		require.EqualError(t, err, "boom (retry after 1m0s)")
+	})
+
+	t.Run("snooze", func(t *testing.T) {
+		t.Parallel()
+		err := Snooze(time.Minute)
+
+		var snoozeErr *SnoozeError
+		require.ErrorAs(t, err, &snoozeErr)
+		require.Equal(t, time.Minute, snoozeErr.Delay)
+		require.EqualError(t, err, "snoozed for 1m0s")
+	})
+}
@@ -0,0 +1,30 @@
+package entity
+
+import "time"
+
+// RateLimit is a token bucket limiting how many tasks are fetched per interval.
+// The bucket is stored in the database, so the limit is shared by all processors of the queue.
+type RateLimit struct {
+	// Tokens is the number of tasks allowed per Interval.
+	Tokens int64
+	// Interval is the period the bucket is refilled with Tokens over.
+	Interval time.Duration
+	// Burst is the maximum number of tokens the bucket accumulates while idle, Tokens if not positive.
+	Burst int64
+}
+
+// Capacity returns the maximum number of tokens the bucket holds.
+func (l RateLimit) Capacity() float64 {
+	if l.Burst > 0 {
+		return float64(l.Burst)
+	}
+	return float64(l.Tokens)
+}
+
+// Refill returns the number of tokens in the bucket that held tokens elapsed ago, capped by the capacity.
+func (l RateLimit) Refill(tokens float64, elapsed time.Duration) float64 {
+	if elapsed > 0 && l.Interval > 0 {
+		tokens += float64(l.Tokens) * elapsed.Seconds() / l.Interval.Seconds()
+	}
+	return min(tokens, l.Capacity())
+}
@@ -0,0 +1,36 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimit_Refill(t *testing.T) {
+	t.Parallel()
+
+	limit := RateLimit{Tokens: 100, Interval: time.Second, Burst: 10}
+
+	t.Run("capacity", func(t *testing.T) {
+		t.Parallel()
+		require.InDelta(t, 10, limit.Capacity(), 0)
+		require.InDelta(t, 100, RateLimit{Tokens: 100, Interval: time.Second}.Capacity(), 0)
+	})
+
+	t.Run("refills proportionally to elapsed time", func(t *testing.T) {
+		t.Parallel()
+		require.InDelta(t, 5, limit.Refill(0, 50*time.Millisecond), 1e-9)
+		require.InDelta(t, 7.5, limit.Refill(2.5, 50*time.Millisecond), 1e-9)
+	})
+
+	t.Run("capped by burst", func(t *testing.T) {
+		t.Parallel()
+		require.InDelta(t, 10, limit.Refill(0, time.Hour), 0)
+	})
+
+	t.Run("clock going backwards", func(t *testing.T) {
+		t.Parallel()
+		require.InDelta(t, 3, limit.Refill(3, -time.Second), 0)
+	})
+}
@@ -0,0 +1,45 @@
+package entity
+
+import "time"
+
+// TaskStats is a snapshot of the queue state for a single task type.
+type TaskStats struct {
+	TaskType TaskType
+	// Counts holds the number of tasks by status. Statuses without tasks are absent.
+	Counts map[TaskStatus]int64
+	// OldestRunnableAt is the earliest next_attempt_at among the tasks ready to be fetched,
+	// nil if there are none. The time passed since it is the queue lag.
+	OldestRunnableAt *time.Time
+	// OldestProcessingAt is the earliest time a task still in processing was picked up by a worker,
+	// nil if no task is in processing.
+	OldestProcessingAt *time.Time
+	// ProcessingProgress is the average progress of the tasks in processing, from 0 to MaxTaskProgress.
+	ProcessingProgress float64
+	// Paused reports whether fetching the tasks of the type is paused across the cluster.
+	Paused bool
+}
+
+// Total returns the number of tasks of all statuses.
+func (s *TaskStats) Total() int64 {
+	var total int64
+	for _, count := range s.Counts {
+		total += count
+	}
+	return total
+}
+
+// QueueLag returns how long the oldest runnable task has been waiting at the moment now.
+func (s *TaskStats) QueueLag(now time.Time) time.Duration {
+	if s.OldestRunnableAt == nil {
+		return 0
+	}
+	return max(now.Sub(*s.OldestRunnableAt), 0)
+}
+
+// OldestProcessingAge returns how long the oldest task in processing has been running at the moment now.
+func (s *TaskStats) OldestProcessingAge(now time.Time) time.Duration {
+	if s.OldestProcessingAt == nil {
+		return 0
+	}
+	return max(now.Sub(*s.OldestProcessingAt), 0)
+}
@@ -3,6 +3,8 @@ package entity
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -23,6 +25,8 @@ const (
 	TaskStatusNew = "new"
 	// TaskStatusPending is a task waiting to be processed.
 	TaskStatusPending = "pending"
+	// TaskStatusWaiting is a task waiting for the tasks it depends on to be done.
+	TaskStatusWaiting = "waiting"
 	// TaskStatusProcessing is a task in progress.
 	TaskStatusProcessing = "processing"
 	// TaskStatusDone is a task that was processed successfully.
@@ -38,12 +42,16 @@ const (
 // NoTaskPayload is an empty JSON object payload for tasks without input data.
 const NoTaskPayload = "{}"
 
+// MaxTaskProgress is the progress of a completed task.
+const MaxTaskProgress = 100
+
 // Task represents a unit of work in the queue system.
 type Task struct {
 	ID            uuid.UUID
 	Type          TaskType
 	ExternalID    string
 	Payload       string
+	Priority      int32
 	Status        TaskStatus
 	Attempts      int32
 	Errors        *string
@@ -51,15 +59,113 @@ type Task struct {
 	CreatedAt     time.Time
 	UpdatedAt     *time.Time
 	NextAttemptAt time.Time
+	// LockedBy is the processor that fetched the task last. It owns the task
+	// while the task is pending or processing.
+	LockedBy *string
+	// LeaseExpiresAt is the moment the ownership ends unless the owner renews it.
+	LeaseExpiresAt *time.Time
+	// Version is bumped by every state transition of the task. A transition made from
+	// a stale version is rejected with ErrTaskStateConflict.
+	Version int64
+	// Timeout overrides the processing timeout of the processor for this task.
+	Timeout *time.Duration
+	// MaxAttempts overrides the maximum number of attempts of the processor for this task.
+	MaxAttempts *int32
+	// ConcurrencyKey groups tasks of any type limited by the processor concurrency limit per key,
+	// e.g. a customer ID. Tasks without a key are not limited.
+	ConcurrencyKey *string
+	// Result is the JSON value returned by the processing, set when the task is done.
+	Result *string
+	// Checkpoint is the JSON state saved by the processing to resume from on the next attempt.
+	// It is cleared when the task is done.
+	Checkpoint *string
+	// Progress is the completion percentage of the task reported by the processing, from 0 to MaxTaskProgress.
+	Progress int32
+	// DependsOn lists the tasks that must be done before this one is processed. It is saved
+	// when the task is added and not loaded back, see TaskStatusWaiting.
+	DependsOn []uuid.UUID
+	// BatchID is the batch the task belongs to, see Batch.
+	BatchID *uuid.UUID
+	// RetryDelay is the delay the task waited for after its last failed attempt.
+	RetryDelay *time.Duration
+}
+
+// TaskLease describes the ownership a processor takes on the tasks it fetches.
+type TaskLease struct {
+	// Owner identifies the processor instance.
+	Owner string
+	// Duration is how long the ownership lasts without renewal.
+	Duration time.Duration
+}
+
+// TaskOpts configures optional task attributes on creation.
+type TaskOpts func(*Task)
+
+// WithTaskPriority sets the task priority. Tasks with a higher priority are fetched first.
+func WithTaskPriority(priority int32) TaskOpts {
+	return func(t *Task) {
+		t.Priority = priority
+	}
+}
+
+// WithTaskTimeout overrides the processing timeout of the processor for the task.
+// A non-positive timeout keeps the processor default.
+func WithTaskTimeout(timeout time.Duration) TaskOpts {
+	return func(t *Task) {
+		t.Timeout = nil
+		if timeout > 0 {
+			t.Timeout = &timeout
+		}
+	}
+}
+
+// WithTaskMaxAttempts overrides the maximum number of attempts of the processor for the task.
+// A non-positive number keeps the processor default.
+func WithTaskMaxAttempts(maxAttempts int32) TaskOpts {
+	return func(t *Task) {
+		t.MaxAttempts = nil
+		if maxAttempts > 0 {
+			t.MaxAttempts = &maxAttempts
+		}
+	}
+}
+
+// WithTaskConcurrencyKey sets the concurrency key of the task. An empty key removes it.
+func WithTaskConcurrencyKey(key string) TaskOpts {
+	return func(t *Task) {
+		t.ConcurrencyKey = lo.EmptyableToPtr(key)
+	}
+}
+
+// WithTaskRunAt schedules the task to be processed not earlier than runAt.
+func WithTaskRunAt(runAt time.Time) TaskOpts {
+	return func(t *Task) {
+		t.ScheduleAt(runAt)
+	}
+}
+
+// WithTaskDelay schedules the task to be processed after the delay passes.
+func WithTaskDelay(delay time.Duration) TaskOpts {
+	return func(t *Task) {
+		t.ScheduleAt(t.CreatedAt.Add(delay))
+	}
+}
+
+// WithTaskDependsOn makes the task wait until the tasks with the given IDs are done.
+// The task is canceled if any of them is canceled or runs out of attempts.
+func WithTaskDependsOn(taskIDs ...uuid.UUID) TaskOpts {
+	return func(t *Task) {
+		t.DependsOn = append(t.DependsOn, taskIDs...)
+	}
 }
 
 // NewTask creates a new task with the specified type and payload.
-func NewTask(taskType TaskType, payload string) *Task {
-	return NewTaskWithExternalID(taskType, payload, "")
+func NewTask(taskType TaskType, payload string, opts ...TaskOpts) *Task {
+	return NewTaskWithExternalID(taskType, payload, "", opts...)
 }
 
 // NewTaskWithExternalID creates a new task with a custom external ID.
-func NewTaskWithExternalID(taskType, payload, externalID string) *Task {
+func NewTaskWithExternalID(taskType, payload, externalID string, opts ...TaskOpts) *Task {
 	if externalID == "" {
 		externalID = "internal-" + uuid.NewString()
 	}
@@ -75,6 +181,13 @@ func NewTaskWithExternalID(taskType, payload, externalID string) *Task {
 		NextAttemptAt: now,
 	}
 
+	for _, opt := range opts {
+		opt(task)
+	}
+	if len(task.DependsOn) > 0 {
+		task.Status = TaskStatusWaiting
+	}
+
 	return task
 }
 
@@ -88,14 +201,28 @@ func (t *Task) AddError(err error) {
 	t.Errors = &taskErr
 }
 
+// LastError returns the error of the last failed attempt, empty if the task has no errors.
+func (t *Task) LastError() string {
+	lines := strings.Split(strings.TrimRight(lo.FromPtr(t.Errors), "\n"), "\n")
+	return lines[len(lines)-1]
+}
+
+// ScheduleAt defers a new task until runAt.
+// The task stays new and is fetched for processing once runAt has passed.
+// A task with dependencies keeps waiting for them and is not processed before runAt either.
+func (t *Task) ScheduleAt(runAt time.Time) {
+	t.NextAttemptAt = runAt
+	t.Status = lo.Ternary(len(t.DependsOn) > 0, TaskStatusWaiting, TaskStatusNew)
+}
+
 // IsInTerminalState reports whether the task is in a terminal status.
 func (t *Task) IsInTerminalState() bool {
-	switch t.Status {
-	case TaskStatusDone, TaskStatusCanceled, TaskStatusAttemptsLeft:
-		return true
-	default:
-		return false
-	}
+	return slices.Contains(TerminalStatuses(), t.Status)
+}
+
+// TerminalStatuses returns the statuses of tasks that will not be processed again.
+func TerminalStatuses() []TaskStatus {
+	return []TaskStatus{TaskStatusDone, TaskStatusCanceled, TaskStatusAttemptsLeft}
 }
 
 func newUUID() uuid.UUID {
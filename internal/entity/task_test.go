@@ -3,6 +3,7 @@ package entity
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
@@ -23,9 +24,102 @@ func TestNewTask(t *testing.T) {
 	require.Equal(t, task.CreatedAt, task.NextAttemptAt, "NextAttemptAt should default to CreatedAt")
 	require.Nil(t, task.UpdatedAt)
 	require.Equal(t, int32(0), task.Attempts)
+	require.Equal(t, int32(0), task.Priority)
 	require.Nil(t, task.Errors)
 }
 
+func TestNewTask_WithTaskPriority(t *testing.T) {
+	t.Parallel()
+
+	task := NewTask("email", `{}`, WithTaskPriority(10))
+	require.Equal(t, int32(10), task.Priority)
+
+	task = NewTaskWithExternalID("email", `{}`, "order-42", WithTaskPriority(-5))
+	require.Equal(t, int32(-5), task.Priority)
+	require.Equal(t, "order-42", task.ExternalID)
+}
+
+func TestNewTask_WithTaskLimits(t *testing.T) {
+	t.Parallel()
+
+	task := NewTask("export", `{}`, WithTaskTimeout(time.Hour), WithTaskMaxAttempts(2))
+	require.Equal(t, time.Hour, *task.Timeout)
+	require.Equal(t, int32(2), *task.MaxAttempts)
+
+	task = NewTask("export", `{}`, WithTaskTimeout(0), WithTaskMaxAttempts(-1))
+	require.Nil(t, task.Timeout, "non-positive timeout keeps the processor default")
+	require.Nil(t, task.MaxAttempts, "non-positive max attempts keeps the processor default")
+}
+
+func TestNewTask_WithTaskConcurrencyKey(t *testing.T) {
+	t.Parallel()
+
+	task := NewTask("webhook", `{}`, WithTaskConcurrencyKey("customer-1"))
+	require.Equal(t, "customer-1", *task.ConcurrencyKey)
+
+	task = NewTask("webhook", `{}`, WithTaskConcurrencyKey(""))
+	require.Nil(t, task.ConcurrencyKey, "empty key leaves the task unlimited")
+}
+
+func TestNewTask_Scheduled(t *testing.T) {
+	t.Parallel()
+
+	t.Run("run at in the future keeps the task new", func(t *testing.T) {
+		t.Parallel()
+		runAt := time.Now().Add(time.Hour)
+		task := NewTask("email", `{}`, WithTaskRunAt(runAt))
+		require.Equal(t, TaskStatusNew, task.Status)
+		require.Equal(t, runAt, task.NextAttemptAt)
+	})
+
+	t.Run("run at in the past keeps the task new", func(t *testing.T) {
+		t.Parallel()
+		runAt := time.Now().Add(-time.Hour)
+		task := NewTask("email", `{}`, WithTaskRunAt(runAt))
+		require.Equal(t, TaskStatusNew, task.Status)
+		require.Equal(t, runAt, task.NextAttemptAt)
+	})
+
+	t.Run("delay is counted from the creation time", func(t *testing.T) {
+		t.Parallel()
+		task := NewTask("email", `{}`, WithTaskDelay(time.Minute))
+		require.Equal(t, TaskStatusNew, task.Status)
+		require.Equal(t, task.CreatedAt.Add(time.Minute), task.NextAttemptAt)
+	})
+}
+
+func TestNewTask_WithTaskDependsOn(t *testing.T) {
+	t.Parallel()
+
+	dependsOn := []uuid.UUID{uuid.New(), uuid.New()}
+	runAt := time.Now().Add(time.Hour)
+	task := NewTask("email", `{}`, WithTaskRunAt(runAt), WithTaskDependsOn(dependsOn...))
+	require.Equal(t, TaskStatusWaiting, task.Status, "dependencies outweigh the schedule")
+	require.Equal(t, dependsOn, task.DependsOn)
+	require.Equal(t, runAt, task.NextAttemptAt)
+
+	task.ScheduleAt(runAt)
+	require.Equal(t, TaskStatusWaiting, task.Status)
+}
+
+func TestWorkflow(t *testing.T) {
+	t.Parallel()
+
+	queued := NewTask("email", `{}`)
+	workflow := NewWorkflow()
+	extract := workflow.Add(NewTask("extract", `{}`))
+	transform := workflow.Add(NewTask("transform", `{}`), extract)
+	load := workflow.Add(NewTask("load", `{}`, WithTaskDependsOn(queued.ID)), extract, transform)
+
+	require.Equal(t, []*Task{extract, transform, load}, workflow.Tasks())
+	require.Equal(t, TaskStatusNew, extract.Status)
+	require.Empty(t, extract.DependsOn)
+	require.Equal(t, TaskStatusWaiting, transform.Status)
+	require.Equal(t, []uuid.UUID{extract.ID}, transform.DependsOn)
+	require.Equal(t, TaskStatusWaiting, load.Status)
+	require.Equal(t, []uuid.UUID{queued.ID, extract.ID, transform.ID}, load.DependsOn)
+}
+
 func TestNewTaskWithExternalID(t *testing.T) {
 	t.Parallel()
 
@@ -100,3 +194,16 @@ func TestTask_IsInTerminalState(t *testing.T) {
 		})
 	}
 }
+
+func TestTask_LastError(t *testing.T) {
+	t.Parallel()
+
+	task := NewTask("test", NoTaskPayload)
+	require.Empty(t, task.LastError())
+
+	task.Attempts = 1
+	task.AddError(errors.New("first"))
+	task.Attempts = 2
+	task.AddError(errors.New("second"))
+	require.Equal(t, "attempt 2: second", task.LastError())
+}
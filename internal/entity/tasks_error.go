@@ -0,0 +1,50 @@
+package entity
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+)
+
+// TasksError reports per-task failures of a batch operation.
+// Tasks missing in Errors were processed successfully.
+type TasksError struct {
+	Errors map[uuid.UUID]error
+}
+
+// NewTasksError creates an empty TasksError.
+func NewTasksError() *TasksError {
+	return &TasksError{Errors: make(map[uuid.UUID]error)}
+}
+
+// Add records the failure of the task.
+func (e *TasksError) Add(taskID uuid.UUID, err error) {
+	e.Errors[taskID] = err
+}
+
+// ErrOrNil returns the TasksError if any task failed, nil otherwise.
+func (e *TasksError) ErrOrNil() error {
+	if len(e.Errors) == 0 {
+		return nil
+	}
+	return e
+}
+
+// Error implements the error interface.
+func (e *TasksError) Error() string {
+	messages := lo.Uniq(lo.MapToSlice(e.Errors, func(_ uuid.UUID, err error) string {
+		return err.Error()
+	}))
+	slices.Sort(messages)
+
+	return fmt.Sprintf("%d task(s) failed: %s", len(e.Errors), strings.Join(messages, "; "))
+}
+
+// Unwrap returns the task errors, so errors.Is(err, ErrDuplicateTask) reports
+// whether any task of the batch is a duplicate.
+func (e *TasksError) Unwrap() []error {
+	return lo.Values(e.Errors)
+}
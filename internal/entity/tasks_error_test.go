@@ -0,0 +1,26 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTasksError(t *testing.T) {
+	t.Parallel()
+
+	tasksErr := NewTasksError()
+	require.NoError(t, tasksErr.ErrOrNil())
+
+	tasksErr.Add(uuid.New(), ErrDuplicateTask)
+	tasksErr.Add(uuid.New(), ErrDuplicateTask)
+	tasksErr.Add(uuid.New(), ErrInvalidPayloadFormat)
+
+	err := tasksErr.ErrOrNil()
+	require.Error(t, err)
+	require.ErrorIs(t, err, ErrDuplicateTask)
+	require.ErrorIs(t, err, ErrInvalidPayloadFormat)
+	require.NotErrorIs(t, err, ErrTaskCancel)
+	require.Equal(t, "3 task(s) failed: "+ErrInvalidPayloadFormat.Error()+"; "+ErrDuplicateTask.Error(), err.Error())
+}
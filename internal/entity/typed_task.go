@@ -13,16 +13,16 @@ type TypedTask[T any] struct {
 }
 
 // NewTaskWithPayload creates a new task with a typed payload marshaled as JSON.
-func NewTaskWithPayload[T any](taskType TaskType, payload T) (*Task, error) {
-	return NewTaskWithPayloadAndExternalID[T](taskType, payload, "")
+func NewTaskWithPayload[T any](taskType TaskType, payload T, opts ...TaskOpts) (*Task, error) {
+	return NewTaskWithPayloadAndExternalID[T](taskType, payload, "", opts...)
 }
 
 // NewTaskWithPayloadAndExternalID creates a new task with a typed payload and custom external ID.
-func NewTaskWithPayloadAndExternalID[T any](taskType TaskType, payload T, externalID string) (*Task, error) {
+func NewTaskWithPayloadAndExternalID[T any](taskType TaskType, payload T, externalID string, opts ...TaskOpts) (*Task, error) {
 	payloadJSON, err := json.Marshal(payload)
 	if err != nil {
 		return nil, fmt.Errorf("%w: encode %s task payload: %w", ErrPayloadMarshal, taskType, err)
 	}
 
-	return NewTaskWithExternalID(taskType, string(payloadJSON), externalID), nil
+	return NewTaskWithExternalID(taskType, string(payloadJSON), externalID, opts...), nil
 }
@@ -0,0 +1,37 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+)
+
+// Workflow is a graph of tasks added to the queue at once, where a task runs
+// after the tasks it depends on are done.
+type Workflow struct {
+	tasks []*Task
+}
+
+// NewWorkflow creates an empty workflow.
+func NewWorkflow() *Workflow {
+	return &Workflow{}
+}
+
+// Add adds the task to the workflow to be processed after the dependencies are done and returns it,
+// so it can be passed as a dependency of the next tasks. A dependency may be a task of the workflow
+// or a task already in the queue.
+func (w *Workflow) Add(task *Task, dependsOn ...*Task) *Task {
+	WithTaskDependsOn(lo.Map(dependsOn, func(dependency *Task, _ int) uuid.UUID {
+		return dependency.ID
+	})...)(task)
+	if len(task.DependsOn) > 0 {
+		task.Status = TaskStatusWaiting
+	}
+	w.tasks = append(w.tasks, task)
+
+	return task
+}
+
+// Tasks returns the tasks of the workflow in the order they were added.
+func (w *Workflow) Tasks() []*Task {
+	return w.tasks
+}
@@ -6,6 +6,7 @@ package metrics
 import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/samber/lo"
 
 	"github.com/ruko1202/goque/internal/entity"
 )
@@ -103,6 +104,36 @@ var (
 		},
 		[]string{labelTaskType, labelTaskProcessingOperations},
 	)
+	rateLimitTokensUsedTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace:   namespace,
+			Subsystem:   promSubsystem,
+			Name:        "rate_limit_tokens_used_total",
+			Help:        "Total number of rate limit tokens spent on fetched tasks by task type",
+			ConstLabels: constLabels,
+		},
+		[]string{labelTaskType},
+	)
+	rateLimitThrottledTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace:   namespace,
+			Subsystem:   promSubsystem,
+			Name:        "rate_limit_throttled_total",
+			Help:        "Total number of fetches limited by lack of rate limit tokens by task type",
+			ConstLabels: constLabels,
+		},
+		[]string{labelTaskType},
+	)
+	taskTypePaused = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   promSubsystem,
+			Name:        "task_type_paused",
+			Help:        "Whether fetching is paused (1) or not (0) by task type",
+			ConstLabels: constLabels,
+		},
+		[]string{labelTaskType},
+	)
 )
 
 // IncProcessingTasks increments the counter of processed tasks for the given task type and status.
@@ -156,3 +187,24 @@ func SetOperationsTotal(taskType entity.TaskType, operations entity.TaskProcessi
 		labelTaskProcessingOperations: operations,
 	}).Add(float64(count))
 }
+
+// AddRateLimitTokensUsed adds to the counter of rate limit tokens spent on fetched tasks.
+func AddRateLimitTokensUsed(taskType entity.TaskType, count int) {
+	rateLimitTokensUsedTotal.With(prometheus.Labels{
+		labelTaskType: taskType,
+	}).Add(float64(count))
+}
+
+// IncRateLimitThrottled increments the counter of fetches limited by lack of rate limit tokens.
+func IncRateLimitThrottled(taskType entity.TaskType) {
+	rateLimitThrottledTotal.With(prometheus.Labels{
+		labelTaskType: taskType,
+	}).Inc()
+}
+
+// SetTaskTypePaused sets the pause state of a task type.
+func SetTaskTypePaused(taskType entity.TaskType, paused bool) {
+	taskTypePaused.With(prometheus.Labels{
+		labelTaskType: taskType,
+	}).Set(lo.Ternary(paused, 1.0, 0.0))
+}
@@ -0,0 +1,196 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/ruko1202/xlog"
+	"github.com/ruko1202/xlog/xfield"
+
+	"github.com/ruko1202/goque/internal/entity"
+	"github.com/ruko1202/goque/internal/utils/xtime"
+)
+
+var (
+	tasksCount = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   promSubsystem,
+			Name:        "tasks_count",
+			Help:        "Current number of tasks in the queue by task type and status",
+			ConstLabels: constLabels,
+		},
+		[]string{labelTaskType, labelStatus},
+	)
+	queueLagSeconds = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   promSubsystem,
+			Name:        "queue_lag_seconds",
+			Help:        "How long the oldest task ready for processing has been waiting, by task type",
+			ConstLabels: constLabels,
+		},
+		[]string{labelTaskType},
+	)
+	oldestProcessingTaskAgeSeconds = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   promSubsystem,
+			Name:        "oldest_processing_task_age_seconds",
+			Help:        "How long the oldest task in processing has been running, by task type",
+			ConstLabels: constLabels,
+		},
+		[]string{labelTaskType},
+	)
+	processingProgressPercent = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Subsystem:   promSubsystem,
+			Name:        "processing_progress_percent",
+			Help:        "Average progress of the tasks in processing, by task type",
+			ConstLabels: constLabels,
+		},
+		[]string{labelTaskType},
+	)
+)
+
+// StatsFunc returns the queue snapshot exported by StatsCollector.
+type StatsFunc func(ctx context.Context) ([]*entity.TaskStats, error)
+
+type statusKey struct {
+	taskType entity.TaskType
+	status   entity.TaskStatus
+}
+
+// StatsCollector periodically exports the queue stats as Prometheus gauges.
+type StatsCollector struct {
+	statsFunc StatsFunc
+	period    time.Duration
+	timeout   time.Duration
+
+	// exported remembers the series set by previous collections, so
+	// a status or type that has drained is reset to 0 instead of
+	// freezing at its last value.
+	exported map[statusKey]struct{}
+
+	globalCtx         context.Context
+	gracefulStoppedCh chan struct{}
+	gracefulCtxCancel context.CancelFunc
+}
+
+// NewStatsCollector creates a collector calling statsFunc every period.
+func NewStatsCollector(statsFunc StatsFunc, period time.Duration) *StatsCollector {
+	return &StatsCollector{
+		statsFunc:         statsFunc,
+		period:            period,
+		timeout:           period,
+		exported:          make(map[statusKey]struct{}),
+		gracefulStoppedCh: make(chan struct{}),
+		gracefulCtxCancel: func() {},
+	}
+}
+
+// Run starts collecting the stats in the background until Stop is called or ctx is canceled.
+func (c *StatsCollector) Run(ctx context.Context) {
+	ctx = xlog.WithOperation(ctx, "metrics.stats_collector")
+	c.globalCtx = ctx
+
+	xlog.Info(ctx, "start stats collector", xfield.Duration("period", c.period))
+
+	ctx, c.gracefulCtxCancel = context.WithCancel(ctx)
+
+	go c.run(ctx)
+}
+
+// Stop stops the collector and waits for the in-flight collection to finish.
+// No-op if the collector was never run.
+func (c *StatsCollector) Stop() {
+	if c.globalCtx == nil {
+		return
+	}
+
+	xlog.Info(c.globalCtx, "graceful shutdown")
+	c.gracefulCtxCancel()
+	<-c.gracefulStoppedCh
+	xlog.Info(c.globalCtx, "graceful shutdown successful finished")
+}
+
+func (c *StatsCollector) run(ctx context.Context) {
+	defer close(c.gracefulStoppedCh)
+
+	if c.period <= 0 {
+		xlog.Error(ctx, "non-positive period, stats collector will not start",
+			xfield.Duration("period", c.period))
+		return
+	}
+
+	ticker := time.NewTicker(c.period)
+	defer ticker.Stop()
+
+	for {
+		c.Collect(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Collect fetches the stats once and updates the gauges.
+func (c *StatsCollector) Collect(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	stats, err := c.statsFunc(ctx)
+	if err != nil {
+		xlog.Error(ctx, "failed to collect stats", xfield.Error(err))
+		return
+	}
+
+	now := xtime.Now()
+	current := make(map[statusKey]struct{}, len(c.exported))
+	currentTypes := make(map[entity.TaskType]struct{}, len(stats))
+	for _, typeStats := range stats {
+		currentTypes[typeStats.TaskType] = struct{}{}
+		for status, count := range typeStats.Counts {
+			key := statusKey{taskType: typeStats.TaskType, status: status}
+			current[key] = struct{}{}
+			tasksCount.With(prometheus.Labels{
+				labelTaskType: key.taskType,
+				labelStatus:   key.status,
+			}).Set(float64(count))
+		}
+
+		queueLagSeconds.With(prometheus.Labels{
+			labelTaskType: typeStats.TaskType,
+		}).Set(typeStats.QueueLag(now).Seconds())
+		oldestProcessingTaskAgeSeconds.With(prometheus.Labels{
+			labelTaskType: typeStats.TaskType,
+		}).Set(typeStats.OldestProcessingAge(now).Seconds())
+		processingProgressPercent.With(prometheus.Labels{
+			labelTaskType: typeStats.TaskType,
+		}).Set(typeStats.ProcessingProgress)
+	}
+
+	for key := range c.exported {
+		if _, ok := current[key]; ok {
+			continue
+		}
+		current[key] = struct{}{}
+		tasksCount.With(prometheus.Labels{
+			labelTaskType: key.taskType,
+			labelStatus:   key.status,
+		}).Set(0)
+
+		if _, ok := currentTypes[key.taskType]; !ok {
+			queueLagSeconds.With(prometheus.Labels{labelTaskType: key.taskType}).Set(0)
+			oldestProcessingTaskAgeSeconds.With(prometheus.Labels{labelTaskType: key.taskType}).Set(0)
+			processingProgressPercent.With(prometheus.Labels{labelTaskType: key.taskType}).Set(0)
+		}
+	}
+	c.exported = current
+}
@@ -45,6 +45,44 @@ func (m *MockTask) EXPECT() *MockTaskMockRecorder {
 	return m.recorder
 }
 
+// AddBatch mocks base method.
+func (m *MockTask) AddBatch(ctx context.Context, batch *entity.Batch, tasks []*entity.Task, onComplete *entity.Task) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddBatch", ctx, batch, tasks, onComplete)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddBatch indicates an expected call of AddBatch.
+func (mr *MockTaskMockRecorder) AddBatch(ctx, batch, tasks, onComplete any) *MockTaskAddBatchCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddBatch", reflect.TypeOf((*MockTask)(nil).AddBatch), ctx, batch, tasks, onComplete)
+	return &MockTaskAddBatchCall{Call: call}
+}
+
+// MockTaskAddBatchCall wrap *gomock.Call
+type MockTaskAddBatchCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTaskAddBatchCall) Return(arg0 error) *MockTaskAddBatchCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTaskAddBatchCall) Do(f func(context.Context, *entity.Batch, []*entity.Task, *entity.Task) error) *MockTaskAddBatchCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTaskAddBatchCall) DoAndReturn(f func(context.Context, *entity.Batch, []*entity.Task, *entity.Task) error) *MockTaskAddBatchCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // AddTask mocks base method.
 func (m *MockTask) AddTask(ctx context.Context, task *entity.Task) error {
 	m.ctrl.T.Helper()
@@ -83,6 +121,123 @@ func (c *MockTaskAddTaskCall) DoAndReturn(f func(context.Context, *entity.Task)
 	return c
 }
 
+// AddTaskOrGet mocks base method.
+func (m *MockTask) AddTaskOrGet(ctx context.Context, task *entity.Task) (*entity.Task, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTaskOrGet", ctx, task)
+	ret0, _ := ret[0].(*entity.Task)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// AddTaskOrGet indicates an expected call of AddTaskOrGet.
+func (mr *MockTaskMockRecorder) AddTaskOrGet(ctx, task any) *MockTaskAddTaskOrGetCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTaskOrGet", reflect.TypeOf((*MockTask)(nil).AddTaskOrGet), ctx, task)
+	return &MockTaskAddTaskOrGetCall{Call: call}
+}
+
+// MockTaskAddTaskOrGetCall wrap *gomock.Call
+type MockTaskAddTaskOrGetCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTaskAddTaskOrGetCall) Return(arg0 *entity.Task, arg1 bool, arg2 error) *MockTaskAddTaskOrGetCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTaskAddTaskOrGetCall) Do(f func(context.Context, *entity.Task) (*entity.Task, bool, error)) *MockTaskAddTaskOrGetCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTaskAddTaskOrGetCall) DoAndReturn(f func(context.Context, *entity.Task) (*entity.Task, bool, error)) *MockTaskAddTaskOrGetCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// AddTasks mocks base method.
+func (m *MockTask) AddTasks(ctx context.Context, tasks []*entity.Task, skipDuplicates bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTasks", ctx, tasks, skipDuplicates)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddTasks indicates an expected call of AddTasks.
+func (mr *MockTaskMockRecorder) AddTasks(ctx, tasks, skipDuplicates any) *MockTaskAddTasksCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTasks", reflect.TypeOf((*MockTask)(nil).AddTasks), ctx, tasks, skipDuplicates)
+	return &MockTaskAddTasksCall{Call: call}
+}
+
+// MockTaskAddTasksCall wrap *gomock.Call
+type MockTaskAddTasksCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTaskAddTasksCall) Return(arg0 error) *MockTaskAddTasksCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTaskAddTasksCall) Do(f func(context.Context, []*entity.Task, bool) error) *MockTaskAddTasksCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTaskAddTasksCall) DoAndReturn(f func(context.Context, []*entity.Task, bool) error) *MockTaskAddTasksCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CancelTasks mocks base method.
+func (m *MockTask) CancelTasks(ctx context.Context, filter *dbentity.GetTasksFilter) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelTasks", ctx, filter)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelTasks indicates an expected call of CancelTasks.
+func (mr *MockTaskMockRecorder) CancelTasks(ctx, filter any) *MockTaskCancelTasksCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelTasks", reflect.TypeOf((*MockTask)(nil).CancelTasks), ctx, filter)
+	return &MockTaskCancelTasksCall{Call: call}
+}
+
+// MockTaskCancelTasksCall wrap *gomock.Call
+type MockTaskCancelTasksCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTaskCancelTasksCall) Return(arg0 int64, arg1 error) *MockTaskCancelTasksCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTaskCancelTasksCall) Do(f func(context.Context, *dbentity.GetTasksFilter) (int64, error)) *MockTaskCancelTasksCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTaskCancelTasksCall) DoAndReturn(f func(context.Context, *dbentity.GetTasksFilter) (int64, error)) *MockTaskCancelTasksCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // CureTasks mocks base method.
 func (m *MockTask) CureTasks(ctx context.Context, taskType entity.TaskType, unhealthStatuses []entity.TaskStatus, updatedAtTimeAgo time.Duration, comment string) ([]*entity.Task, error) {
 	m.ctrl.T.Helper()
@@ -122,6 +277,45 @@ func (c *MockTaskCureTasksCall) DoAndReturn(f func(context.Context, entity.TaskT
 	return c
 }
 
+// DeleteDeadTasks mocks base method.
+func (m *MockTask) DeleteDeadTasks(ctx context.Context, filter *dbentity.DeadTasksFilter) ([]*entity.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDeadTasks", ctx, filter)
+	ret0, _ := ret[0].([]*entity.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteDeadTasks indicates an expected call of DeleteDeadTasks.
+func (mr *MockTaskMockRecorder) DeleteDeadTasks(ctx, filter any) *MockTaskDeleteDeadTasksCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDeadTasks", reflect.TypeOf((*MockTask)(nil).DeleteDeadTasks), ctx, filter)
+	return &MockTaskDeleteDeadTasksCall{Call: call}
+}
+
+// MockTaskDeleteDeadTasksCall wrap *gomock.Call
+type MockTaskDeleteDeadTasksCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTaskDeleteDeadTasksCall) Return(arg0 []*entity.Task, arg1 error) *MockTaskDeleteDeadTasksCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTaskDeleteDeadTasksCall) Do(f func(context.Context, *dbentity.DeadTasksFilter) ([]*entity.Task, error)) *MockTaskDeleteDeadTasksCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTaskDeleteDeadTasksCall) DoAndReturn(f func(context.Context, *dbentity.DeadTasksFilter) ([]*entity.Task, error)) *MockTaskDeleteDeadTasksCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // DeleteTasks mocks base method.
 func (m *MockTask) DeleteTasks(ctx context.Context, taskType entity.TaskType, statuses []entity.TaskStatus, updatedAtTimeAgo time.Duration) ([]*entity.Task, error) {
 	m.ctrl.T.Helper()
@@ -161,566 +355,2020 @@ func (c *MockTaskDeleteTasksCall) DoAndReturn(f func(context.Context, entity.Tas
 	return c
 }
 
-// GetTask mocks base method.
-func (m *MockTask) GetTask(ctx context.Context, id uuid.UUID) (*entity.Task, error) {
+// DeleteTasksByFilter mocks base method.
+func (m *MockTask) DeleteTasksByFilter(ctx context.Context, filter *dbentity.GetTasksFilter) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTasksByFilter", ctx, filter)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTasksByFilter indicates an expected call of DeleteTasksByFilter.
+func (mr *MockTaskMockRecorder) DeleteTasksByFilter(ctx, filter any) *MockTaskDeleteTasksByFilterCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTasksByFilter", reflect.TypeOf((*MockTask)(nil).DeleteTasksByFilter), ctx, filter)
+	return &MockTaskDeleteTasksByFilterCall{Call: call}
+}
+
+// MockTaskDeleteTasksByFilterCall wrap *gomock.Call
+type MockTaskDeleteTasksByFilterCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTaskDeleteTasksByFilterCall) Return(arg0 int64, arg1 error) *MockTaskDeleteTasksByFilterCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTaskDeleteTasksByFilterCall) Do(f func(context.Context, *dbentity.GetTasksFilter) (int64, error)) *MockTaskDeleteTasksByFilterCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTaskDeleteTasksByFilterCall) DoAndReturn(f func(context.Context, *dbentity.GetTasksFilter) (int64, error)) *MockTaskDeleteTasksByFilterCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetBatch mocks base method.
+func (m *MockTask) GetBatch(ctx context.Context, id uuid.UUID) (*entity.Batch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBatch", ctx, id)
+	ret0, _ := ret[0].(*entity.Batch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBatch indicates an expected call of GetBatch.
+func (mr *MockTaskMockRecorder) GetBatch(ctx, id any) *MockTaskGetBatchCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBatch", reflect.TypeOf((*MockTask)(nil).GetBatch), ctx, id)
+	return &MockTaskGetBatchCall{Call: call}
+}
+
+// MockTaskGetBatchCall wrap *gomock.Call
+type MockTaskGetBatchCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTaskGetBatchCall) Return(arg0 *entity.Batch, arg1 error) *MockTaskGetBatchCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTaskGetBatchCall) Do(f func(context.Context, uuid.UUID) (*entity.Batch, error)) *MockTaskGetBatchCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTaskGetBatchCall) DoAndReturn(f func(context.Context, uuid.UUID) (*entity.Batch, error)) *MockTaskGetBatchCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetDeadTasks mocks base method.
+func (m *MockTask) GetDeadTasks(ctx context.Context, filter *dbentity.DeadTasksFilter, limit int64) ([]*entity.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeadTasks", ctx, filter, limit)
+	ret0, _ := ret[0].([]*entity.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeadTasks indicates an expected call of GetDeadTasks.
+func (mr *MockTaskMockRecorder) GetDeadTasks(ctx, filter, limit any) *MockTaskGetDeadTasksCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeadTasks", reflect.TypeOf((*MockTask)(nil).GetDeadTasks), ctx, filter, limit)
+	return &MockTaskGetDeadTasksCall{Call: call}
+}
+
+// MockTaskGetDeadTasksCall wrap *gomock.Call
+type MockTaskGetDeadTasksCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTaskGetDeadTasksCall) Return(arg0 []*entity.Task, arg1 error) *MockTaskGetDeadTasksCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTaskGetDeadTasksCall) Do(f func(context.Context, *dbentity.DeadTasksFilter, int64) ([]*entity.Task, error)) *MockTaskGetDeadTasksCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTaskGetDeadTasksCall) DoAndReturn(f func(context.Context, *dbentity.DeadTasksFilter, int64) ([]*entity.Task, error)) *MockTaskGetDeadTasksCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetPausedTaskTypes mocks base method.
+func (m *MockTask) GetPausedTaskTypes(ctx context.Context) ([]entity.TaskType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPausedTaskTypes", ctx)
+	ret0, _ := ret[0].([]entity.TaskType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPausedTaskTypes indicates an expected call of GetPausedTaskTypes.
+func (mr *MockTaskMockRecorder) GetPausedTaskTypes(ctx any) *MockTaskGetPausedTaskTypesCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPausedTaskTypes", reflect.TypeOf((*MockTask)(nil).GetPausedTaskTypes), ctx)
+	return &MockTaskGetPausedTaskTypesCall{Call: call}
+}
+
+// MockTaskGetPausedTaskTypesCall wrap *gomock.Call
+type MockTaskGetPausedTaskTypesCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTaskGetPausedTaskTypesCall) Return(arg0 []entity.TaskType, arg1 error) *MockTaskGetPausedTaskTypesCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTaskGetPausedTaskTypesCall) Do(f func(context.Context) ([]entity.TaskType, error)) *MockTaskGetPausedTaskTypesCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTaskGetPausedTaskTypesCall) DoAndReturn(f func(context.Context) ([]entity.TaskType, error)) *MockTaskGetPausedTaskTypesCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetTask mocks base method.
+func (m *MockTask) GetTask(ctx context.Context, id uuid.UUID) (*entity.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTask", ctx, id)
+	ret0, _ := ret[0].(*entity.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTask indicates an expected call of GetTask.
+func (mr *MockTaskMockRecorder) GetTask(ctx, id any) *MockTaskGetTaskCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTask", reflect.TypeOf((*MockTask)(nil).GetTask), ctx, id)
+	return &MockTaskGetTaskCall{Call: call}
+}
+
+// MockTaskGetTaskCall wrap *gomock.Call
+type MockTaskGetTaskCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTaskGetTaskCall) Return(arg0 *entity.Task, arg1 error) *MockTaskGetTaskCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTaskGetTaskCall) Do(f func(context.Context, uuid.UUID) (*entity.Task, error)) *MockTaskGetTaskCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTaskGetTaskCall) DoAndReturn(f func(context.Context, uuid.UUID) (*entity.Task, error)) *MockTaskGetTaskCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetTasks mocks base method.
+func (m *MockTask) GetTasks(ctx context.Context, filter *dbentity.GetTasksFilter, limit int64) ([]*entity.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTasks", ctx, filter, limit)
+	ret0, _ := ret[0].([]*entity.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTasks indicates an expected call of GetTasks.
+func (mr *MockTaskMockRecorder) GetTasks(ctx, filter, limit any) *MockTaskGetTasksCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasks", reflect.TypeOf((*MockTask)(nil).GetTasks), ctx, filter, limit)
+	return &MockTaskGetTasksCall{Call: call}
+}
+
+// MockTaskGetTasksCall wrap *gomock.Call
+type MockTaskGetTasksCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTaskGetTasksCall) Return(arg0 []*entity.Task, arg1 error) *MockTaskGetTasksCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTaskGetTasksCall) Do(f func(context.Context, *dbentity.GetTasksFilter, int64) ([]*entity.Task, error)) *MockTaskGetTasksCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTaskGetTasksCall) DoAndReturn(f func(context.Context, *dbentity.GetTasksFilter, int64) ([]*entity.Task, error)) *MockTaskGetTasksCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetTasksForProcessing mocks base method.
+func (m *MockTask) GetTasksForProcessing(ctx context.Context, taskType entity.TaskType, maxTasks int64, lease entity.TaskLease, concurrencyLimitPerKey int64) ([]*entity.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTasksForProcessing", ctx, taskType, maxTasks, lease, concurrencyLimitPerKey)
+	ret0, _ := ret[0].([]*entity.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTasksForProcessing indicates an expected call of GetTasksForProcessing.
+func (mr *MockTaskMockRecorder) GetTasksForProcessing(ctx, taskType, maxTasks, lease, concurrencyLimitPerKey any) *MockTaskGetTasksForProcessingCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasksForProcessing", reflect.TypeOf((*MockTask)(nil).GetTasksForProcessing), ctx, taskType, maxTasks, lease, concurrencyLimitPerKey)
+	return &MockTaskGetTasksForProcessingCall{Call: call}
+}
+
+// MockTaskGetTasksForProcessingCall wrap *gomock.Call
+type MockTaskGetTasksForProcessingCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTaskGetTasksForProcessingCall) Return(arg0 []*entity.Task, arg1 error) *MockTaskGetTasksForProcessingCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTaskGetTasksForProcessingCall) Do(f func(context.Context, entity.TaskType, int64, entity.TaskLease, int64) ([]*entity.Task, error)) *MockTaskGetTasksForProcessingCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTaskGetTasksForProcessingCall) DoAndReturn(f func(context.Context, entity.TaskType, int64, entity.TaskLease, int64) ([]*entity.Task, error)) *MockTaskGetTasksForProcessingCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// IsTaskTypePaused mocks base method.
+func (m *MockTask) IsTaskTypePaused(ctx context.Context, taskType entity.TaskType) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsTaskTypePaused", ctx, taskType)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsTaskTypePaused indicates an expected call of IsTaskTypePaused.
+func (mr *MockTaskMockRecorder) IsTaskTypePaused(ctx, taskType any) *MockTaskIsTaskTypePausedCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsTaskTypePaused", reflect.TypeOf((*MockTask)(nil).IsTaskTypePaused), ctx, taskType)
+	return &MockTaskIsTaskTypePausedCall{Call: call}
+}
+
+// MockTaskIsTaskTypePausedCall wrap *gomock.Call
+type MockTaskIsTaskTypePausedCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTaskIsTaskTypePausedCall) Return(arg0 bool, arg1 error) *MockTaskIsTaskTypePausedCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTaskIsTaskTypePausedCall) Do(f func(context.Context, entity.TaskType) (bool, error)) *MockTaskIsTaskTypePausedCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTaskIsTaskTypePausedCall) DoAndReturn(f func(context.Context, entity.TaskType) (bool, error)) *MockTaskIsTaskTypePausedCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MoveTaskToDead mocks base method.
+func (m *MockTask) MoveTaskToDead(ctx context.Context, task *entity.Task) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveTaskToDead", ctx, task)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveTaskToDead indicates an expected call of MoveTaskToDead.
+func (mr *MockTaskMockRecorder) MoveTaskToDead(ctx, task any) *MockTaskMoveTaskToDeadCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveTaskToDead", reflect.TypeOf((*MockTask)(nil).MoveTaskToDead), ctx, task)
+	return &MockTaskMoveTaskToDeadCall{Call: call}
+}
+
+// MockTaskMoveTaskToDeadCall wrap *gomock.Call
+type MockTaskMoveTaskToDeadCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTaskMoveTaskToDeadCall) Return(arg0 error) *MockTaskMoveTaskToDeadCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTaskMoveTaskToDeadCall) Do(f func(context.Context, *entity.Task) error) *MockTaskMoveTaskToDeadCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTaskMoveTaskToDeadCall) DoAndReturn(f func(context.Context, *entity.Task) error) *MockTaskMoveTaskToDeadCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// PauseTaskType mocks base method.
+func (m *MockTask) PauseTaskType(ctx context.Context, taskType entity.TaskType) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PauseTaskType", ctx, taskType)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PauseTaskType indicates an expected call of PauseTaskType.
+func (mr *MockTaskMockRecorder) PauseTaskType(ctx, taskType any) *MockTaskPauseTaskTypeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PauseTaskType", reflect.TypeOf((*MockTask)(nil).PauseTaskType), ctx, taskType)
+	return &MockTaskPauseTaskTypeCall{Call: call}
+}
+
+// MockTaskPauseTaskTypeCall wrap *gomock.Call
+type MockTaskPauseTaskTypeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTaskPauseTaskTypeCall) Return(arg0 error) *MockTaskPauseTaskTypeCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTaskPauseTaskTypeCall) Do(f func(context.Context, entity.TaskType) error) *MockTaskPauseTaskTypeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTaskPauseTaskTypeCall) DoAndReturn(f func(context.Context, entity.TaskType) error) *MockTaskPauseTaskTypeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// RenewTaskLeases mocks base method.
+func (m *MockTask) RenewTaskLeases(ctx context.Context, taskIDs []uuid.UUID, lease entity.TaskLease) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenewTaskLeases", ctx, taskIDs, lease)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RenewTaskLeases indicates an expected call of RenewTaskLeases.
+func (mr *MockTaskMockRecorder) RenewTaskLeases(ctx, taskIDs, lease any) *MockTaskRenewTaskLeasesCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenewTaskLeases", reflect.TypeOf((*MockTask)(nil).RenewTaskLeases), ctx, taskIDs, lease)
+	return &MockTaskRenewTaskLeasesCall{Call: call}
+}
+
+// MockTaskRenewTaskLeasesCall wrap *gomock.Call
+type MockTaskRenewTaskLeasesCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTaskRenewTaskLeasesCall) Return(arg0 error) *MockTaskRenewTaskLeasesCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTaskRenewTaskLeasesCall) Do(f func(context.Context, []uuid.UUID, entity.TaskLease) error) *MockTaskRenewTaskLeasesCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTaskRenewTaskLeasesCall) DoAndReturn(f func(context.Context, []uuid.UUID, entity.TaskLease) error) *MockTaskRenewTaskLeasesCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// RequeueDeadTasks mocks base method.
+func (m *MockTask) RequeueDeadTasks(ctx context.Context, filter *dbentity.DeadTasksFilter) ([]*entity.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequeueDeadTasks", ctx, filter)
+	ret0, _ := ret[0].([]*entity.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequeueDeadTasks indicates an expected call of RequeueDeadTasks.
+func (mr *MockTaskMockRecorder) RequeueDeadTasks(ctx, filter any) *MockTaskRequeueDeadTasksCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequeueDeadTasks", reflect.TypeOf((*MockTask)(nil).RequeueDeadTasks), ctx, filter)
+	return &MockTaskRequeueDeadTasksCall{Call: call}
+}
+
+// MockTaskRequeueDeadTasksCall wrap *gomock.Call
+type MockTaskRequeueDeadTasksCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTaskRequeueDeadTasksCall) Return(arg0 []*entity.Task, arg1 error) *MockTaskRequeueDeadTasksCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTaskRequeueDeadTasksCall) Do(f func(context.Context, *dbentity.DeadTasksFilter) ([]*entity.Task, error)) *MockTaskRequeueDeadTasksCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTaskRequeueDeadTasksCall) DoAndReturn(f func(context.Context, *dbentity.DeadTasksFilter) ([]*entity.Task, error)) *MockTaskRequeueDeadTasksCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ResetAttempts mocks base method.
+func (m *MockTask) ResetAttempts(ctx context.Context, taskID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetAttempts", ctx, taskID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetAttempts indicates an expected call of ResetAttempts.
+func (mr *MockTaskMockRecorder) ResetAttempts(ctx, taskID any) *MockTaskResetAttemptsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetAttempts", reflect.TypeOf((*MockTask)(nil).ResetAttempts), ctx, taskID)
+	return &MockTaskResetAttemptsCall{Call: call}
+}
+
+// MockTaskResetAttemptsCall wrap *gomock.Call
+type MockTaskResetAttemptsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTaskResetAttemptsCall) Return(arg0 error) *MockTaskResetAttemptsCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTaskResetAttemptsCall) Do(f func(context.Context, uuid.UUID) error) *MockTaskResetAttemptsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTaskResetAttemptsCall) DoAndReturn(f func(context.Context, uuid.UUID) error) *MockTaskResetAttemptsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ResumeTaskType mocks base method.
+func (m *MockTask) ResumeTaskType(ctx context.Context, taskType entity.TaskType) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResumeTaskType", ctx, taskType)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResumeTaskType indicates an expected call of ResumeTaskType.
+func (mr *MockTaskMockRecorder) ResumeTaskType(ctx, taskType any) *MockTaskResumeTaskTypeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResumeTaskType", reflect.TypeOf((*MockTask)(nil).ResumeTaskType), ctx, taskType)
+	return &MockTaskResumeTaskTypeCall{Call: call}
+}
+
+// MockTaskResumeTaskTypeCall wrap *gomock.Call
+type MockTaskResumeTaskTypeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTaskResumeTaskTypeCall) Return(arg0 error) *MockTaskResumeTaskTypeCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTaskResumeTaskTypeCall) Do(f func(context.Context, entity.TaskType) error) *MockTaskResumeTaskTypeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTaskResumeTaskTypeCall) DoAndReturn(f func(context.Context, entity.TaskType) error) *MockTaskResumeTaskTypeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// RetryTasks mocks base method.
+func (m *MockTask) RetryTasks(ctx context.Context, filter *dbentity.GetTasksFilter) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetryTasks", ctx, filter)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RetryTasks indicates an expected call of RetryTasks.
+func (mr *MockTaskMockRecorder) RetryTasks(ctx, filter any) *MockTaskRetryTasksCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetryTasks", reflect.TypeOf((*MockTask)(nil).RetryTasks), ctx, filter)
+	return &MockTaskRetryTasksCall{Call: call}
+}
+
+// MockTaskRetryTasksCall wrap *gomock.Call
+type MockTaskRetryTasksCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTaskRetryTasksCall) Return(arg0 int64, arg1 error) *MockTaskRetryTasksCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTaskRetryTasksCall) Do(f func(context.Context, *dbentity.GetTasksFilter) (int64, error)) *MockTaskRetryTasksCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTaskRetryTasksCall) DoAndReturn(f func(context.Context, *dbentity.GetTasksFilter) (int64, error)) *MockTaskRetryTasksCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ReturnRateLimitTokens mocks base method.
+func (m *MockTask) ReturnRateLimitTokens(ctx context.Context, name string, limit entity.RateLimit, tokens int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReturnRateLimitTokens", ctx, name, limit, tokens)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReturnRateLimitTokens indicates an expected call of ReturnRateLimitTokens.
+func (mr *MockTaskMockRecorder) ReturnRateLimitTokens(ctx, name, limit, tokens any) *MockTaskReturnRateLimitTokensCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReturnRateLimitTokens", reflect.TypeOf((*MockTask)(nil).ReturnRateLimitTokens), ctx, name, limit, tokens)
+	return &MockTaskReturnRateLimitTokensCall{Call: call}
+}
+
+// MockTaskReturnRateLimitTokensCall wrap *gomock.Call
+type MockTaskReturnRateLimitTokensCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTaskReturnRateLimitTokensCall) Return(arg0 error) *MockTaskReturnRateLimitTokensCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTaskReturnRateLimitTokensCall) Do(f func(context.Context, string, entity.RateLimit, int64) error) *MockTaskReturnRateLimitTokensCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTaskReturnRateLimitTokensCall) DoAndReturn(f func(context.Context, string, entity.RateLimit, int64) error) *MockTaskReturnRateLimitTokensCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// SaveTaskCheckpoint mocks base method.
+func (m *MockTask) SaveTaskCheckpoint(ctx context.Context, task *entity.Task) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveTaskCheckpoint", ctx, task)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveTaskCheckpoint indicates an expected call of SaveTaskCheckpoint.
+func (mr *MockTaskMockRecorder) SaveTaskCheckpoint(ctx, task any) *MockTaskSaveTaskCheckpointCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTaskCheckpoint", reflect.TypeOf((*MockTask)(nil).SaveTaskCheckpoint), ctx, task)
+	return &MockTaskSaveTaskCheckpointCall{Call: call}
+}
+
+// MockTaskSaveTaskCheckpointCall wrap *gomock.Call
+type MockTaskSaveTaskCheckpointCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTaskSaveTaskCheckpointCall) Return(arg0 error) *MockTaskSaveTaskCheckpointCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTaskSaveTaskCheckpointCall) Do(f func(context.Context, *entity.Task) error) *MockTaskSaveTaskCheckpointCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTaskSaveTaskCheckpointCall) DoAndReturn(f func(context.Context, *entity.Task) error) *MockTaskSaveTaskCheckpointCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Stats mocks base method.
+func (m *MockTask) Stats(ctx context.Context, filter *dbentity.GetTasksFilter) ([]*entity.TaskStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stats", ctx, filter)
+	ret0, _ := ret[0].([]*entity.TaskStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Stats indicates an expected call of Stats.
+func (mr *MockTaskMockRecorder) Stats(ctx, filter any) *MockTaskStatsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stats", reflect.TypeOf((*MockTask)(nil).Stats), ctx, filter)
+	return &MockTaskStatsCall{Call: call}
+}
+
+// MockTaskStatsCall wrap *gomock.Call
+type MockTaskStatsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTaskStatsCall) Return(arg0 []*entity.TaskStats, arg1 error) *MockTaskStatsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTaskStatsCall) Do(f func(context.Context, *dbentity.GetTasksFilter) ([]*entity.TaskStats, error)) *MockTaskStatsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTaskStatsCall) DoAndReturn(f func(context.Context, *dbentity.GetTasksFilter) ([]*entity.TaskStats, error)) *MockTaskStatsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// TakeRateLimitTokens mocks base method.
+func (m *MockTask) TakeRateLimitTokens(ctx context.Context, name string, limit entity.RateLimit, maxTokens int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TakeRateLimitTokens", ctx, name, limit, maxTokens)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TakeRateLimitTokens indicates an expected call of TakeRateLimitTokens.
+func (mr *MockTaskMockRecorder) TakeRateLimitTokens(ctx, name, limit, maxTokens any) *MockTaskTakeRateLimitTokensCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TakeRateLimitTokens", reflect.TypeOf((*MockTask)(nil).TakeRateLimitTokens), ctx, name, limit, maxTokens)
+	return &MockTaskTakeRateLimitTokensCall{Call: call}
+}
+
+// MockTaskTakeRateLimitTokensCall wrap *gomock.Call
+type MockTaskTakeRateLimitTokensCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTaskTakeRateLimitTokensCall) Return(arg0 int64, arg1 error) *MockTaskTakeRateLimitTokensCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTaskTakeRateLimitTokensCall) Do(f func(context.Context, string, entity.RateLimit, int64) (int64, error)) *MockTaskTakeRateLimitTokensCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTaskTakeRateLimitTokensCall) DoAndReturn(f func(context.Context, string, entity.RateLimit, int64) (int64, error)) *MockTaskTakeRateLimitTokensCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// UpdateTask mocks base method.
+func (m *MockTask) UpdateTask(ctx context.Context, taskID uuid.UUID, task *entity.Task) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTask", ctx, taskID, task)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTask indicates an expected call of UpdateTask.
+func (mr *MockTaskMockRecorder) UpdateTask(ctx, taskID, task any) *MockTaskUpdateTaskCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTask", reflect.TypeOf((*MockTask)(nil).UpdateTask), ctx, taskID, task)
+	return &MockTaskUpdateTaskCall{Call: call}
+}
+
+// MockTaskUpdateTaskCall wrap *gomock.Call
+type MockTaskUpdateTaskCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTaskUpdateTaskCall) Return(arg0 error) *MockTaskUpdateTaskCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTaskUpdateTaskCall) Do(f func(context.Context, uuid.UUID, *entity.Task) error) *MockTaskUpdateTaskCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTaskUpdateTaskCall) DoAndReturn(f func(context.Context, uuid.UUID, *entity.Task) error) *MockTaskUpdateTaskCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockTaskListener is a mock of TaskListener interface.
+type MockTaskListener struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaskListenerMockRecorder
+	isgomock struct{}
+}
+
+// MockTaskListenerMockRecorder is the mock recorder for MockTaskListener.
+type MockTaskListenerMockRecorder struct {
+	mock *MockTaskListener
+}
+
+// NewMockTaskListener creates a new mock instance.
+func NewMockTaskListener(ctrl *gomock.Controller) *MockTaskListener {
+	mock := &MockTaskListener{ctrl: ctrl}
+	mock.recorder = &MockTaskListenerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaskListener) EXPECT() *MockTaskListenerMockRecorder {
+	return m.recorder
+}
+
+// ListenFinishedTasks mocks base method.
+func (m *MockTaskListener) ListenFinishedTasks(ctx context.Context) (<-chan uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListenFinishedTasks", ctx)
+	ret0, _ := ret[0].(<-chan uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListenFinishedTasks indicates an expected call of ListenFinishedTasks.
+func (mr *MockTaskListenerMockRecorder) ListenFinishedTasks(ctx any) *MockTaskListenerListenFinishedTasksCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListenFinishedTasks", reflect.TypeOf((*MockTaskListener)(nil).ListenFinishedTasks), ctx)
+	return &MockTaskListenerListenFinishedTasksCall{Call: call}
+}
+
+// MockTaskListenerListenFinishedTasksCall wrap *gomock.Call
+type MockTaskListenerListenFinishedTasksCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTaskListenerListenFinishedTasksCall) Return(arg0 <-chan uuid.UUID, arg1 error) *MockTaskListenerListenFinishedTasksCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTaskListenerListenFinishedTasksCall) Do(f func(context.Context) (<-chan uuid.UUID, error)) *MockTaskListenerListenFinishedTasksCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTaskListenerListenFinishedTasksCall) DoAndReturn(f func(context.Context) (<-chan uuid.UUID, error)) *MockTaskListenerListenFinishedTasksCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListenTasks mocks base method.
+func (m *MockTaskListener) ListenTasks(ctx context.Context, taskType entity.TaskType) (<-chan struct{}, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListenTasks", ctx, taskType)
+	ret0, _ := ret[0].(<-chan struct{})
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListenTasks indicates an expected call of ListenTasks.
+func (mr *MockTaskListenerMockRecorder) ListenTasks(ctx, taskType any) *MockTaskListenerListenTasksCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListenTasks", reflect.TypeOf((*MockTaskListener)(nil).ListenTasks), ctx, taskType)
+	return &MockTaskListenerListenTasksCall{Call: call}
+}
+
+// MockTaskListenerListenTasksCall wrap *gomock.Call
+type MockTaskListenerListenTasksCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTaskListenerListenTasksCall) Return(arg0 <-chan struct{}, arg1 error) *MockTaskListenerListenTasksCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTaskListenerListenTasksCall) Do(f func(context.Context, entity.TaskType) (<-chan struct{}, error)) *MockTaskListenerListenTasksCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTaskListenerListenTasksCall) DoAndReturn(f func(context.Context, entity.TaskType) (<-chan struct{}, error)) *MockTaskListenerListenTasksCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockAdvancedTaskStorage is a mock of AdvancedTaskStorage interface.
+type MockAdvancedTaskStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockAdvancedTaskStorageMockRecorder
+	isgomock struct{}
+}
+
+// MockAdvancedTaskStorageMockRecorder is the mock recorder for MockAdvancedTaskStorage.
+type MockAdvancedTaskStorageMockRecorder struct {
+	mock *MockAdvancedTaskStorage
+}
+
+// NewMockAdvancedTaskStorage creates a new mock instance.
+func NewMockAdvancedTaskStorage(ctrl *gomock.Controller) *MockAdvancedTaskStorage {
+	mock := &MockAdvancedTaskStorage{ctrl: ctrl}
+	mock.recorder = &MockAdvancedTaskStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAdvancedTaskStorage) EXPECT() *MockAdvancedTaskStorageMockRecorder {
+	return m.recorder
+}
+
+// AddBatch mocks base method.
+func (m *MockAdvancedTaskStorage) AddBatch(ctx context.Context, batch *entity.Batch, tasks []*entity.Task, onComplete *entity.Task) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddBatch", ctx, batch, tasks, onComplete)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddBatch indicates an expected call of AddBatch.
+func (mr *MockAdvancedTaskStorageMockRecorder) AddBatch(ctx, batch, tasks, onComplete any) *MockAdvancedTaskStorageAddBatchCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddBatch", reflect.TypeOf((*MockAdvancedTaskStorage)(nil).AddBatch), ctx, batch, tasks, onComplete)
+	return &MockAdvancedTaskStorageAddBatchCall{Call: call}
+}
+
+// MockAdvancedTaskStorageAddBatchCall wrap *gomock.Call
+type MockAdvancedTaskStorageAddBatchCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAdvancedTaskStorageAddBatchCall) Return(arg0 error) *MockAdvancedTaskStorageAddBatchCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAdvancedTaskStorageAddBatchCall) Do(f func(context.Context, *entity.Batch, []*entity.Task, *entity.Task) error) *MockAdvancedTaskStorageAddBatchCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAdvancedTaskStorageAddBatchCall) DoAndReturn(f func(context.Context, *entity.Batch, []*entity.Task, *entity.Task) error) *MockAdvancedTaskStorageAddBatchCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// AddTask mocks base method.
+func (m *MockAdvancedTaskStorage) AddTask(ctx context.Context, task *entity.Task) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTask", ctx, task)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddTask indicates an expected call of AddTask.
+func (mr *MockAdvancedTaskStorageMockRecorder) AddTask(ctx, task any) *MockAdvancedTaskStorageAddTaskCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTask", reflect.TypeOf((*MockAdvancedTaskStorage)(nil).AddTask), ctx, task)
+	return &MockAdvancedTaskStorageAddTaskCall{Call: call}
+}
+
+// MockAdvancedTaskStorageAddTaskCall wrap *gomock.Call
+type MockAdvancedTaskStorageAddTaskCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAdvancedTaskStorageAddTaskCall) Return(arg0 error) *MockAdvancedTaskStorageAddTaskCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAdvancedTaskStorageAddTaskCall) Do(f func(context.Context, *entity.Task) error) *MockAdvancedTaskStorageAddTaskCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAdvancedTaskStorageAddTaskCall) DoAndReturn(f func(context.Context, *entity.Task) error) *MockAdvancedTaskStorageAddTaskCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// AddTaskOrGet mocks base method.
+func (m *MockAdvancedTaskStorage) AddTaskOrGet(ctx context.Context, task *entity.Task) (*entity.Task, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTaskOrGet", ctx, task)
+	ret0, _ := ret[0].(*entity.Task)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// AddTaskOrGet indicates an expected call of AddTaskOrGet.
+func (mr *MockAdvancedTaskStorageMockRecorder) AddTaskOrGet(ctx, task any) *MockAdvancedTaskStorageAddTaskOrGetCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTaskOrGet", reflect.TypeOf((*MockAdvancedTaskStorage)(nil).AddTaskOrGet), ctx, task)
+	return &MockAdvancedTaskStorageAddTaskOrGetCall{Call: call}
+}
+
+// MockAdvancedTaskStorageAddTaskOrGetCall wrap *gomock.Call
+type MockAdvancedTaskStorageAddTaskOrGetCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAdvancedTaskStorageAddTaskOrGetCall) Return(arg0 *entity.Task, arg1 bool, arg2 error) *MockAdvancedTaskStorageAddTaskOrGetCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAdvancedTaskStorageAddTaskOrGetCall) Do(f func(context.Context, *entity.Task) (*entity.Task, bool, error)) *MockAdvancedTaskStorageAddTaskOrGetCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAdvancedTaskStorageAddTaskOrGetCall) DoAndReturn(f func(context.Context, *entity.Task) (*entity.Task, bool, error)) *MockAdvancedTaskStorageAddTaskOrGetCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// AddTasks mocks base method.
+func (m *MockAdvancedTaskStorage) AddTasks(ctx context.Context, tasks []*entity.Task, skipDuplicates bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTasks", ctx, tasks, skipDuplicates)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddTasks indicates an expected call of AddTasks.
+func (mr *MockAdvancedTaskStorageMockRecorder) AddTasks(ctx, tasks, skipDuplicates any) *MockAdvancedTaskStorageAddTasksCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTasks", reflect.TypeOf((*MockAdvancedTaskStorage)(nil).AddTasks), ctx, tasks, skipDuplicates)
+	return &MockAdvancedTaskStorageAddTasksCall{Call: call}
+}
+
+// MockAdvancedTaskStorageAddTasksCall wrap *gomock.Call
+type MockAdvancedTaskStorageAddTasksCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAdvancedTaskStorageAddTasksCall) Return(arg0 error) *MockAdvancedTaskStorageAddTasksCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAdvancedTaskStorageAddTasksCall) Do(f func(context.Context, []*entity.Task, bool) error) *MockAdvancedTaskStorageAddTasksCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAdvancedTaskStorageAddTasksCall) DoAndReturn(f func(context.Context, []*entity.Task, bool) error) *MockAdvancedTaskStorageAddTasksCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CancelTasks mocks base method.
+func (m *MockAdvancedTaskStorage) CancelTasks(ctx context.Context, filter *dbentity.GetTasksFilter) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelTasks", ctx, filter)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelTasks indicates an expected call of CancelTasks.
+func (mr *MockAdvancedTaskStorageMockRecorder) CancelTasks(ctx, filter any) *MockAdvancedTaskStorageCancelTasksCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelTasks", reflect.TypeOf((*MockAdvancedTaskStorage)(nil).CancelTasks), ctx, filter)
+	return &MockAdvancedTaskStorageCancelTasksCall{Call: call}
+}
+
+// MockAdvancedTaskStorageCancelTasksCall wrap *gomock.Call
+type MockAdvancedTaskStorageCancelTasksCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAdvancedTaskStorageCancelTasksCall) Return(arg0 int64, arg1 error) *MockAdvancedTaskStorageCancelTasksCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAdvancedTaskStorageCancelTasksCall) Do(f func(context.Context, *dbentity.GetTasksFilter) (int64, error)) *MockAdvancedTaskStorageCancelTasksCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAdvancedTaskStorageCancelTasksCall) DoAndReturn(f func(context.Context, *dbentity.GetTasksFilter) (int64, error)) *MockAdvancedTaskStorageCancelTasksCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CureTasks mocks base method.
+func (m *MockAdvancedTaskStorage) CureTasks(ctx context.Context, taskType entity.TaskType, unhealthStatuses []entity.TaskStatus, updatedAtTimeAgo time.Duration, comment string) ([]*entity.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CureTasks", ctx, taskType, unhealthStatuses, updatedAtTimeAgo, comment)
+	ret0, _ := ret[0].([]*entity.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CureTasks indicates an expected call of CureTasks.
+func (mr *MockAdvancedTaskStorageMockRecorder) CureTasks(ctx, taskType, unhealthStatuses, updatedAtTimeAgo, comment any) *MockAdvancedTaskStorageCureTasksCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CureTasks", reflect.TypeOf((*MockAdvancedTaskStorage)(nil).CureTasks), ctx, taskType, unhealthStatuses, updatedAtTimeAgo, comment)
+	return &MockAdvancedTaskStorageCureTasksCall{Call: call}
+}
+
+// MockAdvancedTaskStorageCureTasksCall wrap *gomock.Call
+type MockAdvancedTaskStorageCureTasksCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAdvancedTaskStorageCureTasksCall) Return(arg0 []*entity.Task, arg1 error) *MockAdvancedTaskStorageCureTasksCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAdvancedTaskStorageCureTasksCall) Do(f func(context.Context, entity.TaskType, []entity.TaskStatus, time.Duration, string) ([]*entity.Task, error)) *MockAdvancedTaskStorageCureTasksCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAdvancedTaskStorageCureTasksCall) DoAndReturn(f func(context.Context, entity.TaskType, []entity.TaskStatus, time.Duration, string) ([]*entity.Task, error)) *MockAdvancedTaskStorageCureTasksCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DeleteDeadTasks mocks base method.
+func (m *MockAdvancedTaskStorage) DeleteDeadTasks(ctx context.Context, filter *dbentity.DeadTasksFilter) ([]*entity.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDeadTasks", ctx, filter)
+	ret0, _ := ret[0].([]*entity.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteDeadTasks indicates an expected call of DeleteDeadTasks.
+func (mr *MockAdvancedTaskStorageMockRecorder) DeleteDeadTasks(ctx, filter any) *MockAdvancedTaskStorageDeleteDeadTasksCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDeadTasks", reflect.TypeOf((*MockAdvancedTaskStorage)(nil).DeleteDeadTasks), ctx, filter)
+	return &MockAdvancedTaskStorageDeleteDeadTasksCall{Call: call}
+}
+
+// MockAdvancedTaskStorageDeleteDeadTasksCall wrap *gomock.Call
+type MockAdvancedTaskStorageDeleteDeadTasksCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAdvancedTaskStorageDeleteDeadTasksCall) Return(arg0 []*entity.Task, arg1 error) *MockAdvancedTaskStorageDeleteDeadTasksCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAdvancedTaskStorageDeleteDeadTasksCall) Do(f func(context.Context, *dbentity.DeadTasksFilter) ([]*entity.Task, error)) *MockAdvancedTaskStorageDeleteDeadTasksCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAdvancedTaskStorageDeleteDeadTasksCall) DoAndReturn(f func(context.Context, *dbentity.DeadTasksFilter) ([]*entity.Task, error)) *MockAdvancedTaskStorageDeleteDeadTasksCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DeleteTasks mocks base method.
+func (m *MockAdvancedTaskStorage) DeleteTasks(ctx context.Context, taskType entity.TaskType, statuses []entity.TaskStatus, updatedAtTimeAgo time.Duration) ([]*entity.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTasks", ctx, taskType, statuses, updatedAtTimeAgo)
+	ret0, _ := ret[0].([]*entity.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTasks indicates an expected call of DeleteTasks.
+func (mr *MockAdvancedTaskStorageMockRecorder) DeleteTasks(ctx, taskType, statuses, updatedAtTimeAgo any) *MockAdvancedTaskStorageDeleteTasksCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTasks", reflect.TypeOf((*MockAdvancedTaskStorage)(nil).DeleteTasks), ctx, taskType, statuses, updatedAtTimeAgo)
+	return &MockAdvancedTaskStorageDeleteTasksCall{Call: call}
+}
+
+// MockAdvancedTaskStorageDeleteTasksCall wrap *gomock.Call
+type MockAdvancedTaskStorageDeleteTasksCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAdvancedTaskStorageDeleteTasksCall) Return(arg0 []*entity.Task, arg1 error) *MockAdvancedTaskStorageDeleteTasksCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAdvancedTaskStorageDeleteTasksCall) Do(f func(context.Context, entity.TaskType, []entity.TaskStatus, time.Duration) ([]*entity.Task, error)) *MockAdvancedTaskStorageDeleteTasksCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAdvancedTaskStorageDeleteTasksCall) DoAndReturn(f func(context.Context, entity.TaskType, []entity.TaskStatus, time.Duration) ([]*entity.Task, error)) *MockAdvancedTaskStorageDeleteTasksCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DeleteTasksByFilter mocks base method.
+func (m *MockAdvancedTaskStorage) DeleteTasksByFilter(ctx context.Context, filter *dbentity.GetTasksFilter) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTasksByFilter", ctx, filter)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTasksByFilter indicates an expected call of DeleteTasksByFilter.
+func (mr *MockAdvancedTaskStorageMockRecorder) DeleteTasksByFilter(ctx, filter any) *MockAdvancedTaskStorageDeleteTasksByFilterCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTasksByFilter", reflect.TypeOf((*MockAdvancedTaskStorage)(nil).DeleteTasksByFilter), ctx, filter)
+	return &MockAdvancedTaskStorageDeleteTasksByFilterCall{Call: call}
+}
+
+// MockAdvancedTaskStorageDeleteTasksByFilterCall wrap *gomock.Call
+type MockAdvancedTaskStorageDeleteTasksByFilterCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAdvancedTaskStorageDeleteTasksByFilterCall) Return(arg0 int64, arg1 error) *MockAdvancedTaskStorageDeleteTasksByFilterCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAdvancedTaskStorageDeleteTasksByFilterCall) Do(f func(context.Context, *dbentity.GetTasksFilter) (int64, error)) *MockAdvancedTaskStorageDeleteTasksByFilterCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAdvancedTaskStorageDeleteTasksByFilterCall) DoAndReturn(f func(context.Context, *dbentity.GetTasksFilter) (int64, error)) *MockAdvancedTaskStorageDeleteTasksByFilterCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetBatch mocks base method.
+func (m *MockAdvancedTaskStorage) GetBatch(ctx context.Context, id uuid.UUID) (*entity.Batch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBatch", ctx, id)
+	ret0, _ := ret[0].(*entity.Batch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBatch indicates an expected call of GetBatch.
+func (mr *MockAdvancedTaskStorageMockRecorder) GetBatch(ctx, id any) *MockAdvancedTaskStorageGetBatchCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBatch", reflect.TypeOf((*MockAdvancedTaskStorage)(nil).GetBatch), ctx, id)
+	return &MockAdvancedTaskStorageGetBatchCall{Call: call}
+}
+
+// MockAdvancedTaskStorageGetBatchCall wrap *gomock.Call
+type MockAdvancedTaskStorageGetBatchCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAdvancedTaskStorageGetBatchCall) Return(arg0 *entity.Batch, arg1 error) *MockAdvancedTaskStorageGetBatchCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAdvancedTaskStorageGetBatchCall) Do(f func(context.Context, uuid.UUID) (*entity.Batch, error)) *MockAdvancedTaskStorageGetBatchCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAdvancedTaskStorageGetBatchCall) DoAndReturn(f func(context.Context, uuid.UUID) (*entity.Batch, error)) *MockAdvancedTaskStorageGetBatchCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetDB mocks base method.
+func (m *MockAdvancedTaskStorage) GetDB() *sqlx.DB {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDB")
+	ret0, _ := ret[0].(*sqlx.DB)
+	return ret0
+}
+
+// GetDB indicates an expected call of GetDB.
+func (mr *MockAdvancedTaskStorageMockRecorder) GetDB() *MockAdvancedTaskStorageGetDBCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDB", reflect.TypeOf((*MockAdvancedTaskStorage)(nil).GetDB))
+	return &MockAdvancedTaskStorageGetDBCall{Call: call}
+}
+
+// MockAdvancedTaskStorageGetDBCall wrap *gomock.Call
+type MockAdvancedTaskStorageGetDBCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAdvancedTaskStorageGetDBCall) Return(arg0 *sqlx.DB) *MockAdvancedTaskStorageGetDBCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAdvancedTaskStorageGetDBCall) Do(f func() *sqlx.DB) *MockAdvancedTaskStorageGetDBCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAdvancedTaskStorageGetDBCall) DoAndReturn(f func() *sqlx.DB) *MockAdvancedTaskStorageGetDBCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetDeadTasks mocks base method.
+func (m *MockAdvancedTaskStorage) GetDeadTasks(ctx context.Context, filter *dbentity.DeadTasksFilter, limit int64) ([]*entity.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeadTasks", ctx, filter, limit)
+	ret0, _ := ret[0].([]*entity.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeadTasks indicates an expected call of GetDeadTasks.
+func (mr *MockAdvancedTaskStorageMockRecorder) GetDeadTasks(ctx, filter, limit any) *MockAdvancedTaskStorageGetDeadTasksCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeadTasks", reflect.TypeOf((*MockAdvancedTaskStorage)(nil).GetDeadTasks), ctx, filter, limit)
+	return &MockAdvancedTaskStorageGetDeadTasksCall{Call: call}
+}
+
+// MockAdvancedTaskStorageGetDeadTasksCall wrap *gomock.Call
+type MockAdvancedTaskStorageGetDeadTasksCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAdvancedTaskStorageGetDeadTasksCall) Return(arg0 []*entity.Task, arg1 error) *MockAdvancedTaskStorageGetDeadTasksCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAdvancedTaskStorageGetDeadTasksCall) Do(f func(context.Context, *dbentity.DeadTasksFilter, int64) ([]*entity.Task, error)) *MockAdvancedTaskStorageGetDeadTasksCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAdvancedTaskStorageGetDeadTasksCall) DoAndReturn(f func(context.Context, *dbentity.DeadTasksFilter, int64) ([]*entity.Task, error)) *MockAdvancedTaskStorageGetDeadTasksCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetPausedTaskTypes mocks base method.
+func (m *MockAdvancedTaskStorage) GetPausedTaskTypes(ctx context.Context) ([]entity.TaskType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPausedTaskTypes", ctx)
+	ret0, _ := ret[0].([]entity.TaskType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPausedTaskTypes indicates an expected call of GetPausedTaskTypes.
+func (mr *MockAdvancedTaskStorageMockRecorder) GetPausedTaskTypes(ctx any) *MockAdvancedTaskStorageGetPausedTaskTypesCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPausedTaskTypes", reflect.TypeOf((*MockAdvancedTaskStorage)(nil).GetPausedTaskTypes), ctx)
+	return &MockAdvancedTaskStorageGetPausedTaskTypesCall{Call: call}
+}
+
+// MockAdvancedTaskStorageGetPausedTaskTypesCall wrap *gomock.Call
+type MockAdvancedTaskStorageGetPausedTaskTypesCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAdvancedTaskStorageGetPausedTaskTypesCall) Return(arg0 []entity.TaskType, arg1 error) *MockAdvancedTaskStorageGetPausedTaskTypesCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAdvancedTaskStorageGetPausedTaskTypesCall) Do(f func(context.Context) ([]entity.TaskType, error)) *MockAdvancedTaskStorageGetPausedTaskTypesCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAdvancedTaskStorageGetPausedTaskTypesCall) DoAndReturn(f func(context.Context) ([]entity.TaskType, error)) *MockAdvancedTaskStorageGetPausedTaskTypesCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetTask mocks base method.
+func (m *MockAdvancedTaskStorage) GetTask(ctx context.Context, id uuid.UUID) (*entity.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTask", ctx, id)
+	ret0, _ := ret[0].(*entity.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTask indicates an expected call of GetTask.
+func (mr *MockAdvancedTaskStorageMockRecorder) GetTask(ctx, id any) *MockAdvancedTaskStorageGetTaskCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTask", reflect.TypeOf((*MockAdvancedTaskStorage)(nil).GetTask), ctx, id)
+	return &MockAdvancedTaskStorageGetTaskCall{Call: call}
+}
+
+// MockAdvancedTaskStorageGetTaskCall wrap *gomock.Call
+type MockAdvancedTaskStorageGetTaskCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAdvancedTaskStorageGetTaskCall) Return(arg0 *entity.Task, arg1 error) *MockAdvancedTaskStorageGetTaskCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAdvancedTaskStorageGetTaskCall) Do(f func(context.Context, uuid.UUID) (*entity.Task, error)) *MockAdvancedTaskStorageGetTaskCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAdvancedTaskStorageGetTaskCall) DoAndReturn(f func(context.Context, uuid.UUID) (*entity.Task, error)) *MockAdvancedTaskStorageGetTaskCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetTasks mocks base method.
+func (m *MockAdvancedTaskStorage) GetTasks(ctx context.Context, filter *dbentity.GetTasksFilter, limit int64) ([]*entity.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTasks", ctx, filter, limit)
+	ret0, _ := ret[0].([]*entity.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTasks indicates an expected call of GetTasks.
+func (mr *MockAdvancedTaskStorageMockRecorder) GetTasks(ctx, filter, limit any) *MockAdvancedTaskStorageGetTasksCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasks", reflect.TypeOf((*MockAdvancedTaskStorage)(nil).GetTasks), ctx, filter, limit)
+	return &MockAdvancedTaskStorageGetTasksCall{Call: call}
+}
+
+// MockAdvancedTaskStorageGetTasksCall wrap *gomock.Call
+type MockAdvancedTaskStorageGetTasksCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAdvancedTaskStorageGetTasksCall) Return(arg0 []*entity.Task, arg1 error) *MockAdvancedTaskStorageGetTasksCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAdvancedTaskStorageGetTasksCall) Do(f func(context.Context, *dbentity.GetTasksFilter, int64) ([]*entity.Task, error)) *MockAdvancedTaskStorageGetTasksCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAdvancedTaskStorageGetTasksCall) DoAndReturn(f func(context.Context, *dbentity.GetTasksFilter, int64) ([]*entity.Task, error)) *MockAdvancedTaskStorageGetTasksCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetTasksForProcessing mocks base method.
+func (m *MockAdvancedTaskStorage) GetTasksForProcessing(ctx context.Context, taskType entity.TaskType, maxTasks int64, lease entity.TaskLease, concurrencyLimitPerKey int64) ([]*entity.Task, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTask", ctx, id)
-	ret0, _ := ret[0].(*entity.Task)
+	ret := m.ctrl.Call(m, "GetTasksForProcessing", ctx, taskType, maxTasks, lease, concurrencyLimitPerKey)
+	ret0, _ := ret[0].([]*entity.Task)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetTask indicates an expected call of GetTask.
-func (mr *MockTaskMockRecorder) GetTask(ctx, id any) *MockTaskGetTaskCall {
+// GetTasksForProcessing indicates an expected call of GetTasksForProcessing.
+func (mr *MockAdvancedTaskStorageMockRecorder) GetTasksForProcessing(ctx, taskType, maxTasks, lease, concurrencyLimitPerKey any) *MockAdvancedTaskStorageGetTasksForProcessingCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTask", reflect.TypeOf((*MockTask)(nil).GetTask), ctx, id)
-	return &MockTaskGetTaskCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasksForProcessing", reflect.TypeOf((*MockAdvancedTaskStorage)(nil).GetTasksForProcessing), ctx, taskType, maxTasks, lease, concurrencyLimitPerKey)
+	return &MockAdvancedTaskStorageGetTasksForProcessingCall{Call: call}
 }
 
-// MockTaskGetTaskCall wrap *gomock.Call
-type MockTaskGetTaskCall struct {
+// MockAdvancedTaskStorageGetTasksForProcessingCall wrap *gomock.Call
+type MockAdvancedTaskStorageGetTasksForProcessingCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *MockTaskGetTaskCall) Return(arg0 *entity.Task, arg1 error) *MockTaskGetTaskCall {
+func (c *MockAdvancedTaskStorageGetTasksForProcessingCall) Return(arg0 []*entity.Task, arg1 error) *MockAdvancedTaskStorageGetTasksForProcessingCall {
 	c.Call = c.Call.Return(arg0, arg1)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *MockTaskGetTaskCall) Do(f func(context.Context, uuid.UUID) (*entity.Task, error)) *MockTaskGetTaskCall {
+func (c *MockAdvancedTaskStorageGetTasksForProcessingCall) Do(f func(context.Context, entity.TaskType, int64, entity.TaskLease, int64) ([]*entity.Task, error)) *MockAdvancedTaskStorageGetTasksForProcessingCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *MockTaskGetTaskCall) DoAndReturn(f func(context.Context, uuid.UUID) (*entity.Task, error)) *MockTaskGetTaskCall {
+func (c *MockAdvancedTaskStorageGetTasksForProcessingCall) DoAndReturn(f func(context.Context, entity.TaskType, int64, entity.TaskLease, int64) ([]*entity.Task, error)) *MockAdvancedTaskStorageGetTasksForProcessingCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
-// GetTasks mocks base method.
-func (m *MockTask) GetTasks(ctx context.Context, filter *dbentity.GetTasksFilter, limit int64) ([]*entity.Task, error) {
+// HardUpdateTask mocks base method.
+func (m *MockAdvancedTaskStorage) HardUpdateTask(ctx context.Context, taskID uuid.UUID, task *entity.Task) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTasks", ctx, filter, limit)
-	ret0, _ := ret[0].([]*entity.Task)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "HardUpdateTask", ctx, taskID, task)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetTasks indicates an expected call of GetTasks.
-func (mr *MockTaskMockRecorder) GetTasks(ctx, filter, limit any) *MockTaskGetTasksCall {
+// HardUpdateTask indicates an expected call of HardUpdateTask.
+func (mr *MockAdvancedTaskStorageMockRecorder) HardUpdateTask(ctx, taskID, task any) *MockAdvancedTaskStorageHardUpdateTaskCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasks", reflect.TypeOf((*MockTask)(nil).GetTasks), ctx, filter, limit)
-	return &MockTaskGetTasksCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HardUpdateTask", reflect.TypeOf((*MockAdvancedTaskStorage)(nil).HardUpdateTask), ctx, taskID, task)
+	return &MockAdvancedTaskStorageHardUpdateTaskCall{Call: call}
 }
 
-// MockTaskGetTasksCall wrap *gomock.Call
-type MockTaskGetTasksCall struct {
+// MockAdvancedTaskStorageHardUpdateTaskCall wrap *gomock.Call
+type MockAdvancedTaskStorageHardUpdateTaskCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *MockTaskGetTasksCall) Return(arg0 []*entity.Task, arg1 error) *MockTaskGetTasksCall {
-	c.Call = c.Call.Return(arg0, arg1)
+func (c *MockAdvancedTaskStorageHardUpdateTaskCall) Return(arg0 error) *MockAdvancedTaskStorageHardUpdateTaskCall {
+	c.Call = c.Call.Return(arg0)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *MockTaskGetTasksCall) Do(f func(context.Context, *dbentity.GetTasksFilter, int64) ([]*entity.Task, error)) *MockTaskGetTasksCall {
+func (c *MockAdvancedTaskStorageHardUpdateTaskCall) Do(f func(context.Context, uuid.UUID, *entity.Task) error) *MockAdvancedTaskStorageHardUpdateTaskCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *MockTaskGetTasksCall) DoAndReturn(f func(context.Context, *dbentity.GetTasksFilter, int64) ([]*entity.Task, error)) *MockTaskGetTasksCall {
+func (c *MockAdvancedTaskStorageHardUpdateTaskCall) DoAndReturn(f func(context.Context, uuid.UUID, *entity.Task) error) *MockAdvancedTaskStorageHardUpdateTaskCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
-// GetTasksForProcessing mocks base method.
-func (m *MockTask) GetTasksForProcessing(ctx context.Context, taskType entity.TaskType, maxTasks int64) ([]*entity.Task, error) {
+// IsTaskTypePaused mocks base method.
+func (m *MockAdvancedTaskStorage) IsTaskTypePaused(ctx context.Context, taskType entity.TaskType) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTasksForProcessing", ctx, taskType, maxTasks)
-	ret0, _ := ret[0].([]*entity.Task)
+	ret := m.ctrl.Call(m, "IsTaskTypePaused", ctx, taskType)
+	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetTasksForProcessing indicates an expected call of GetTasksForProcessing.
-func (mr *MockTaskMockRecorder) GetTasksForProcessing(ctx, taskType, maxTasks any) *MockTaskGetTasksForProcessingCall {
+// IsTaskTypePaused indicates an expected call of IsTaskTypePaused.
+func (mr *MockAdvancedTaskStorageMockRecorder) IsTaskTypePaused(ctx, taskType any) *MockAdvancedTaskStorageIsTaskTypePausedCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasksForProcessing", reflect.TypeOf((*MockTask)(nil).GetTasksForProcessing), ctx, taskType, maxTasks)
-	return &MockTaskGetTasksForProcessingCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsTaskTypePaused", reflect.TypeOf((*MockAdvancedTaskStorage)(nil).IsTaskTypePaused), ctx, taskType)
+	return &MockAdvancedTaskStorageIsTaskTypePausedCall{Call: call}
 }
 
-// MockTaskGetTasksForProcessingCall wrap *gomock.Call
-type MockTaskGetTasksForProcessingCall struct {
+// MockAdvancedTaskStorageIsTaskTypePausedCall wrap *gomock.Call
+type MockAdvancedTaskStorageIsTaskTypePausedCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *MockTaskGetTasksForProcessingCall) Return(arg0 []*entity.Task, arg1 error) *MockTaskGetTasksForProcessingCall {
+func (c *MockAdvancedTaskStorageIsTaskTypePausedCall) Return(arg0 bool, arg1 error) *MockAdvancedTaskStorageIsTaskTypePausedCall {
 	c.Call = c.Call.Return(arg0, arg1)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *MockTaskGetTasksForProcessingCall) Do(f func(context.Context, entity.TaskType, int64) ([]*entity.Task, error)) *MockTaskGetTasksForProcessingCall {
+func (c *MockAdvancedTaskStorageIsTaskTypePausedCall) Do(f func(context.Context, entity.TaskType) (bool, error)) *MockAdvancedTaskStorageIsTaskTypePausedCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *MockTaskGetTasksForProcessingCall) DoAndReturn(f func(context.Context, entity.TaskType, int64) ([]*entity.Task, error)) *MockTaskGetTasksForProcessingCall {
+func (c *MockAdvancedTaskStorageIsTaskTypePausedCall) DoAndReturn(f func(context.Context, entity.TaskType) (bool, error)) *MockAdvancedTaskStorageIsTaskTypePausedCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
-// ResetAttempts mocks base method.
-func (m *MockTask) ResetAttempts(ctx context.Context, taskID uuid.UUID) error {
+// MoveTaskToDead mocks base method.
+func (m *MockAdvancedTaskStorage) MoveTaskToDead(ctx context.Context, task *entity.Task) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ResetAttempts", ctx, taskID)
+	ret := m.ctrl.Call(m, "MoveTaskToDead", ctx, task)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// ResetAttempts indicates an expected call of ResetAttempts.
-func (mr *MockTaskMockRecorder) ResetAttempts(ctx, taskID any) *MockTaskResetAttemptsCall {
+// MoveTaskToDead indicates an expected call of MoveTaskToDead.
+func (mr *MockAdvancedTaskStorageMockRecorder) MoveTaskToDead(ctx, task any) *MockAdvancedTaskStorageMoveTaskToDeadCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetAttempts", reflect.TypeOf((*MockTask)(nil).ResetAttempts), ctx, taskID)
-	return &MockTaskResetAttemptsCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveTaskToDead", reflect.TypeOf((*MockAdvancedTaskStorage)(nil).MoveTaskToDead), ctx, task)
+	return &MockAdvancedTaskStorageMoveTaskToDeadCall{Call: call}
 }
 
-// MockTaskResetAttemptsCall wrap *gomock.Call
-type MockTaskResetAttemptsCall struct {
+// MockAdvancedTaskStorageMoveTaskToDeadCall wrap *gomock.Call
+type MockAdvancedTaskStorageMoveTaskToDeadCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *MockTaskResetAttemptsCall) Return(arg0 error) *MockTaskResetAttemptsCall {
+func (c *MockAdvancedTaskStorageMoveTaskToDeadCall) Return(arg0 error) *MockAdvancedTaskStorageMoveTaskToDeadCall {
 	c.Call = c.Call.Return(arg0)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *MockTaskResetAttemptsCall) Do(f func(context.Context, uuid.UUID) error) *MockTaskResetAttemptsCall {
+func (c *MockAdvancedTaskStorageMoveTaskToDeadCall) Do(f func(context.Context, *entity.Task) error) *MockAdvancedTaskStorageMoveTaskToDeadCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *MockTaskResetAttemptsCall) DoAndReturn(f func(context.Context, uuid.UUID) error) *MockTaskResetAttemptsCall {
+func (c *MockAdvancedTaskStorageMoveTaskToDeadCall) DoAndReturn(f func(context.Context, *entity.Task) error) *MockAdvancedTaskStorageMoveTaskToDeadCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
-// UpdateTask mocks base method.
-func (m *MockTask) UpdateTask(ctx context.Context, taskID uuid.UUID, task *entity.Task) error {
+// PauseTaskType mocks base method.
+func (m *MockAdvancedTaskStorage) PauseTaskType(ctx context.Context, taskType entity.TaskType) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateTask", ctx, taskID, task)
+	ret := m.ctrl.Call(m, "PauseTaskType", ctx, taskType)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// UpdateTask indicates an expected call of UpdateTask.
-func (mr *MockTaskMockRecorder) UpdateTask(ctx, taskID, task any) *MockTaskUpdateTaskCall {
+// PauseTaskType indicates an expected call of PauseTaskType.
+func (mr *MockAdvancedTaskStorageMockRecorder) PauseTaskType(ctx, taskType any) *MockAdvancedTaskStoragePauseTaskTypeCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTask", reflect.TypeOf((*MockTask)(nil).UpdateTask), ctx, taskID, task)
-	return &MockTaskUpdateTaskCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PauseTaskType", reflect.TypeOf((*MockAdvancedTaskStorage)(nil).PauseTaskType), ctx, taskType)
+	return &MockAdvancedTaskStoragePauseTaskTypeCall{Call: call}
 }
 
-// MockTaskUpdateTaskCall wrap *gomock.Call
-type MockTaskUpdateTaskCall struct {
+// MockAdvancedTaskStoragePauseTaskTypeCall wrap *gomock.Call
+type MockAdvancedTaskStoragePauseTaskTypeCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *MockTaskUpdateTaskCall) Return(arg0 error) *MockTaskUpdateTaskCall {
+func (c *MockAdvancedTaskStoragePauseTaskTypeCall) Return(arg0 error) *MockAdvancedTaskStoragePauseTaskTypeCall {
 	c.Call = c.Call.Return(arg0)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *MockTaskUpdateTaskCall) Do(f func(context.Context, uuid.UUID, *entity.Task) error) *MockTaskUpdateTaskCall {
+func (c *MockAdvancedTaskStoragePauseTaskTypeCall) Do(f func(context.Context, entity.TaskType) error) *MockAdvancedTaskStoragePauseTaskTypeCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *MockTaskUpdateTaskCall) DoAndReturn(f func(context.Context, uuid.UUID, *entity.Task) error) *MockTaskUpdateTaskCall {
+func (c *MockAdvancedTaskStoragePauseTaskTypeCall) DoAndReturn(f func(context.Context, entity.TaskType) error) *MockAdvancedTaskStoragePauseTaskTypeCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
-// MockAdvancedTaskStorage is a mock of AdvancedTaskStorage interface.
-type MockAdvancedTaskStorage struct {
-	ctrl     *gomock.Controller
-	recorder *MockAdvancedTaskStorageMockRecorder
-	isgomock struct{}
-}
-
-// MockAdvancedTaskStorageMockRecorder is the mock recorder for MockAdvancedTaskStorage.
-type MockAdvancedTaskStorageMockRecorder struct {
-	mock *MockAdvancedTaskStorage
-}
-
-// NewMockAdvancedTaskStorage creates a new mock instance.
-func NewMockAdvancedTaskStorage(ctrl *gomock.Controller) *MockAdvancedTaskStorage {
-	mock := &MockAdvancedTaskStorage{ctrl: ctrl}
-	mock.recorder = &MockAdvancedTaskStorageMockRecorder{mock}
-	return mock
-}
-
-// EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockAdvancedTaskStorage) EXPECT() *MockAdvancedTaskStorageMockRecorder {
-	return m.recorder
-}
-
-// AddTask mocks base method.
-func (m *MockAdvancedTaskStorage) AddTask(ctx context.Context, task *entity.Task) error {
+// RenewTaskLeases mocks base method.
+func (m *MockAdvancedTaskStorage) RenewTaskLeases(ctx context.Context, taskIDs []uuid.UUID, lease entity.TaskLease) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddTask", ctx, task)
+	ret := m.ctrl.Call(m, "RenewTaskLeases", ctx, taskIDs, lease)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// AddTask indicates an expected call of AddTask.
-func (mr *MockAdvancedTaskStorageMockRecorder) AddTask(ctx, task any) *MockAdvancedTaskStorageAddTaskCall {
+// RenewTaskLeases indicates an expected call of RenewTaskLeases.
+func (mr *MockAdvancedTaskStorageMockRecorder) RenewTaskLeases(ctx, taskIDs, lease any) *MockAdvancedTaskStorageRenewTaskLeasesCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTask", reflect.TypeOf((*MockAdvancedTaskStorage)(nil).AddTask), ctx, task)
-	return &MockAdvancedTaskStorageAddTaskCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenewTaskLeases", reflect.TypeOf((*MockAdvancedTaskStorage)(nil).RenewTaskLeases), ctx, taskIDs, lease)
+	return &MockAdvancedTaskStorageRenewTaskLeasesCall{Call: call}
 }
 
-// MockAdvancedTaskStorageAddTaskCall wrap *gomock.Call
-type MockAdvancedTaskStorageAddTaskCall struct {
+// MockAdvancedTaskStorageRenewTaskLeasesCall wrap *gomock.Call
+type MockAdvancedTaskStorageRenewTaskLeasesCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *MockAdvancedTaskStorageAddTaskCall) Return(arg0 error) *MockAdvancedTaskStorageAddTaskCall {
+func (c *MockAdvancedTaskStorageRenewTaskLeasesCall) Return(arg0 error) *MockAdvancedTaskStorageRenewTaskLeasesCall {
 	c.Call = c.Call.Return(arg0)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *MockAdvancedTaskStorageAddTaskCall) Do(f func(context.Context, *entity.Task) error) *MockAdvancedTaskStorageAddTaskCall {
+func (c *MockAdvancedTaskStorageRenewTaskLeasesCall) Do(f func(context.Context, []uuid.UUID, entity.TaskLease) error) *MockAdvancedTaskStorageRenewTaskLeasesCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *MockAdvancedTaskStorageAddTaskCall) DoAndReturn(f func(context.Context, *entity.Task) error) *MockAdvancedTaskStorageAddTaskCall {
+func (c *MockAdvancedTaskStorageRenewTaskLeasesCall) DoAndReturn(f func(context.Context, []uuid.UUID, entity.TaskLease) error) *MockAdvancedTaskStorageRenewTaskLeasesCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
-// CureTasks mocks base method.
-func (m *MockAdvancedTaskStorage) CureTasks(ctx context.Context, taskType entity.TaskType, unhealthStatuses []entity.TaskStatus, updatedAtTimeAgo time.Duration, comment string) ([]*entity.Task, error) {
+// RequeueDeadTasks mocks base method.
+func (m *MockAdvancedTaskStorage) RequeueDeadTasks(ctx context.Context, filter *dbentity.DeadTasksFilter) ([]*entity.Task, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CureTasks", ctx, taskType, unhealthStatuses, updatedAtTimeAgo, comment)
+	ret := m.ctrl.Call(m, "RequeueDeadTasks", ctx, filter)
 	ret0, _ := ret[0].([]*entity.Task)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CureTasks indicates an expected call of CureTasks.
-func (mr *MockAdvancedTaskStorageMockRecorder) CureTasks(ctx, taskType, unhealthStatuses, updatedAtTimeAgo, comment any) *MockAdvancedTaskStorageCureTasksCall {
+// RequeueDeadTasks indicates an expected call of RequeueDeadTasks.
+func (mr *MockAdvancedTaskStorageMockRecorder) RequeueDeadTasks(ctx, filter any) *MockAdvancedTaskStorageRequeueDeadTasksCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CureTasks", reflect.TypeOf((*MockAdvancedTaskStorage)(nil).CureTasks), ctx, taskType, unhealthStatuses, updatedAtTimeAgo, comment)
-	return &MockAdvancedTaskStorageCureTasksCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequeueDeadTasks", reflect.TypeOf((*MockAdvancedTaskStorage)(nil).RequeueDeadTasks), ctx, filter)
+	return &MockAdvancedTaskStorageRequeueDeadTasksCall{Call: call}
 }
 
-// MockAdvancedTaskStorageCureTasksCall wrap *gomock.Call
-type MockAdvancedTaskStorageCureTasksCall struct {
+// MockAdvancedTaskStorageRequeueDeadTasksCall wrap *gomock.Call
+type MockAdvancedTaskStorageRequeueDeadTasksCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *MockAdvancedTaskStorageCureTasksCall) Return(arg0 []*entity.Task, arg1 error) *MockAdvancedTaskStorageCureTasksCall {
+func (c *MockAdvancedTaskStorageRequeueDeadTasksCall) Return(arg0 []*entity.Task, arg1 error) *MockAdvancedTaskStorageRequeueDeadTasksCall {
 	c.Call = c.Call.Return(arg0, arg1)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *MockAdvancedTaskStorageCureTasksCall) Do(f func(context.Context, entity.TaskType, []entity.TaskStatus, time.Duration, string) ([]*entity.Task, error)) *MockAdvancedTaskStorageCureTasksCall {
+func (c *MockAdvancedTaskStorageRequeueDeadTasksCall) Do(f func(context.Context, *dbentity.DeadTasksFilter) ([]*entity.Task, error)) *MockAdvancedTaskStorageRequeueDeadTasksCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *MockAdvancedTaskStorageCureTasksCall) DoAndReturn(f func(context.Context, entity.TaskType, []entity.TaskStatus, time.Duration, string) ([]*entity.Task, error)) *MockAdvancedTaskStorageCureTasksCall {
+func (c *MockAdvancedTaskStorageRequeueDeadTasksCall) DoAndReturn(f func(context.Context, *dbentity.DeadTasksFilter) ([]*entity.Task, error)) *MockAdvancedTaskStorageRequeueDeadTasksCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
-// DeleteTasks mocks base method.
-func (m *MockAdvancedTaskStorage) DeleteTasks(ctx context.Context, taskType entity.TaskType, statuses []entity.TaskStatus, updatedAtTimeAgo time.Duration) ([]*entity.Task, error) {
+// ResetAttempts mocks base method.
+func (m *MockAdvancedTaskStorage) ResetAttempts(ctx context.Context, taskID uuid.UUID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteTasks", ctx, taskType, statuses, updatedAtTimeAgo)
-	ret0, _ := ret[0].([]*entity.Task)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "ResetAttempts", ctx, taskID)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// DeleteTasks indicates an expected call of DeleteTasks.
-func (mr *MockAdvancedTaskStorageMockRecorder) DeleteTasks(ctx, taskType, statuses, updatedAtTimeAgo any) *MockAdvancedTaskStorageDeleteTasksCall {
+// ResetAttempts indicates an expected call of ResetAttempts.
+func (mr *MockAdvancedTaskStorageMockRecorder) ResetAttempts(ctx, taskID any) *MockAdvancedTaskStorageResetAttemptsCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTasks", reflect.TypeOf((*MockAdvancedTaskStorage)(nil).DeleteTasks), ctx, taskType, statuses, updatedAtTimeAgo)
-	return &MockAdvancedTaskStorageDeleteTasksCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetAttempts", reflect.TypeOf((*MockAdvancedTaskStorage)(nil).ResetAttempts), ctx, taskID)
+	return &MockAdvancedTaskStorageResetAttemptsCall{Call: call}
 }
 
-// MockAdvancedTaskStorageDeleteTasksCall wrap *gomock.Call
-type MockAdvancedTaskStorageDeleteTasksCall struct {
+// MockAdvancedTaskStorageResetAttemptsCall wrap *gomock.Call
+type MockAdvancedTaskStorageResetAttemptsCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *MockAdvancedTaskStorageDeleteTasksCall) Return(arg0 []*entity.Task, arg1 error) *MockAdvancedTaskStorageDeleteTasksCall {
-	c.Call = c.Call.Return(arg0, arg1)
+func (c *MockAdvancedTaskStorageResetAttemptsCall) Return(arg0 error) *MockAdvancedTaskStorageResetAttemptsCall {
+	c.Call = c.Call.Return(arg0)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *MockAdvancedTaskStorageDeleteTasksCall) Do(f func(context.Context, entity.TaskType, []entity.TaskStatus, time.Duration) ([]*entity.Task, error)) *MockAdvancedTaskStorageDeleteTasksCall {
+func (c *MockAdvancedTaskStorageResetAttemptsCall) Do(f func(context.Context, uuid.UUID) error) *MockAdvancedTaskStorageResetAttemptsCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *MockAdvancedTaskStorageDeleteTasksCall) DoAndReturn(f func(context.Context, entity.TaskType, []entity.TaskStatus, time.Duration) ([]*entity.Task, error)) *MockAdvancedTaskStorageDeleteTasksCall {
+func (c *MockAdvancedTaskStorageResetAttemptsCall) DoAndReturn(f func(context.Context, uuid.UUID) error) *MockAdvancedTaskStorageResetAttemptsCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
-// GetDB mocks base method.
-func (m *MockAdvancedTaskStorage) GetDB() *sqlx.DB {
+// ResumeTaskType mocks base method.
+func (m *MockAdvancedTaskStorage) ResumeTaskType(ctx context.Context, taskType entity.TaskType) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetDB")
-	ret0, _ := ret[0].(*sqlx.DB)
+	ret := m.ctrl.Call(m, "ResumeTaskType", ctx, taskType)
+	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// GetDB indicates an expected call of GetDB.
-func (mr *MockAdvancedTaskStorageMockRecorder) GetDB() *MockAdvancedTaskStorageGetDBCall {
+// ResumeTaskType indicates an expected call of ResumeTaskType.
+func (mr *MockAdvancedTaskStorageMockRecorder) ResumeTaskType(ctx, taskType any) *MockAdvancedTaskStorageResumeTaskTypeCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDB", reflect.TypeOf((*MockAdvancedTaskStorage)(nil).GetDB))
-	return &MockAdvancedTaskStorageGetDBCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResumeTaskType", reflect.TypeOf((*MockAdvancedTaskStorage)(nil).ResumeTaskType), ctx, taskType)
+	return &MockAdvancedTaskStorageResumeTaskTypeCall{Call: call}
 }
 
-// MockAdvancedTaskStorageGetDBCall wrap *gomock.Call
-type MockAdvancedTaskStorageGetDBCall struct {
+// MockAdvancedTaskStorageResumeTaskTypeCall wrap *gomock.Call
+type MockAdvancedTaskStorageResumeTaskTypeCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *MockAdvancedTaskStorageGetDBCall) Return(arg0 *sqlx.DB) *MockAdvancedTaskStorageGetDBCall {
+func (c *MockAdvancedTaskStorageResumeTaskTypeCall) Return(arg0 error) *MockAdvancedTaskStorageResumeTaskTypeCall {
 	c.Call = c.Call.Return(arg0)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *MockAdvancedTaskStorageGetDBCall) Do(f func() *sqlx.DB) *MockAdvancedTaskStorageGetDBCall {
+func (c *MockAdvancedTaskStorageResumeTaskTypeCall) Do(f func(context.Context, entity.TaskType) error) *MockAdvancedTaskStorageResumeTaskTypeCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *MockAdvancedTaskStorageGetDBCall) DoAndReturn(f func() *sqlx.DB) *MockAdvancedTaskStorageGetDBCall {
+func (c *MockAdvancedTaskStorageResumeTaskTypeCall) DoAndReturn(f func(context.Context, entity.TaskType) error) *MockAdvancedTaskStorageResumeTaskTypeCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
-// GetTask mocks base method.
-func (m *MockAdvancedTaskStorage) GetTask(ctx context.Context, id uuid.UUID) (*entity.Task, error) {
+// RetryTasks mocks base method.
+func (m *MockAdvancedTaskStorage) RetryTasks(ctx context.Context, filter *dbentity.GetTasksFilter) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTask", ctx, id)
-	ret0, _ := ret[0].(*entity.Task)
+	ret := m.ctrl.Call(m, "RetryTasks", ctx, filter)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetTask indicates an expected call of GetTask.
-func (mr *MockAdvancedTaskStorageMockRecorder) GetTask(ctx, id any) *MockAdvancedTaskStorageGetTaskCall {
+// RetryTasks indicates an expected call of RetryTasks.
+func (mr *MockAdvancedTaskStorageMockRecorder) RetryTasks(ctx, filter any) *MockAdvancedTaskStorageRetryTasksCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTask", reflect.TypeOf((*MockAdvancedTaskStorage)(nil).GetTask), ctx, id)
-	return &MockAdvancedTaskStorageGetTaskCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetryTasks", reflect.TypeOf((*MockAdvancedTaskStorage)(nil).RetryTasks), ctx, filter)
+	return &MockAdvancedTaskStorageRetryTasksCall{Call: call}
 }
 
-// MockAdvancedTaskStorageGetTaskCall wrap *gomock.Call
-type MockAdvancedTaskStorageGetTaskCall struct {
+// MockAdvancedTaskStorageRetryTasksCall wrap *gomock.Call
+type MockAdvancedTaskStorageRetryTasksCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *MockAdvancedTaskStorageGetTaskCall) Return(arg0 *entity.Task, arg1 error) *MockAdvancedTaskStorageGetTaskCall {
+func (c *MockAdvancedTaskStorageRetryTasksCall) Return(arg0 int64, arg1 error) *MockAdvancedTaskStorageRetryTasksCall {
 	c.Call = c.Call.Return(arg0, arg1)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *MockAdvancedTaskStorageGetTaskCall) Do(f func(context.Context, uuid.UUID) (*entity.Task, error)) *MockAdvancedTaskStorageGetTaskCall {
+func (c *MockAdvancedTaskStorageRetryTasksCall) Do(f func(context.Context, *dbentity.GetTasksFilter) (int64, error)) *MockAdvancedTaskStorageRetryTasksCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *MockAdvancedTaskStorageGetTaskCall) DoAndReturn(f func(context.Context, uuid.UUID) (*entity.Task, error)) *MockAdvancedTaskStorageGetTaskCall {
+func (c *MockAdvancedTaskStorageRetryTasksCall) DoAndReturn(f func(context.Context, *dbentity.GetTasksFilter) (int64, error)) *MockAdvancedTaskStorageRetryTasksCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
-// GetTasks mocks base method.
-func (m *MockAdvancedTaskStorage) GetTasks(ctx context.Context, filter *dbentity.GetTasksFilter, limit int64) ([]*entity.Task, error) {
+// ReturnRateLimitTokens mocks base method.
+func (m *MockAdvancedTaskStorage) ReturnRateLimitTokens(ctx context.Context, name string, limit entity.RateLimit, tokens int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTasks", ctx, filter, limit)
-	ret0, _ := ret[0].([]*entity.Task)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "ReturnRateLimitTokens", ctx, name, limit, tokens)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetTasks indicates an expected call of GetTasks.
-func (mr *MockAdvancedTaskStorageMockRecorder) GetTasks(ctx, filter, limit any) *MockAdvancedTaskStorageGetTasksCall {
+// ReturnRateLimitTokens indicates an expected call of ReturnRateLimitTokens.
+func (mr *MockAdvancedTaskStorageMockRecorder) ReturnRateLimitTokens(ctx, name, limit, tokens any) *MockAdvancedTaskStorageReturnRateLimitTokensCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasks", reflect.TypeOf((*MockAdvancedTaskStorage)(nil).GetTasks), ctx, filter, limit)
-	return &MockAdvancedTaskStorageGetTasksCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReturnRateLimitTokens", reflect.TypeOf((*MockAdvancedTaskStorage)(nil).ReturnRateLimitTokens), ctx, name, limit, tokens)
+	return &MockAdvancedTaskStorageReturnRateLimitTokensCall{Call: call}
 }
 
-// MockAdvancedTaskStorageGetTasksCall wrap *gomock.Call
-type MockAdvancedTaskStorageGetTasksCall struct {
+// MockAdvancedTaskStorageReturnRateLimitTokensCall wrap *gomock.Call
+type MockAdvancedTaskStorageReturnRateLimitTokensCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *MockAdvancedTaskStorageGetTasksCall) Return(arg0 []*entity.Task, arg1 error) *MockAdvancedTaskStorageGetTasksCall {
-	c.Call = c.Call.Return(arg0, arg1)
+func (c *MockAdvancedTaskStorageReturnRateLimitTokensCall) Return(arg0 error) *MockAdvancedTaskStorageReturnRateLimitTokensCall {
+	c.Call = c.Call.Return(arg0)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *MockAdvancedTaskStorageGetTasksCall) Do(f func(context.Context, *dbentity.GetTasksFilter, int64) ([]*entity.Task, error)) *MockAdvancedTaskStorageGetTasksCall {
+func (c *MockAdvancedTaskStorageReturnRateLimitTokensCall) Do(f func(context.Context, string, entity.RateLimit, int64) error) *MockAdvancedTaskStorageReturnRateLimitTokensCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *MockAdvancedTaskStorageGetTasksCall) DoAndReturn(f func(context.Context, *dbentity.GetTasksFilter, int64) ([]*entity.Task, error)) *MockAdvancedTaskStorageGetTasksCall {
+func (c *MockAdvancedTaskStorageReturnRateLimitTokensCall) DoAndReturn(f func(context.Context, string, entity.RateLimit, int64) error) *MockAdvancedTaskStorageReturnRateLimitTokensCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
-// GetTasksForProcessing mocks base method.
-func (m *MockAdvancedTaskStorage) GetTasksForProcessing(ctx context.Context, taskType entity.TaskType, maxTasks int64) ([]*entity.Task, error) {
+// SaveTaskCheckpoint mocks base method.
+func (m *MockAdvancedTaskStorage) SaveTaskCheckpoint(ctx context.Context, task *entity.Task) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTasksForProcessing", ctx, taskType, maxTasks)
-	ret0, _ := ret[0].([]*entity.Task)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "SaveTaskCheckpoint", ctx, task)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetTasksForProcessing indicates an expected call of GetTasksForProcessing.
-func (mr *MockAdvancedTaskStorageMockRecorder) GetTasksForProcessing(ctx, taskType, maxTasks any) *MockAdvancedTaskStorageGetTasksForProcessingCall {
+// SaveTaskCheckpoint indicates an expected call of SaveTaskCheckpoint.
+func (mr *MockAdvancedTaskStorageMockRecorder) SaveTaskCheckpoint(ctx, task any) *MockAdvancedTaskStorageSaveTaskCheckpointCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasksForProcessing", reflect.TypeOf((*MockAdvancedTaskStorage)(nil).GetTasksForProcessing), ctx, taskType, maxTasks)
-	return &MockAdvancedTaskStorageGetTasksForProcessingCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTaskCheckpoint", reflect.TypeOf((*MockAdvancedTaskStorage)(nil).SaveTaskCheckpoint), ctx, task)
+	return &MockAdvancedTaskStorageSaveTaskCheckpointCall{Call: call}
 }
 
-// MockAdvancedTaskStorageGetTasksForProcessingCall wrap *gomock.Call
-type MockAdvancedTaskStorageGetTasksForProcessingCall struct {
+// MockAdvancedTaskStorageSaveTaskCheckpointCall wrap *gomock.Call
+type MockAdvancedTaskStorageSaveTaskCheckpointCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *MockAdvancedTaskStorageGetTasksForProcessingCall) Return(arg0 []*entity.Task, arg1 error) *MockAdvancedTaskStorageGetTasksForProcessingCall {
-	c.Call = c.Call.Return(arg0, arg1)
+func (c *MockAdvancedTaskStorageSaveTaskCheckpointCall) Return(arg0 error) *MockAdvancedTaskStorageSaveTaskCheckpointCall {
+	c.Call = c.Call.Return(arg0)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *MockAdvancedTaskStorageGetTasksForProcessingCall) Do(f func(context.Context, entity.TaskType, int64) ([]*entity.Task, error)) *MockAdvancedTaskStorageGetTasksForProcessingCall {
+func (c *MockAdvancedTaskStorageSaveTaskCheckpointCall) Do(f func(context.Context, *entity.Task) error) *MockAdvancedTaskStorageSaveTaskCheckpointCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *MockAdvancedTaskStorageGetTasksForProcessingCall) DoAndReturn(f func(context.Context, entity.TaskType, int64) ([]*entity.Task, error)) *MockAdvancedTaskStorageGetTasksForProcessingCall {
+func (c *MockAdvancedTaskStorageSaveTaskCheckpointCall) DoAndReturn(f func(context.Context, *entity.Task) error) *MockAdvancedTaskStorageSaveTaskCheckpointCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
-// HardUpdateTask mocks base method.
-func (m *MockAdvancedTaskStorage) HardUpdateTask(ctx context.Context, taskID uuid.UUID, task *entity.Task) error {
+// Stats mocks base method.
+func (m *MockAdvancedTaskStorage) Stats(ctx context.Context, filter *dbentity.GetTasksFilter) ([]*entity.TaskStats, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "HardUpdateTask", ctx, taskID, task)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "Stats", ctx, filter)
+	ret0, _ := ret[0].([]*entity.TaskStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// HardUpdateTask indicates an expected call of HardUpdateTask.
-func (mr *MockAdvancedTaskStorageMockRecorder) HardUpdateTask(ctx, taskID, task any) *MockAdvancedTaskStorageHardUpdateTaskCall {
+// Stats indicates an expected call of Stats.
+func (mr *MockAdvancedTaskStorageMockRecorder) Stats(ctx, filter any) *MockAdvancedTaskStorageStatsCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HardUpdateTask", reflect.TypeOf((*MockAdvancedTaskStorage)(nil).HardUpdateTask), ctx, taskID, task)
-	return &MockAdvancedTaskStorageHardUpdateTaskCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stats", reflect.TypeOf((*MockAdvancedTaskStorage)(nil).Stats), ctx, filter)
+	return &MockAdvancedTaskStorageStatsCall{Call: call}
 }
 
-// MockAdvancedTaskStorageHardUpdateTaskCall wrap *gomock.Call
-type MockAdvancedTaskStorageHardUpdateTaskCall struct {
+// MockAdvancedTaskStorageStatsCall wrap *gomock.Call
+type MockAdvancedTaskStorageStatsCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *MockAdvancedTaskStorageHardUpdateTaskCall) Return(arg0 error) *MockAdvancedTaskStorageHardUpdateTaskCall {
-	c.Call = c.Call.Return(arg0)
+func (c *MockAdvancedTaskStorageStatsCall) Return(arg0 []*entity.TaskStats, arg1 error) *MockAdvancedTaskStorageStatsCall {
+	c.Call = c.Call.Return(arg0, arg1)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *MockAdvancedTaskStorageHardUpdateTaskCall) Do(f func(context.Context, uuid.UUID, *entity.Task) error) *MockAdvancedTaskStorageHardUpdateTaskCall {
+func (c *MockAdvancedTaskStorageStatsCall) Do(f func(context.Context, *dbentity.GetTasksFilter) ([]*entity.TaskStats, error)) *MockAdvancedTaskStorageStatsCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *MockAdvancedTaskStorageHardUpdateTaskCall) DoAndReturn(f func(context.Context, uuid.UUID, *entity.Task) error) *MockAdvancedTaskStorageHardUpdateTaskCall {
+func (c *MockAdvancedTaskStorageStatsCall) DoAndReturn(f func(context.Context, *dbentity.GetTasksFilter) ([]*entity.TaskStats, error)) *MockAdvancedTaskStorageStatsCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
-// ResetAttempts mocks base method.
-func (m *MockAdvancedTaskStorage) ResetAttempts(ctx context.Context, taskID uuid.UUID) error {
+// TakeRateLimitTokens mocks base method.
+func (m *MockAdvancedTaskStorage) TakeRateLimitTokens(ctx context.Context, name string, limit entity.RateLimit, maxTokens int64) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ResetAttempts", ctx, taskID)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "TakeRateLimitTokens", ctx, name, limit, maxTokens)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// ResetAttempts indicates an expected call of ResetAttempts.
-func (mr *MockAdvancedTaskStorageMockRecorder) ResetAttempts(ctx, taskID any) *MockAdvancedTaskStorageResetAttemptsCall {
+// TakeRateLimitTokens indicates an expected call of TakeRateLimitTokens.
+func (mr *MockAdvancedTaskStorageMockRecorder) TakeRateLimitTokens(ctx, name, limit, maxTokens any) *MockAdvancedTaskStorageTakeRateLimitTokensCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetAttempts", reflect.TypeOf((*MockAdvancedTaskStorage)(nil).ResetAttempts), ctx, taskID)
-	return &MockAdvancedTaskStorageResetAttemptsCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TakeRateLimitTokens", reflect.TypeOf((*MockAdvancedTaskStorage)(nil).TakeRateLimitTokens), ctx, name, limit, maxTokens)
+	return &MockAdvancedTaskStorageTakeRateLimitTokensCall{Call: call}
 }
 
-// MockAdvancedTaskStorageResetAttemptsCall wrap *gomock.Call
-type MockAdvancedTaskStorageResetAttemptsCall struct {
+// MockAdvancedTaskStorageTakeRateLimitTokensCall wrap *gomock.Call
+type MockAdvancedTaskStorageTakeRateLimitTokensCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *MockAdvancedTaskStorageResetAttemptsCall) Return(arg0 error) *MockAdvancedTaskStorageResetAttemptsCall {
-	c.Call = c.Call.Return(arg0)
+func (c *MockAdvancedTaskStorageTakeRateLimitTokensCall) Return(arg0 int64, arg1 error) *MockAdvancedTaskStorageTakeRateLimitTokensCall {
+	c.Call = c.Call.Return(arg0, arg1)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *MockAdvancedTaskStorageResetAttemptsCall) Do(f func(context.Context, uuid.UUID) error) *MockAdvancedTaskStorageResetAttemptsCall {
+func (c *MockAdvancedTaskStorageTakeRateLimitTokensCall) Do(f func(context.Context, string, entity.RateLimit, int64) (int64, error)) *MockAdvancedTaskStorageTakeRateLimitTokensCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *MockAdvancedTaskStorageResetAttemptsCall) DoAndReturn(f func(context.Context, uuid.UUID) error) *MockAdvancedTaskStorageResetAttemptsCall {
+func (c *MockAdvancedTaskStorageTakeRateLimitTokensCall) DoAndReturn(f func(context.Context, string, entity.RateLimit, int64) (int64, error)) *MockAdvancedTaskStorageTakeRateLimitTokensCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
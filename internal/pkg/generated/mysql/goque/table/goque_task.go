@@ -17,17 +17,29 @@ type goqueTaskTable struct {
 	mysql.Table
 
 	// Columns
-	ID            mysql.ColumnString
-	Type          mysql.ColumnString
-	ExternalID    mysql.ColumnString
-	Payload       mysql.ColumnString
-	Status        mysql.ColumnString
-	Attempts      mysql.ColumnInteger
-	Errors        mysql.ColumnString
-	Metadata      mysql.ColumnString
-	CreatedAt     mysql.ColumnTimestamp
-	UpdatedAt     mysql.ColumnTimestamp
-	NextAttemptAt mysql.ColumnTimestamp
+	ID             mysql.ColumnString
+	Type           mysql.ColumnString
+	ExternalID     mysql.ColumnString
+	Payload        mysql.ColumnString
+	Status         mysql.ColumnString
+	Attempts       mysql.ColumnInteger
+	Errors         mysql.ColumnString
+	Metadata       mysql.ColumnString
+	CreatedAt      mysql.ColumnTimestamp
+	UpdatedAt      mysql.ColumnTimestamp
+	NextAttemptAt  mysql.ColumnTimestamp
+	Priority       mysql.ColumnInteger
+	LockedBy       mysql.ColumnString
+	LeaseExpiresAt mysql.ColumnTimestamp
+	Version        mysql.ColumnInteger
+	TimeoutMs      mysql.ColumnInteger
+	MaxAttempts    mysql.ColumnInteger
+	ConcurrencyKey mysql.ColumnString
+	Result         mysql.ColumnString
+	Checkpoint     mysql.ColumnString
+	Progress       mysql.ColumnInteger
+	BatchID        mysql.ColumnString
+	RetryDelayMs   mysql.ColumnInteger
 
 	AllColumns     mysql.ColumnList
 	MutableColumns mysql.ColumnList
@@ -69,37 +81,61 @@ func newGoqueTaskTable(schemaName, tableName, alias string) *GoqueTaskTable {
 
 func newGoqueTaskTableImpl(schemaName, tableName, alias string) goqueTaskTable {
 	var (
-		IDColumn            = mysql.StringColumn("id")
-		TypeColumn          = mysql.StringColumn("type")
-		ExternalIDColumn    = mysql.StringColumn("external_id")
-		PayloadColumn       = mysql.StringColumn("payload")
-		StatusColumn        = mysql.StringColumn("status")
-		AttemptsColumn      = mysql.IntegerColumn("attempts")
-		ErrorsColumn        = mysql.StringColumn("errors")
-		MetadataColumn      = mysql.StringColumn("metadata")
-		CreatedAtColumn     = mysql.TimestampColumn("created_at")
-		UpdatedAtColumn     = mysql.TimestampColumn("updated_at")
-		NextAttemptAtColumn = mysql.TimestampColumn("next_attempt_at")
-		allColumns          = mysql.ColumnList{IDColumn, TypeColumn, ExternalIDColumn, PayloadColumn, StatusColumn, AttemptsColumn, ErrorsColumn, MetadataColumn, CreatedAtColumn, UpdatedAtColumn, NextAttemptAtColumn}
-		mutableColumns      = mysql.ColumnList{TypeColumn, ExternalIDColumn, PayloadColumn, StatusColumn, AttemptsColumn, ErrorsColumn, MetadataColumn, CreatedAtColumn, UpdatedAtColumn, NextAttemptAtColumn}
-		defaultColumns      = mysql.ColumnList{CreatedAtColumn, NextAttemptAtColumn}
+		IDColumn             = mysql.StringColumn("id")
+		TypeColumn           = mysql.StringColumn("type")
+		ExternalIDColumn     = mysql.StringColumn("external_id")
+		PayloadColumn        = mysql.StringColumn("payload")
+		StatusColumn         = mysql.StringColumn("status")
+		AttemptsColumn       = mysql.IntegerColumn("attempts")
+		ErrorsColumn         = mysql.StringColumn("errors")
+		MetadataColumn       = mysql.StringColumn("metadata")
+		CreatedAtColumn      = mysql.TimestampColumn("created_at")
+		UpdatedAtColumn      = mysql.TimestampColumn("updated_at")
+		NextAttemptAtColumn  = mysql.TimestampColumn("next_attempt_at")
+		PriorityColumn       = mysql.IntegerColumn("priority")
+		LockedByColumn       = mysql.StringColumn("locked_by")
+		LeaseExpiresAtColumn = mysql.TimestampColumn("lease_expires_at")
+		VersionColumn        = mysql.IntegerColumn("version")
+		TimeoutMsColumn      = mysql.IntegerColumn("timeout_ms")
+		MaxAttemptsColumn    = mysql.IntegerColumn("max_attempts")
+		ConcurrencyKeyColumn = mysql.StringColumn("concurrency_key")
+		ResultColumn         = mysql.StringColumn("result")
+		CheckpointColumn     = mysql.StringColumn("checkpoint")
+		ProgressColumn       = mysql.IntegerColumn("progress")
+		BatchIDColumn        = mysql.StringColumn("batch_id")
+		RetryDelayMsColumn   = mysql.IntegerColumn("retry_delay_ms")
+		allColumns           = mysql.ColumnList{IDColumn, TypeColumn, ExternalIDColumn, PayloadColumn, StatusColumn, AttemptsColumn, ErrorsColumn, MetadataColumn, CreatedAtColumn, UpdatedAtColumn, NextAttemptAtColumn, PriorityColumn, LockedByColumn, LeaseExpiresAtColumn, VersionColumn, TimeoutMsColumn, MaxAttemptsColumn, ConcurrencyKeyColumn, ResultColumn, CheckpointColumn, ProgressColumn, BatchIDColumn, RetryDelayMsColumn}
+		mutableColumns       = mysql.ColumnList{TypeColumn, ExternalIDColumn, PayloadColumn, StatusColumn, AttemptsColumn, ErrorsColumn, MetadataColumn, CreatedAtColumn, UpdatedAtColumn, NextAttemptAtColumn, PriorityColumn, LockedByColumn, LeaseExpiresAtColumn, VersionColumn, TimeoutMsColumn, MaxAttemptsColumn, ConcurrencyKeyColumn, ResultColumn, CheckpointColumn, ProgressColumn, BatchIDColumn, RetryDelayMsColumn}
+		defaultColumns       = mysql.ColumnList{CreatedAtColumn, NextAttemptAtColumn, PriorityColumn, VersionColumn, ProgressColumn}
 	)
 
 	return goqueTaskTable{
 		Table: mysql.NewTable(schemaName, tableName, alias, allColumns...),
 
 		//Columns
-		ID:            IDColumn,
-		Type:          TypeColumn,
-		ExternalID:    ExternalIDColumn,
-		Payload:       PayloadColumn,
-		Status:        StatusColumn,
-		Attempts:      AttemptsColumn,
-		Errors:        ErrorsColumn,
-		Metadata:      MetadataColumn,
-		CreatedAt:     CreatedAtColumn,
-		UpdatedAt:     UpdatedAtColumn,
-		NextAttemptAt: NextAttemptAtColumn,
+		ID:             IDColumn,
+		Type:           TypeColumn,
+		ExternalID:     ExternalIDColumn,
+		Payload:        PayloadColumn,
+		Status:         StatusColumn,
+		Attempts:       AttemptsColumn,
+		Errors:         ErrorsColumn,
+		Metadata:       MetadataColumn,
+		CreatedAt:      CreatedAtColumn,
+		UpdatedAt:      UpdatedAtColumn,
+		NextAttemptAt:  NextAttemptAtColumn,
+		Priority:       PriorityColumn,
+		LockedBy:       LockedByColumn,
+		LeaseExpiresAt: LeaseExpiresAtColumn,
+		Version:        VersionColumn,
+		TimeoutMs:      TimeoutMsColumn,
+		MaxAttempts:    MaxAttemptsColumn,
+		ConcurrencyKey: ConcurrencyKeyColumn,
+		Result:         ResultColumn,
+		Checkpoint:     CheckpointColumn,
+		Progress:       ProgressColumn,
+		BatchID:        BatchIDColumn,
+		RetryDelayMs:   RetryDelayMsColumn,
 
 		AllColumns:     allColumns,
 		MutableColumns: mutableColumns,
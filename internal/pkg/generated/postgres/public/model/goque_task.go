@@ -13,15 +13,27 @@ import (
 )
 
 type GoqueTask struct {
-	ID            uuid.UUID  `sql:"primary_key" db:"goque_task.id"`
-	Type          string     `db:"goque_task.type"`
-	ExternalID    string     `db:"goque_task.external_id"`
-	Payload       string     `db:"goque_task.payload"`
-	Status        string     `db:"goque_task.status"`
-	Attempts      int32      `db:"goque_task.attempts"`
-	Errors        *string    `db:"goque_task.errors"`
-	Metadata      *string    `db:"goque_task.metadata"`
-	CreatedAt     time.Time  `db:"goque_task.created_at"`
-	UpdatedAt     *time.Time `db:"goque_task.updated_at"`
-	NextAttemptAt time.Time  `db:"goque_task.next_attempt_at"`
+	ID             uuid.UUID  `sql:"primary_key" db:"goque_task.id"`
+	Type           string     `db:"goque_task.type"`
+	ExternalID     string     `db:"goque_task.external_id"`
+	Payload        string     `db:"goque_task.payload"`
+	Status         string     `db:"goque_task.status"`
+	Attempts       int32      `db:"goque_task.attempts"`
+	Errors         *string    `db:"goque_task.errors"`
+	Metadata       *string    `db:"goque_task.metadata"`
+	CreatedAt      time.Time  `db:"goque_task.created_at"`
+	UpdatedAt      *time.Time `db:"goque_task.updated_at"`
+	NextAttemptAt  time.Time  `db:"goque_task.next_attempt_at"`
+	Priority       int32      `db:"goque_task.priority"`
+	LockedBy       *string    `db:"goque_task.locked_by"`
+	LeaseExpiresAt *time.Time `db:"goque_task.lease_expires_at"`
+	Version        int64      `db:"goque_task.version"`
+	TimeoutMs      *int64     `db:"goque_task.timeout_ms"`
+	MaxAttempts    *int32     `db:"goque_task.max_attempts"`
+	ConcurrencyKey *string    `db:"goque_task.concurrency_key"`
+	Result         *string    `db:"goque_task.result"`
+	Checkpoint     *string    `db:"goque_task.checkpoint"`
+	Progress       int32      `db:"goque_task.progress"`
+	BatchID        *uuid.UUID `db:"goque_task.batch_id"`
+	RetryDelayMs   *int64     `db:"goque_task.retry_delay_ms"`
 }
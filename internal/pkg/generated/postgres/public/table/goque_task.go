@@ -17,17 +17,29 @@ type goqueTaskTable struct {
 	postgres.Table
 
 	// Columns
-	ID            postgres.ColumnString
-	Type          postgres.ColumnString
-	ExternalID    postgres.ColumnString
-	Payload       postgres.ColumnString
-	Status        postgres.ColumnString
-	Attempts      postgres.ColumnInteger
-	Errors        postgres.ColumnString
-	Metadata      postgres.ColumnString
-	CreatedAt     postgres.ColumnTimestampz
-	UpdatedAt     postgres.ColumnTimestampz
-	NextAttemptAt postgres.ColumnTimestampz
+	ID             postgres.ColumnString
+	Type           postgres.ColumnString
+	ExternalID     postgres.ColumnString
+	Payload        postgres.ColumnString
+	Status         postgres.ColumnString
+	Attempts       postgres.ColumnInteger
+	Errors         postgres.ColumnString
+	Metadata       postgres.ColumnString
+	CreatedAt      postgres.ColumnTimestampz
+	UpdatedAt      postgres.ColumnTimestampz
+	NextAttemptAt  postgres.ColumnTimestampz
+	Priority       postgres.ColumnInteger
+	LockedBy       postgres.ColumnString
+	LeaseExpiresAt postgres.ColumnTimestampz
+	Version        postgres.ColumnInteger
+	TimeoutMs      postgres.ColumnInteger
+	MaxAttempts    postgres.ColumnInteger
+	ConcurrencyKey postgres.ColumnString
+	Result         postgres.ColumnString
+	Checkpoint     postgres.ColumnString
+	Progress       postgres.ColumnInteger
+	BatchID        postgres.ColumnString
+	RetryDelayMs   postgres.ColumnInteger
 
 	AllColumns     postgres.ColumnList
 	MutableColumns postgres.ColumnList
@@ -69,37 +81,61 @@ func newGoqueTaskTable(schemaName, tableName, alias string) *GoqueTaskTable {
 
 func newGoqueTaskTableImpl(schemaName, tableName, alias string) goqueTaskTable {
 	var (
-		IDColumn            = postgres.StringColumn("id")
-		TypeColumn          = postgres.StringColumn("type")
-		ExternalIDColumn    = postgres.StringColumn("external_id")
-		PayloadColumn       = postgres.StringColumn("payload")
-		StatusColumn        = postgres.StringColumn("status")
-		AttemptsColumn      = postgres.IntegerColumn("attempts")
-		ErrorsColumn        = postgres.StringColumn("errors")
-		MetadataColumn      = postgres.StringColumn("metadata")
-		CreatedAtColumn     = postgres.TimestampzColumn("created_at")
-		UpdatedAtColumn     = postgres.TimestampzColumn("updated_at")
-		NextAttemptAtColumn = postgres.TimestampzColumn("next_attempt_at")
-		allColumns          = postgres.ColumnList{IDColumn, TypeColumn, ExternalIDColumn, PayloadColumn, StatusColumn, AttemptsColumn, ErrorsColumn, MetadataColumn, CreatedAtColumn, UpdatedAtColumn, NextAttemptAtColumn}
-		mutableColumns      = postgres.ColumnList{TypeColumn, ExternalIDColumn, PayloadColumn, StatusColumn, AttemptsColumn, ErrorsColumn, MetadataColumn, CreatedAtColumn, UpdatedAtColumn, NextAttemptAtColumn}
-		defaultColumns      = postgres.ColumnList{CreatedAtColumn, NextAttemptAtColumn}
+		IDColumn             = postgres.StringColumn("id")
+		TypeColumn           = postgres.StringColumn("type")
+		ExternalIDColumn     = postgres.StringColumn("external_id")
+		PayloadColumn        = postgres.StringColumn("payload")
+		StatusColumn         = postgres.StringColumn("status")
+		AttemptsColumn       = postgres.IntegerColumn("attempts")
+		ErrorsColumn         = postgres.StringColumn("errors")
+		MetadataColumn       = postgres.StringColumn("metadata")
+		CreatedAtColumn      = postgres.TimestampzColumn("created_at")
+		UpdatedAtColumn      = postgres.TimestampzColumn("updated_at")
+		NextAttemptAtColumn  = postgres.TimestampzColumn("next_attempt_at")
+		PriorityColumn       = postgres.IntegerColumn("priority")
+		LockedByColumn       = postgres.StringColumn("locked_by")
+		LeaseExpiresAtColumn = postgres.TimestampzColumn("lease_expires_at")
+		VersionColumn        = postgres.IntegerColumn("version")
+		TimeoutMsColumn      = postgres.IntegerColumn("timeout_ms")
+		MaxAttemptsColumn    = postgres.IntegerColumn("max_attempts")
+		ConcurrencyKeyColumn = postgres.StringColumn("concurrency_key")
+		ResultColumn         = postgres.StringColumn("result")
+		CheckpointColumn     = postgres.StringColumn("checkpoint")
+		ProgressColumn       = postgres.IntegerColumn("progress")
+		BatchIDColumn        = postgres.StringColumn("batch_id")
+		RetryDelayMsColumn   = postgres.IntegerColumn("retry_delay_ms")
+		allColumns           = postgres.ColumnList{IDColumn, TypeColumn, ExternalIDColumn, PayloadColumn, StatusColumn, AttemptsColumn, ErrorsColumn, MetadataColumn, CreatedAtColumn, UpdatedAtColumn, NextAttemptAtColumn, PriorityColumn, LockedByColumn, LeaseExpiresAtColumn, VersionColumn, TimeoutMsColumn, MaxAttemptsColumn, ConcurrencyKeyColumn, ResultColumn, CheckpointColumn, ProgressColumn, BatchIDColumn, RetryDelayMsColumn}
+		mutableColumns       = postgres.ColumnList{TypeColumn, ExternalIDColumn, PayloadColumn, StatusColumn, AttemptsColumn, ErrorsColumn, MetadataColumn, CreatedAtColumn, UpdatedAtColumn, NextAttemptAtColumn, PriorityColumn, LockedByColumn, LeaseExpiresAtColumn, VersionColumn, TimeoutMsColumn, MaxAttemptsColumn, ConcurrencyKeyColumn, ResultColumn, CheckpointColumn, ProgressColumn, BatchIDColumn, RetryDelayMsColumn}
+		defaultColumns       = postgres.ColumnList{CreatedAtColumn, NextAttemptAtColumn, PriorityColumn, VersionColumn, ProgressColumn}
 	)
 
 	return goqueTaskTable{
 		Table: postgres.NewTable(schemaName, tableName, alias, allColumns...),
 
 		//Columns
-		ID:            IDColumn,
-		Type:          TypeColumn,
-		ExternalID:    ExternalIDColumn,
-		Payload:       PayloadColumn,
-		Status:        StatusColumn,
-		Attempts:      AttemptsColumn,
-		Errors:        ErrorsColumn,
-		Metadata:      MetadataColumn,
-		CreatedAt:     CreatedAtColumn,
-		UpdatedAt:     UpdatedAtColumn,
-		NextAttemptAt: NextAttemptAtColumn,
+		ID:             IDColumn,
+		Type:           TypeColumn,
+		ExternalID:     ExternalIDColumn,
+		Payload:        PayloadColumn,
+		Status:         StatusColumn,
+		Attempts:       AttemptsColumn,
+		Errors:         ErrorsColumn,
+		Metadata:       MetadataColumn,
+		CreatedAt:      CreatedAtColumn,
+		UpdatedAt:      UpdatedAtColumn,
+		NextAttemptAt:  NextAttemptAtColumn,
+		Priority:       PriorityColumn,
+		LockedBy:       LockedByColumn,
+		LeaseExpiresAt: LeaseExpiresAtColumn,
+		Version:        VersionColumn,
+		TimeoutMs:      TimeoutMsColumn,
+		MaxAttempts:    MaxAttemptsColumn,
+		ConcurrencyKey: ConcurrencyKeyColumn,
+		Result:         ResultColumn,
+		Checkpoint:     CheckpointColumn,
+		Progress:       ProgressColumn,
+		BatchID:        BatchIDColumn,
+		RetryDelayMs:   RetryDelayMsColumn,
 
 		AllColumns:     allColumns,
 		MutableColumns: mutableColumns,
@@ -17,17 +17,29 @@ type goqueTaskTable struct {
 	sqlite.Table
 
 	// Columns
-	ID            sqlite.ColumnString
-	Type          sqlite.ColumnString
-	ExternalID    sqlite.ColumnString
-	Payload       sqlite.ColumnString
-	Status        sqlite.ColumnString
-	Attempts      sqlite.ColumnInteger
-	Errors        sqlite.ColumnString
-	Metadata      sqlite.ColumnString
-	CreatedAt     sqlite.ColumnString
-	UpdatedAt     sqlite.ColumnString
-	NextAttemptAt sqlite.ColumnString
+	ID             sqlite.ColumnString
+	Type           sqlite.ColumnString
+	ExternalID     sqlite.ColumnString
+	Payload        sqlite.ColumnString
+	Status         sqlite.ColumnString
+	Attempts       sqlite.ColumnInteger
+	Errors         sqlite.ColumnString
+	Metadata       sqlite.ColumnString
+	CreatedAt      sqlite.ColumnString
+	UpdatedAt      sqlite.ColumnString
+	NextAttemptAt  sqlite.ColumnString
+	Priority       sqlite.ColumnInteger
+	LockedBy       sqlite.ColumnString
+	LeaseExpiresAt sqlite.ColumnString
+	Version        sqlite.ColumnInteger
+	TimeoutMs      sqlite.ColumnInteger
+	MaxAttempts    sqlite.ColumnInteger
+	ConcurrencyKey sqlite.ColumnString
+	Result         sqlite.ColumnString
+	Checkpoint     sqlite.ColumnString
+	Progress       sqlite.ColumnInteger
+	BatchID        sqlite.ColumnString
+	RetryDelayMs   sqlite.ColumnInteger
 
 	AllColumns     sqlite.ColumnList
 	MutableColumns sqlite.ColumnList
@@ -69,37 +81,61 @@ func newGoqueTaskTable(schemaName, tableName, alias string) *GoqueTaskTable {
 
 func newGoqueTaskTableImpl(schemaName, tableName, alias string) goqueTaskTable {
 	var (
-		IDColumn            = sqlite.StringColumn("id")
-		TypeColumn          = sqlite.StringColumn("type")
-		ExternalIDColumn    = sqlite.StringColumn("external_id")
-		PayloadColumn       = sqlite.StringColumn("payload")
-		StatusColumn        = sqlite.StringColumn("status")
-		AttemptsColumn      = sqlite.IntegerColumn("attempts")
-		ErrorsColumn        = sqlite.StringColumn("errors")
-		MetadataColumn      = sqlite.StringColumn("metadata")
-		CreatedAtColumn     = sqlite.StringColumn("created_at")
-		UpdatedAtColumn     = sqlite.StringColumn("updated_at")
-		NextAttemptAtColumn = sqlite.StringColumn("next_attempt_at")
-		allColumns          = sqlite.ColumnList{IDColumn, TypeColumn, ExternalIDColumn, PayloadColumn, StatusColumn, AttemptsColumn, ErrorsColumn, MetadataColumn, CreatedAtColumn, UpdatedAtColumn, NextAttemptAtColumn}
-		mutableColumns      = sqlite.ColumnList{TypeColumn, ExternalIDColumn, PayloadColumn, StatusColumn, AttemptsColumn, ErrorsColumn, MetadataColumn, CreatedAtColumn, UpdatedAtColumn, NextAttemptAtColumn}
-		defaultColumns      = sqlite.ColumnList{CreatedAtColumn, NextAttemptAtColumn}
+		IDColumn             = sqlite.StringColumn("id")
+		TypeColumn           = sqlite.StringColumn("type")
+		ExternalIDColumn     = sqlite.StringColumn("external_id")
+		PayloadColumn        = sqlite.StringColumn("payload")
+		StatusColumn         = sqlite.StringColumn("status")
+		AttemptsColumn       = sqlite.IntegerColumn("attempts")
+		ErrorsColumn         = sqlite.StringColumn("errors")
+		MetadataColumn       = sqlite.StringColumn("metadata")
+		CreatedAtColumn      = sqlite.StringColumn("created_at")
+		UpdatedAtColumn      = sqlite.StringColumn("updated_at")
+		NextAttemptAtColumn  = sqlite.StringColumn("next_attempt_at")
+		PriorityColumn       = sqlite.IntegerColumn("priority")
+		LockedByColumn       = sqlite.StringColumn("locked_by")
+		LeaseExpiresAtColumn = sqlite.StringColumn("lease_expires_at")
+		VersionColumn        = sqlite.IntegerColumn("version")
+		TimeoutMsColumn      = sqlite.IntegerColumn("timeout_ms")
+		MaxAttemptsColumn    = sqlite.IntegerColumn("max_attempts")
+		ConcurrencyKeyColumn = sqlite.StringColumn("concurrency_key")
+		ResultColumn         = sqlite.StringColumn("result")
+		CheckpointColumn     = sqlite.StringColumn("checkpoint")
+		ProgressColumn       = sqlite.IntegerColumn("progress")
+		BatchIDColumn        = sqlite.StringColumn("batch_id")
+		RetryDelayMsColumn   = sqlite.IntegerColumn("retry_delay_ms")
+		allColumns           = sqlite.ColumnList{IDColumn, TypeColumn, ExternalIDColumn, PayloadColumn, StatusColumn, AttemptsColumn, ErrorsColumn, MetadataColumn, CreatedAtColumn, UpdatedAtColumn, NextAttemptAtColumn, PriorityColumn, LockedByColumn, LeaseExpiresAtColumn, VersionColumn, TimeoutMsColumn, MaxAttemptsColumn, ConcurrencyKeyColumn, ResultColumn, CheckpointColumn, ProgressColumn, BatchIDColumn, RetryDelayMsColumn}
+		mutableColumns       = sqlite.ColumnList{TypeColumn, ExternalIDColumn, PayloadColumn, StatusColumn, AttemptsColumn, ErrorsColumn, MetadataColumn, CreatedAtColumn, UpdatedAtColumn, NextAttemptAtColumn, PriorityColumn, LockedByColumn, LeaseExpiresAtColumn, VersionColumn, TimeoutMsColumn, MaxAttemptsColumn, ConcurrencyKeyColumn, ResultColumn, CheckpointColumn, ProgressColumn, BatchIDColumn, RetryDelayMsColumn}
+		defaultColumns       = sqlite.ColumnList{CreatedAtColumn, NextAttemptAtColumn, PriorityColumn, VersionColumn, ProgressColumn}
 	)
 
 	return goqueTaskTable{
 		Table: sqlite.NewTable(schemaName, tableName, alias, allColumns...),
 
 		//Columns
-		ID:            IDColumn,
-		Type:          TypeColumn,
-		ExternalID:    ExternalIDColumn,
-		Payload:       PayloadColumn,
-		Status:        StatusColumn,
-		Attempts:      AttemptsColumn,
-		Errors:        ErrorsColumn,
-		Metadata:      MetadataColumn,
-		CreatedAt:     CreatedAtColumn,
-		UpdatedAt:     UpdatedAtColumn,
-		NextAttemptAt: NextAttemptAtColumn,
+		ID:             IDColumn,
+		Type:           TypeColumn,
+		ExternalID:     ExternalIDColumn,
+		Payload:        PayloadColumn,
+		Status:         StatusColumn,
+		Attempts:       AttemptsColumn,
+		Errors:         ErrorsColumn,
+		Metadata:       MetadataColumn,
+		CreatedAt:      CreatedAtColumn,
+		UpdatedAt:      UpdatedAtColumn,
+		NextAttemptAt:  NextAttemptAtColumn,
+		Priority:       PriorityColumn,
+		LockedBy:       LockedByColumn,
+		LeaseExpiresAt: LeaseExpiresAtColumn,
+		Version:        VersionColumn,
+		TimeoutMs:      TimeoutMsColumn,
+		MaxAttempts:    MaxAttemptsColumn,
+		ConcurrencyKey: ConcurrencyKeyColumn,
+		Result:         ResultColumn,
+		Checkpoint:     CheckpointColumn,
+		Progress:       ProgressColumn,
+		BatchID:        BatchIDColumn,
+		RetryDelayMs:   RetryDelayMsColumn,
 
 		AllColumns:     allColumns,
 		MutableColumns: mutableColumns,
@@ -11,5 +11,10 @@ package table
 // this method only once at the beginning of the program.
 func UseSchema(schema string) {
 	GooseDbVersion = GooseDbVersion.FromSchema(schema)
+	GoquePausedTaskType = GoquePausedTaskType.FromSchema(schema)
+	GoqueRateLimit = GoqueRateLimit.FromSchema(schema)
 	GoqueTask = GoqueTask.FromSchema(schema)
+	GoqueTaskBatch = GoqueTaskBatch.FromSchema(schema)
+	GoqueTaskDead = GoqueTaskDead.FromSchema(schema)
+	GoqueTaskDependency = GoqueTaskDependency.FromSchema(schema)
 }
@@ -21,7 +21,8 @@ type HealerTaskStorage interface {
 	CureTasks(ctx context.Context, taskType entity.TaskType, unhealthStatuses []entity.TaskStatus, updatedAtTimeAgo time.Duration, comment string) ([]*entity.Task, error)
 }
 
-// QueueHealer identifies and fixes tasks that have been stuck in pending status for too long.
+// QueueHealer identifies and fixes stuck tasks: pending or processing tasks whose lease has expired,
+// or, for tasks fetched without a lease, that have not been updated for too long.
 type QueueHealer struct {
 	*baseProcessor
 	taskStorage HealerTaskStorage
@@ -46,12 +47,12 @@ func NewQueueHealer(taskStorage HealerTaskStorage, taskType entity.TaskType) *Qu
 	return q
 }
 
-// SetUpdatedAtTimeAgo sets the time threshold for considering a task as stuck.
+// SetUpdatedAtTimeAgo sets the time threshold for considering a task fetched without a lease as stuck.
 func (q *QueueHealer) SetUpdatedAtTimeAgo(updatedAtTimeAgo time.Duration) {
 	q.updatedAtTimeAgo = updatedAtTimeAgo
 }
 
-// CureTasks marks stuck tasks in pending status as errored based on the configured time threshold.
+// CureTasks marks stuck tasks as errored, so they are retried.
 func (q *QueueHealer) CureTasks(ctx context.Context, taskType entity.TaskType) ([]*entity.Task, error) {
 	ctx, span := xlog.WithOperationSpan(ctx, "queue_healer.CureTasks")
 	defer span.End()
@@ -0,0 +1,100 @@
+package queueprocessor
+
+import (
+	"math"
+	"math/rand/v2"
+	"time"
+
+	"github.com/ruko1202/goque/internal/entity"
+	"github.com/ruko1202/goque/internal/utils/xtime"
+)
+
+// BackoffStrategy calculates the next retry time of a failed task.
+type BackoffStrategy interface {
+	// NextAttemptAt returns the time of the next attempt of the task failed with err.
+	// task.Attempts already counts the failed attempt.
+	NextAttemptAt(task *entity.Task, err error) time.Time
+}
+
+// BackoffStrategyFunc is a function type that implements the BackoffStrategy interface.
+type BackoffStrategyFunc func(task *entity.Task, err error) time.Time
+
+// NextAttemptAt calls f(task, err).
+func (f BackoffStrategyFunc) NextAttemptAt(task *entity.Task, err error) time.Time {
+	return f(task, err)
+}
+
+// NextAttemptAt adapts NextAttemptAtFunc to the BackoffStrategy interface, the error is ignored.
+func (f NextAttemptAtFunc) NextAttemptAt(task *entity.Task, _ error) time.Time {
+	return f(task.Attempts)
+}
+
+// ExponentialJitterBackoff creates a strategy with exponential backoff and full jitter:
+// the delay is random between zero and min(maxDelay, base * 2^(attempt-1)).
+// Spreading retries over the whole window avoids synchronized retry storms after an outage.
+// A non-positive base falls back to the default retry period, maxDelay is at least base.
+func ExponentialJitterBackoff(base, maxDelay time.Duration) BackoffStrategy {
+	base, maxDelay = normalizeBackoff(base, maxDelay)
+
+	return BackoffStrategyFunc(func(task *entity.Task, _ error) time.Time {
+		ceil := cappedExpDelay(base, maxDelay, 2, task.Attempts-1)
+		return xtime.Now().Add(randDelay(0, ceil))
+	})
+}
+
+// DecorrelatedJitterBackoff creates a strategy with decorrelated jitter: the delay is random between base
+// and three times the previous delay of the task, min(maxDelay, rand[base, prev * 3]).
+// Each delay grows from the random previous one rather than from the attempt number,
+// so the retries of tasks failed together drift apart. The first retry starts from base.
+// A non-positive base falls back to the default retry period, maxDelay is at least base.
+func DecorrelatedJitterBackoff(base, maxDelay time.Duration) BackoffStrategy {
+	base, maxDelay = normalizeBackoff(base, maxDelay)
+
+	return BackoffStrategyFunc(func(task *entity.Task, _ error) time.Time {
+		prev := base
+		if task.Attempts > 1 && task.RetryDelay != nil {
+			prev = max(base, *task.RetryDelay)
+		}
+
+		ceil := cappedExpDelay(prev, maxDelay, 3, 1)
+		return xtime.Now().Add(randDelay(base, ceil))
+	})
+}
+
+// ExponentialRangeJitterBackoff creates a strategy with exponential backoff and jitter over a range
+// that grows threefold with every attempt: the delay is random between base and min(maxDelay, base * 3^attempt).
+// It is a stateless alternative to DecorrelatedJitterBackoff, the range depends on the attempt number only.
+// Unlike full jitter, a retry never comes sooner than base.
+// A non-positive base falls back to the default retry period, maxDelay is at least base.
+func ExponentialRangeJitterBackoff(base, maxDelay time.Duration) BackoffStrategy {
+	base, maxDelay = normalizeBackoff(base, maxDelay)
+
+	return BackoffStrategyFunc(func(task *entity.Task, _ error) time.Time {
+		ceil := cappedExpDelay(base, maxDelay, 3, task.Attempts)
+		return xtime.Now().Add(randDelay(base, ceil))
+	})
+}
+
+func normalizeBackoff(base, maxDelay time.Duration) (time.Duration, time.Duration) {
+	if base <= 0 {
+		base = defaultProcessorStaticNextAttemptPeriod
+	}
+	return base, max(base, maxDelay)
+}
+
+// cappedExpDelay returns min(maxDelay, base * factor^exp) without overflowing time.Duration.
+func cappedExpDelay(base, maxDelay time.Duration, factor float64, exp int32) time.Duration {
+	delay := float64(base) * math.Pow(factor, float64(max(exp, 0)))
+	if delay >= float64(maxDelay) {
+		return maxDelay
+	}
+	return time.Duration(delay)
+}
+
+// randDelay returns a random delay in [low, high].
+func randDelay(low, high time.Duration) time.Duration {
+	if high <= low {
+		return low
+	}
+	return low + time.Duration(rand.Int64N(int64(high-low)+1)) //nolint:gosec
+}
@@ -0,0 +1,129 @@
+package queueprocessor
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ruko1202/goque/internal/entity"
+	"github.com/ruko1202/goque/internal/utils/xtime"
+)
+
+func TestBackoffStrategy(t *testing.T) {
+	t.Parallel()
+
+	const (
+		base     = time.Second
+		maxDelay = time.Minute
+	)
+	taskErr := errors.New("some error")
+
+	requireTaskDelayWithin := func(t *testing.T, strategy BackoffStrategy, task *entity.Task, low, high time.Duration) {
+		t.Helper()
+		attempts := task.Attempts
+
+		for range 100 {
+			before := xtime.Now()
+			nextAttemptAt := strategy.NextAttemptAt(task, taskErr)
+			after := xtime.Now()
+
+			require.False(t, nextAttemptAt.Before(before.Add(low)), "attempt %d: delay less than %s", attempts, low)
+			require.False(t, nextAttemptAt.After(after.Add(high)), "attempt %d: delay greater than %s", attempts, high)
+		}
+	}
+	requireDelayWithin := func(t *testing.T, strategy BackoffStrategy, attempts int32, low, high time.Duration) {
+		t.Helper()
+		task := entity.NewTask("test", "{}")
+		task.Attempts = attempts
+
+		requireTaskDelayWithin(t, strategy, task, low, high)
+	}
+
+	t.Run("next attempt at func adapter", func(t *testing.T) {
+		t.Parallel()
+
+		var gotAttempt int32
+		strategy := NextAttemptAtFunc(func(currentAttempt int32) time.Time {
+			gotAttempt = currentAttempt
+			return xtime.Now().Add(base)
+		})
+
+		requireDelayWithin(t, strategy, 2, base, base)
+		require.EqualValues(t, 2, gotAttempt)
+	})
+
+	t.Run("exponential jitter", func(t *testing.T) {
+		t.Parallel()
+
+		strategy := ExponentialJitterBackoff(base, maxDelay)
+		requireDelayWithin(t, strategy, 1, 0, base)
+		requireDelayWithin(t, strategy, 3, 0, 4*base)
+		requireDelayWithin(t, strategy, 10, 0, maxDelay)
+		requireDelayWithin(t, strategy, 1_000, 0, maxDelay)
+	})
+
+	t.Run("decorrelated jitter", func(t *testing.T) {
+		t.Parallel()
+
+		strategy := DecorrelatedJitterBackoff(base, maxDelay)
+		requireDelayWithin(t, strategy, 1, base, 3*base)
+		requireDelayWithin(t, strategy, 5, base, 3*base)
+
+		task := entity.NewTask("test", "{}")
+		task.Attempts = 3
+		task.RetryDelay = lo.ToPtr(10 * base)
+		requireTaskDelayWithin(t, strategy, task, base, 30*base)
+
+		task.RetryDelay = lo.ToPtr(maxDelay)
+		requireTaskDelayWithin(t, strategy, task, base, maxDelay)
+
+		// the first retry after a reset of the attempts starts from base again
+		task.Attempts = 1
+		requireTaskDelayWithin(t, strategy, task, base, 3*base)
+	})
+
+	t.Run("exponential range jitter", func(t *testing.T) {
+		t.Parallel()
+
+		strategy := ExponentialRangeJitterBackoff(base, maxDelay)
+		requireDelayWithin(t, strategy, 1, base, 3*base)
+		requireDelayWithin(t, strategy, 2, base, 9*base)
+		requireDelayWithin(t, strategy, 10, base, maxDelay)
+		requireDelayWithin(t, strategy, 1_000, base, maxDelay)
+	})
+
+	t.Run("max delay less than base", func(t *testing.T) {
+		t.Parallel()
+
+		requireDelayWithin(t, ExponentialRangeJitterBackoff(base, 0), 5, base, base)
+	})
+
+	t.Run("jitter spreads retries", func(t *testing.T) {
+		t.Parallel()
+
+		strategy := ExponentialJitterBackoff(base, maxDelay)
+		task := entity.NewTask("test", "{}")
+		task.Attempts = 5
+
+		delays := make(map[time.Time]struct{})
+		for range 10 {
+			delays[strategy.NextAttemptAt(task, taskErr)] = struct{}{}
+		}
+		require.Greater(t, len(delays), 1)
+	})
+
+	t.Run("nil strategy keeps the default", func(t *testing.T) {
+		t.Parallel()
+
+		for _, opt := range []GoqueProcessorOpts{
+			WithTaskProcessingBackoff(nil),
+			WithTaskProcessingNextAttemptAtFunc(nil),
+		} {
+			p := NewGoqueProcessor(nil, "test", NoopTaskProcessor(), opt)
+			requireDelayWithin(t, p.processor.backoff, 1, defaultProcessorStaticNextAttemptPeriod, defaultProcessorStaticNextAttemptPeriod)
+		}
+	})
+}
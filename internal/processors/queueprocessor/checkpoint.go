@@ -0,0 +1,114 @@
+package queueprocessor
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/goccy/go-json"
+	"github.com/samber/lo"
+
+	"github.com/ruko1202/goque/internal/entity"
+	"github.com/ruko1202/goque/internal/storages"
+)
+
+type taskCheckpointerCtxKey struct{}
+
+// taskCheckpointer saves the checkpoints of the task being processed.
+type taskCheckpointer struct {
+	taskStorage storages.Task
+
+	mu   sync.Mutex
+	task *entity.Task
+}
+
+func withTaskCheckpointer(ctx context.Context, taskStorage storages.Task, task *entity.Task) context.Context {
+	return context.WithValue(ctx, taskCheckpointerCtxKey{}, &taskCheckpointer{
+		taskStorage: taskStorage,
+		task:        task,
+	})
+}
+
+func taskCheckpointerFromContext(ctx context.Context) (*taskCheckpointer, error) {
+	checkpointer, ok := ctx.Value(taskCheckpointerCtxKey{}).(*taskCheckpointer)
+	if !ok {
+		return nil, entity.ErrNoTaskInContext
+	}
+	return checkpointer, nil
+}
+
+// save writes the checkpoint and the progress, a nil checkpoint keeps the current one.
+// The task is left as is on failure.
+func (c *taskCheckpointer) save(ctx context.Context, checkpoint *string, progress int32) error {
+	if progress < 0 || progress > entity.MaxTaskProgress {
+		return fmt.Errorf("progress must be within [0, %d], got %d", entity.MaxTaskProgress, progress)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	prevCheckpoint, prevProgress := c.task.Checkpoint, c.task.Progress
+	c.task.Checkpoint, c.task.Progress = lo.CoalesceOrEmpty(checkpoint, prevCheckpoint), progress
+	if err := c.taskStorage.SaveTaskCheckpoint(ctx, c.task); err != nil {
+		c.task.Checkpoint, c.task.Progress = prevCheckpoint, prevProgress
+		return err
+	}
+
+	return nil
+}
+
+// SaveCheckpoint saves the state of the processing to resume from on the next attempt along with
+// the progress percentage of the task, from 0 to 100. The checkpoint is marshaled to JSON and
+// written to the task at once, so it survives a crash of the processor; see LoadCheckpoint.
+//
+// Must be called with the context passed to ProcessTask and before it returns, otherwise
+// entity.ErrNoTaskInContext is returned. A task changed concurrently, e.g. canceled, is not written
+// and entity.ErrTaskStateConflict is returned.
+func SaveCheckpoint(ctx context.Context, checkpoint any, progress int32) error {
+	checkpointer, err := taskCheckpointerFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		return fmt.Errorf("%w: %s task checkpoint: %w", entity.ErrCheckpointMarshal, checkpointer.task.Type, err)
+	}
+
+	return checkpointer.save(ctx, lo.ToPtr(string(data)), progress)
+}
+
+// SaveProgress saves the progress percentage of the task, from 0 to 100, keeping its checkpoint.
+// See SaveCheckpoint.
+func SaveProgress(ctx context.Context, progress int32) error {
+	checkpointer, err := taskCheckpointerFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	return checkpointer.save(ctx, nil, progress)
+}
+
+// LoadCheckpoint decodes the checkpoint saved by SaveCheckpoint during this or a previous attempt
+// of the task being processed. Reports false if the task has no checkpoint.
+func LoadCheckpoint[T any](ctx context.Context) (T, bool, error) {
+	var checkpoint T
+	checkpointer, err := taskCheckpointerFromContext(ctx)
+	if err != nil {
+		return checkpoint, false, err
+	}
+
+	checkpointer.mu.Lock()
+	task := checkpointer.task
+	data := task.Checkpoint
+	checkpointer.mu.Unlock()
+	if data == nil {
+		return checkpoint, false, nil
+	}
+
+	if err := json.Unmarshal([]byte(*data), &checkpoint); err != nil {
+		return checkpoint, false, fmt.Errorf("%w: %s task checkpoint: %w", entity.ErrCheckpointUnmarshal, task.Type, err)
+	}
+
+	return checkpoint, true, nil
+}
@@ -0,0 +1,143 @@
+package queueprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ruko1202/xlog"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/ruko1202/goque/internal/entity"
+)
+
+type checkpointState struct {
+	Offset int `json:"offset"`
+}
+
+func TestCheckpoint(t *testing.T) {
+	t.Parallel()
+	ctx := xlog.ContextWithLogger(context.Background(), xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+	t.Run("save and load", func(t *testing.T) {
+		t.Parallel()
+
+		task := entity.NewTask("type[checkpoint]", entity.NoTaskPayload)
+		task.Checkpoint = lo.ToPtr(`{"offset":10}`)
+		goqueProc, mocks := initGoqueProcessorWithMocks(t, task.Type,
+			TaskProcessorFunc(func(ctx context.Context, _ *entity.Task) error {
+				checkpoint, ok, err := LoadCheckpoint[checkpointState](ctx)
+				require.NoError(t, err)
+				require.True(t, ok)
+				require.Equal(t, checkpointState{Offset: 10}, checkpoint)
+
+				if err := SaveCheckpoint(ctx, checkpointState{Offset: 20}, 40); err != nil {
+					return err
+				}
+				if err := SaveProgress(ctx, 50); err != nil {
+					return err
+				}
+
+				checkpoint, ok, err = LoadCheckpoint[checkpointState](ctx)
+				require.NoError(t, err)
+				require.True(t, ok)
+				require.Equal(t, checkpointState{Offset: 20}, checkpoint)
+				return nil
+			}),
+		)
+		gomock.InOrder(
+			mocks.taskStorage.EXPECT().
+				SaveTaskCheckpoint(gomock.Any(), task).
+				DoAndReturn(func(_ context.Context, task *entity.Task) error {
+					require.JSONEq(t, `{"offset":20}`, lo.FromPtr(task.Checkpoint))
+					require.EqualValues(t, 40, task.Progress)
+					return nil
+				}),
+			mocks.taskStorage.EXPECT().
+				SaveTaskCheckpoint(gomock.Any(), task).
+				DoAndReturn(func(_ context.Context, task *entity.Task) error {
+					require.JSONEq(t, `{"offset":20}`, lo.FromPtr(task.Checkpoint))
+					require.EqualValues(t, 50, task.Progress)
+					return nil
+				}),
+		)
+
+		err := goqueProc.processTask(ctx, task)
+		require.NoError(t, err)
+	})
+
+	t.Run("save error keeps task", func(t *testing.T) {
+		t.Parallel()
+
+		task := entity.NewTask("type[checkpoint conflict]", entity.NoTaskPayload)
+		goqueProc, mocks := initGoqueProcessorWithMocks(t, task.Type,
+			TaskProcessorFunc(func(ctx context.Context, _ *entity.Task) error {
+				return SaveCheckpoint(ctx, checkpointState{Offset: 1}, 10)
+			}),
+		)
+		mocks.taskStorage.EXPECT().
+			SaveTaskCheckpoint(gomock.Any(), task).
+			Return(entity.ErrTaskStateConflict)
+
+		err := goqueProc.processTask(ctx, task)
+		require.ErrorIs(t, err, entity.ErrTaskStateConflict)
+		require.Nil(t, task.Checkpoint)
+		require.Zero(t, task.Progress)
+	})
+
+	t.Run("invalid progress", func(t *testing.T) {
+		t.Parallel()
+
+		task := entity.NewTask("type[checkpoint invalid progress]", entity.NoTaskPayload)
+		goqueProc, _ := initGoqueProcessorWithMocks(t, task.Type,
+			TaskProcessorFunc(func(ctx context.Context, _ *entity.Task) error {
+				return SaveProgress(ctx, entity.MaxTaskProgress+1)
+			}),
+		)
+
+		err := goqueProc.processTask(ctx, task)
+		require.ErrorContains(t, err, "progress must be within")
+	})
+
+	t.Run("unmarshalable checkpoint", func(t *testing.T) {
+		t.Parallel()
+
+		task := entity.NewTask("type[checkpoint marshal]", entity.NoTaskPayload)
+		goqueProc, _ := initGoqueProcessorWithMocks(t, task.Type,
+			TaskProcessorFunc(func(ctx context.Context, _ *entity.Task) error {
+				return SaveCheckpoint(ctx, make(chan int), 10)
+			}),
+		)
+
+		err := goqueProc.processTask(ctx, task)
+		require.ErrorIs(t, err, entity.ErrCheckpointMarshal)
+		require.Nil(t, task.Checkpoint)
+	})
+
+	t.Run("no checkpoint", func(t *testing.T) {
+		t.Parallel()
+
+		task := entity.NewTask("type[checkpoint empty]", entity.NoTaskPayload)
+		goqueProc, _ := initGoqueProcessorWithMocks(t, task.Type,
+			TaskProcessorFunc(func(ctx context.Context, _ *entity.Task) error {
+				_, ok, err := LoadCheckpoint[checkpointState](ctx)
+				require.NoError(t, err)
+				require.False(t, ok)
+				return nil
+			}),
+		)
+
+		err := goqueProc.processTask(ctx, task)
+		require.NoError(t, err)
+	})
+
+	t.Run("outside processing", func(t *testing.T) {
+		t.Parallel()
+
+		require.ErrorIs(t, SaveProgress(ctx, 10), entity.ErrNoTaskInContext)
+		_, _, err := LoadCheckpoint[checkpointState](ctx)
+		require.ErrorIs(t, err, entity.ErrNoTaskInContext)
+	})
+}
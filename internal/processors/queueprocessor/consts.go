@@ -8,9 +8,17 @@ const (
 	defaultProcessorWorkers                 = 10
 	defaultProcessorTimeout                 = 30 * time.Second
 	defaultProcessorStaticNextAttemptPeriod = 10 * time.Minute
+	defaultProcessorCancelCheckPeriod       = 5 * time.Second
 
 	// Fetcher constants.
 	defaultFetchTick     = 30 * time.Second
 	defaultFetchTimeout  = 30 * time.Second
 	defaultFetchMaxTasks = int64(100)
+
+	// Lease constants.
+	defaultLeaseDuration     = time.Minute
+	leaseRenewalsPerDuration = 3
+
+	// Listener constants.
+	defaultListenReconnectDelay = 5 * time.Second
 )
@@ -18,6 +18,8 @@ type (
 	HookBeforeProcessing func(ctx context.Context, task *entity.Task)
 	// HookAfterProcessing defines a hook function called after task processing completes.
 	HookAfterProcessing func(ctx context.Context, task *entity.Task, err error)
+	// DeadLetterHandler defines a callback called once a task runs out of attempts, err is the last processing error.
+	DeadLetterHandler func(ctx context.Context, task *entity.Task, err error)
 )
 
 // LoggingBeforeProcessing default log before processing the task.
@@ -11,15 +11,13 @@ import (
 	"github.com/ruko1202/goque/internal/metrics"
 
 	"github.com/ruko1202/goque/internal/entity"
+	"github.com/ruko1202/goque/internal/utils/xtime"
 )
 
 func (p *GoqueProcessor) updateTaskStateBeforeProcessing(ctx context.Context, task *entity.Task) {
 	task.Status = entity.TaskStatusProcessing
 
-	err := p.taskStorage.UpdateTask(ctx, task.ID, task)
-	if err != nil {
-		xlog.Error(ctx, "failed to update task state", xfield.Error(err))
-	}
+	p.saveTaskState(ctx, task)
 }
 
 func (p *GoqueProcessor) updateTaskState(ctx context.Context, task *entity.Task, taskErr error) {
@@ -27,6 +25,13 @@ func (p *GoqueProcessor) updateTaskState(ctx context.Context, task *entity.Task,
 	ctx = context.WithoutCancel(ctx)
 
 	switch {
+	case errors.Is(taskErr, entity.ErrTaskCancelRequested):
+		// the canceled status is already persisted by the user request
+		task.Status = entity.TaskStatusCanceled
+		return
+	case errors.Is(taskErr, entity.ErrTaskStateConflict):
+		// the stored state of the task wins
+		return
 	case errors.Is(taskErr, entity.ErrTaskCancel):
 		if errors.Is(taskErr, entity.ErrPayloadUnmarshal) {
 			task.AddError(taskErr)
@@ -36,21 +41,96 @@ func (p *GoqueProcessor) updateTaskState(ctx context.Context, task *entity.Task,
 		p.returnTaskWhenGracefulShutdown(ctx, task)
 		return
 	case taskErr != nil:
-		task.Attempts = lo.Ternary(task.Attempts == 0, 1, task.Attempts+1)
+		p.applyTaskError(task, taskErr)
+	default:
+		task.Status = entity.TaskStatusDone
+		task.Checkpoint = nil
+		task.Progress = entity.MaxTaskProgress
+	}
+	if task.Status == entity.TaskStatusAttemptsLeft {
+		p.handleDeadLetter(ctx, task, taskErr)
+		return
+	}
+	p.saveTaskState(ctx, task)
+}
+
+// applyTaskError moves a failed task to its next state according to the kind of the error.
+func (p *GoqueProcessor) applyTaskError(task *entity.Task, taskErr error) {
+	var snoozeErr *entity.SnoozeError
+	if errors.As(taskErr, &snoozeErr) {
+		// snoozing is not a failure, so the attempt is not counted
 		task.AddError(taskErr)
+		task.Status = entity.TaskStatusNew
+		task.NextAttemptAt = xtime.Now().Add(snoozeErr.Delay)
+		return
+	}
 
-		if task.Attempts >= p.processor.maxAttempts {
-			task.Status = entity.TaskStatusAttemptsLeft
-		} else {
-			task.Status = entity.TaskStatusError
-			task.NextAttemptAt = p.processor.nextAttemptAtFunc(task.Attempts)
-		}
+	task.Attempts = lo.Ternary(task.Attempts == 0, 1, task.Attempts+1)
+	task.AddError(taskErr)
+
+	var (
+		permanentErr  *entity.PermanentError
+		retryAfterErr *entity.RetryAfterError
+	)
+	switch {
+	case errors.As(taskErr, &permanentErr), task.Attempts >= p.taskMaxAttempts(task):
+		task.Status = entity.TaskStatusAttemptsLeft
+	case errors.As(taskErr, &retryAfterErr):
+		task.Status = entity.TaskStatusError
+		task.NextAttemptAt = xtime.Now().Add(retryAfterErr.Delay)
 	default:
-		task.Status = entity.TaskStatusDone
+		task.Status = entity.TaskStatusError
+		task.NextAttemptAt = p.processor.backoff.NextAttemptAt(task, taskErr)
+	}
+
+	if task.Status == entity.TaskStatusError {
+		// kept for the backoff strategies growing the delay from the previous one
+		task.RetryDelay = lo.ToPtr(max(task.NextAttemptAt.Sub(xtime.Now()), 0))
 	}
-	err := p.taskStorage.UpdateTask(ctx, task.ID, task)
-	if err != nil {
+}
+
+// saveTaskState persists the task state unless the task was changed concurrently in the meantime.
+func (p *GoqueProcessor) saveTaskState(ctx context.Context, task *entity.Task) {
+	p.checkTaskStateSaved(ctx, task, p.taskStorage.UpdateTask(ctx, task.ID, task))
+}
+
+// checkTaskStateSaved reports whether the task state was saved. If the task was changed concurrently,
+// e.g. canceled by a user or reclaimed by the healer, the stored state wins: a canceled task keeps
+// the canceled status, otherwise the task is not held by the processor anymore.
+func (p *GoqueProcessor) checkTaskStateSaved(ctx context.Context, task *entity.Task, err error) bool {
+	switch {
+	case errors.Is(err, entity.ErrTaskCancelRequested):
+		xlog.Info(ctx, "task is canceled by user request, keep the canceled status")
+		task.Status = entity.TaskStatusCanceled
+		return false
+	case errors.Is(err, entity.ErrTaskStateConflict):
+		xlog.Warn(ctx, "task is changed concurrently, drop its state", xfield.String("status", task.Status))
+		p.inFlight.release(task.ID)
+		return false
+	case err != nil:
 		xlog.Error(ctx, "failed to update task state", xfield.Error(err))
+		return false
+	}
+
+	return true
+}
+
+func (p *GoqueProcessor) handleDeadLetter(ctx context.Context, task *entity.Task, taskErr error) {
+	ctx, span := xlog.WithOperationSpan(ctx, "queue_processor.handleDeadLetter")
+	defer span.End()
+
+	var err error
+	if p.processor.deadLetterQueue {
+		err = p.taskStorage.MoveTaskToDead(ctx, task)
+	} else {
+		err = p.taskStorage.UpdateTask(ctx, task.ID, task)
+	}
+	if !p.checkTaskStateSaved(ctx, task, err) {
+		return
+	}
+
+	if p.processor.deadLetterHandler != nil {
+		p.processor.deadLetterHandler(ctx, task, taskErr)
 	}
 }
 
@@ -62,10 +142,7 @@ func (p *GoqueProcessor) returnTaskWhenGracefulShutdown(ctx context.Context, tas
 	xlog.Info(ctx, "graceful shutdown: return task to queue")
 	task.Status = entity.TaskStatusNew
 
-	err := p.taskStorage.UpdateTask(ctx, task.ID, task)
-	if err != nil {
-		xlog.Error(ctx, "failed to update task state", xfield.Error(err))
-	}
+	p.saveTaskState(ctx, task)
 }
 
 // metricsBeforeProcessing is a placeholder hook for future extensions.
@@ -0,0 +1,114 @@
+package queueprocessor
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+)
+
+// inFlightTasks keeps the tasks held by the processor from the fetch until their state is saved,
+// so their leases can be renewed and a cancellation requested by a user can reach the worker.
+type inFlightTasks struct {
+	mu sync.Mutex
+	// cancels holds the cancel function of the processing context, nil while the task waits for a worker.
+	cancels map[uuid.UUID]context.CancelCauseFunc
+	// released is signaled once the last task is released.
+	released *sync.Cond
+}
+
+func newInFlightTasks() *inFlightTasks {
+	t := &inFlightTasks{
+		cancels: make(map[uuid.UUID]context.CancelCauseFunc),
+	}
+	t.released = sync.NewCond(&t.mu)
+	return t
+}
+
+func (t *inFlightTasks) hold(taskIDs ...uuid.UUID) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, taskID := range taskIDs {
+		t.cancels[taskID] = nil
+	}
+}
+
+func (t *inFlightTasks) release(taskIDs ...uuid.UUID) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, taskID := range taskIDs {
+		delete(t.cancels, taskID)
+	}
+	if len(t.cancels) == 0 {
+		t.released.Broadcast()
+	}
+}
+
+// count returns the number of held tasks.
+func (t *inFlightTasks) count() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return len(t.cancels)
+}
+
+// wait blocks until all the held tasks are released.
+func (t *inFlightTasks) wait() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for len(t.cancels) > 0 {
+		t.released.Wait()
+	}
+}
+
+// holds reports whether the task is still held by the processor.
+func (t *inFlightTasks) holds(taskID uuid.UUID) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	_, ok := t.cancels[taskID]
+	return ok
+}
+
+// track derives the processing context of a held task. The returned func must be called
+// once the processing is over.
+func (t *inFlightTasks) track(ctx context.Context, taskID uuid.UUID) (context.Context, func()) {
+	ctx, cancel := context.WithCancelCause(ctx)
+
+	t.mu.Lock()
+	t.cancels[taskID] = cancel
+	t.mu.Unlock()
+
+	return ctx, func() {
+		t.mu.Lock()
+		if _, ok := t.cancels[taskID]; ok {
+			t.cancels[taskID] = nil
+		}
+		t.mu.Unlock()
+
+		cancel(nil)
+	}
+}
+
+func (t *inFlightTasks) ids() []uuid.UUID {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return lo.Keys(t.cancels)
+}
+
+// cancel cancels the processing context of the task, if it is being processed.
+func (t *inFlightTasks) cancel(taskID uuid.UUID, cause error) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	cancel := t.cancels[taskID]
+	if cancel != nil {
+		cancel(cause)
+	}
+	return cancel != nil
+}
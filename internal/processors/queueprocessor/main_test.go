@@ -37,12 +37,16 @@ func initGoqueProcessorWithMocks(
 }
 
 func defaultFetcherMock(mocks *procMocks, taskType string, tasks []*entity.Task) {
+	mocks.taskStorage.EXPECT().
+		IsTaskTypePaused(gomock.Any(), taskType).
+		Return(false, nil).
+		AnyTimes()
 	gomock.InOrder(
 		mocks.taskStorage.EXPECT().
-			GetTasksForProcessing(gomock.Any(), taskType, defaultFetchMaxTasks).
+			GetTasksForProcessing(gomock.Any(), taskType, defaultFetchMaxTasks, gomock.Any(), int64(0)).
 			Return(tasks, nil),
 		mocks.taskStorage.EXPECT().
-			GetTasksForProcessing(gomock.Any(), taskType, defaultFetchMaxTasks).
+			GetTasksForProcessing(gomock.Any(), taskType, defaultFetchMaxTasks, gomock.Any(), int64(0)).
 			Return([]*entity.Task{}, nil).
 			AnyTimes(),
 	)
@@ -0,0 +1,99 @@
+package queueprocessor
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+
+	"github.com/ruko1202/xlog"
+	"github.com/ruko1202/xlog/xfield"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+
+	"github.com/ruko1202/goque/internal/entity"
+	"github.com/ruko1202/goque/internal/utils/xtime"
+)
+
+// Middleware wraps a task processor, e.g. to change the processing context, short-circuit
+// the processing or handle its result. It runs within the task timeout and cancellation.
+type Middleware func(next TaskProcessor) TaskProcessor
+
+// chainMiddlewares wraps the processor with the middlewares, the first one being the outermost.
+func chainMiddlewares(processor TaskProcessor, middlewares []Middleware) TaskProcessor {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		processor = middlewares[i](processor)
+	}
+	return processor
+}
+
+// SetGlobalMiddlewares sets the middlewares running outside of the ones set by WithMiddlewares,
+// replacing the ones set before, and rewraps the task processor. Should be called before Run.
+func (p *GoqueProcessor) SetGlobalMiddlewares(middlewares ...Middleware) {
+	p.processor.globalMiddlewares = middlewares
+	p.processor.handler = chainMiddlewares(
+		p.processor.taskProcessor,
+		append(append([]Middleware{}, p.processor.globalMiddlewares...), p.processor.middlewares...),
+	)
+}
+
+// RecoveryMiddleware turns a panic of the processing into a task error wrapping entity.ErrTaskPanic,
+// so the task is retried as usual instead of crashing the worker.
+func RecoveryMiddleware(next TaskProcessor) TaskProcessor {
+	return TaskProcessorFunc(func(ctx context.Context, task *entity.Task) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				xlog.Error(ctx, "task processing panic",
+					xfield.Any("panic", r),
+					xfield.Binary("stack", debug.Stack()),
+				)
+				err = fmt.Errorf("%w: %v", entity.ErrTaskPanic, r)
+			}
+		}()
+
+		return next.ProcessTask(ctx, task)
+	})
+}
+
+// LoggingMiddleware logs the start and the end of the processing with its duration.
+func LoggingMiddleware(next TaskProcessor) TaskProcessor {
+	return TaskProcessorFunc(func(ctx context.Context, task *entity.Task) error {
+		ctx = xlog.WithFields(ctx,
+			xfield.String("taskID", task.ID.String()),
+			xfield.String("task_type", task.Type),
+			xfield.Any("attempt", task.Attempts+1),
+		)
+		xlog.Info(ctx, "task processing started")
+
+		start := xtime.Now()
+		err := next.ProcessTask(ctx, task)
+		duration := xtime.Now().Sub(start)
+		if err != nil {
+			xlog.Error(ctx, "task processing failed", xfield.Duration("duration", duration), xfield.Error(err))
+			return err
+		}
+
+		xlog.Info(ctx, "task processing finished", xfield.Duration("duration", duration))
+		return nil
+	})
+}
+
+// TracingMiddleware runs the processing in its own span with the task attributes, recording the error.
+func TracingMiddleware(next TaskProcessor) TaskProcessor {
+	return TaskProcessorFunc(func(ctx context.Context, task *entity.Task) error {
+		ctx, span := xlog.WithOperationSpan(ctx, "task_processor.ProcessTask")
+		defer span.End()
+		span.SetAttributes(
+			attribute.String("goque.task.id", task.ID.String()),
+			attribute.String("goque.task.type", task.Type),
+			attribute.Int("goque.task.attempt", int(task.Attempts)+1),
+		)
+
+		err := next.ProcessTask(ctx, task)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+
+		return err
+	})
+}
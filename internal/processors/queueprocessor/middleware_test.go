@@ -0,0 +1,117 @@
+package queueprocessor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ruko1202/xlog"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/ruko1202/goque/internal/entity"
+)
+
+type middlewareCtxKey struct{}
+
+func TestMiddleware(t *testing.T) {
+	t.Parallel()
+	ctx := xlog.ContextWithLogger(context.Background(), xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+	recordingMiddleware := func(name string, calls *[]string) Middleware {
+		return func(next TaskProcessor) TaskProcessor {
+			return TaskProcessorFunc(func(ctx context.Context, task *entity.Task) error {
+				*calls = append(*calls, name+" before")
+				err := next.ProcessTask(ctx, task)
+				*calls = append(*calls, name+" after")
+				return err
+			})
+		}
+	}
+
+	t.Run("chain order", func(t *testing.T) {
+		t.Parallel()
+
+		var calls []string
+		goqueProc, _ := initGoqueProcessorWithMocks(t, "type[middleware order]",
+			TaskProcessorFunc(func(_ context.Context, _ *entity.Task) error {
+				calls = append(calls, "process")
+				return nil
+			}),
+			WithMiddlewares(recordingMiddleware("processor 1", &calls), recordingMiddleware("processor 2", &calls)),
+		)
+		goqueProc.SetGlobalMiddlewares(recordingMiddleware("outdated", &calls))
+		goqueProc.SetGlobalMiddlewares(recordingMiddleware("global", &calls))
+
+		err := goqueProc.processTask(ctx, entity.NewTask("type[middleware order]", "{}"))
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			"global before",
+			"processor 1 before",
+			"processor 2 before",
+			"process",
+			"processor 2 after",
+			"processor 1 after",
+			"global after",
+		}, calls)
+	})
+
+	t.Run("change context", func(t *testing.T) {
+		t.Parallel()
+
+		goqueProc, _ := initGoqueProcessorWithMocks(t, "type[middleware context]",
+			TaskProcessorFunc(func(ctx context.Context, _ *entity.Task) error {
+				require.Equal(t, "tenant", ctx.Value(middlewareCtxKey{}))
+				<-ctx.Done()
+				return ctx.Err()
+			}),
+			WithMiddlewares(func(next TaskProcessor) TaskProcessor {
+				return TaskProcessorFunc(func(ctx context.Context, task *entity.Task) error {
+					ctx = context.WithValue(ctx, middlewareCtxKey{}, "tenant")
+					ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+					defer cancel()
+					return next.ProcessTask(ctx, task)
+				})
+			}),
+		)
+
+		err := goqueProc.processTask(ctx, entity.NewTask("type[middleware context]", "{}"))
+		require.ErrorIs(t, err, entity.ErrTaskTimeout)
+	})
+
+	t.Run("short circuit", func(t *testing.T) {
+		t.Parallel()
+
+		errLocked := errors.New("locked")
+		goqueProc, _ := initGoqueProcessorWithMocks(t, "type[middleware short circuit]",
+			TaskProcessorFunc(func(_ context.Context, _ *entity.Task) error {
+				require.Fail(t, "processor must not be called")
+				return nil
+			}),
+			WithMiddlewares(func(_ TaskProcessor) TaskProcessor {
+				return TaskProcessorFunc(func(_ context.Context, _ *entity.Task) error {
+					return errLocked
+				})
+			}),
+		)
+
+		err := goqueProc.processTask(ctx, entity.NewTask("type[middleware short circuit]", "{}"))
+		require.ErrorIs(t, err, errLocked)
+	})
+
+	t.Run("built-in", func(t *testing.T) {
+		t.Parallel()
+
+		goqueProc, _ := initGoqueProcessorWithMocks(t, "type[middleware built-in]",
+			TaskProcessorFunc(func(_ context.Context, _ *entity.Task) error {
+				panic("boom")
+			}),
+			WithMiddlewares(TracingMiddleware, LoggingMiddleware, RecoveryMiddleware),
+		)
+
+		err := goqueProc.processTask(ctx, entity.NewTask("type[middleware built-in]", "{}"))
+		require.ErrorIs(t, err, entity.ErrTaskPanic)
+		require.ErrorContains(t, err, "boom")
+	})
+}
@@ -0,0 +1,32 @@
+package queueprocessor
+
+import (
+	"context"
+
+	"github.com/ruko1202/xlog"
+	"github.com/ruko1202/xlog/xfield"
+
+	"github.com/ruko1202/goque/internal/metrics"
+)
+
+// isPaused reports whether the task type is paused across the cluster, so the fetch must be skipped.
+// The tasks already fetched are processed as usual. Storage errors skip the fetch too,
+// so a paused type is never fetched because its state could not be read.
+func (p *GoqueProcessor) isPaused(ctx context.Context) bool {
+	paused, err := p.taskStorage.IsTaskTypePaused(ctx, p.fetcher.taskType)
+	if err != nil {
+		xlog.Error(ctx, "failed to check if task type is paused", xfield.Error(err))
+		return true
+	}
+
+	metrics.SetTaskTypePaused(p.fetcher.taskType, paused)
+	if p.fetcher.paused.Swap(paused) != paused {
+		if paused {
+			xlog.Info(ctx, "task type paused, fetching stopped")
+		} else {
+			xlog.Info(ctx, "task type resumed, fetching started")
+		}
+	}
+
+	return paused
+}
@@ -6,8 +6,11 @@ import (
 	"errors"
 	"fmt"
 	"runtime/debug"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/panjf2000/ants/v2"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/ruko1202/xlog"
@@ -29,6 +32,16 @@ type (
 		maxTasks int64
 		tick     time.Duration
 		timeout  time.Duration
+		// listenNotify enables immediate fetching on new task notifications.
+		listenNotify bool
+		// lease is the ownership taken on the fetched tasks, renewed while they are held.
+		lease entity.TaskLease
+		// rateLimit limits the number of fetched tasks across all processors of the task type, nil if unlimited.
+		rateLimit *entity.RateLimit
+		// concurrencyLimitPerKey limits the tasks in flight per concurrency key across all processors, 0 if unlimited.
+		concurrencyLimitPerKey int64
+		// paused is the pause state of the task type seen by the last fetch.
+		paused atomic.Bool
 	}
 	taskProcessor struct {
 		taskProcessor         TaskProcessor
@@ -36,10 +49,21 @@ type (
 		workerPanicHandler    func(context.Context) func(any)
 		timeout               time.Duration
 		maxAttempts           int32
-		nextAttemptAtFunc     NextAttemptAtFunc
+		backoff               BackoffStrategy
 		hooksBeforeProcessing []HookBeforeProcessing
 		hooksAfterProcessing  []HookAfterProcessing
 		verboseLogging        bool
+		// deadLetterQueue moves tasks out of attempts to the dead letters table.
+		deadLetterQueue   bool
+		deadLetterHandler DeadLetterHandler
+		// cancelCheckPeriod is the interval of checking in-flight tasks for cancel requests.
+		cancelCheckPeriod time.Duration
+		// autoscale tunes the number of workers within the range by the load, nil if the number is fixed.
+		autoscale *workersAutoscale
+		// globalMiddlewares and then middlewares wrap taskProcessor into handler, the first one being the outermost.
+		globalMiddlewares []Middleware
+		middlewares       []Middleware
+		handler           TaskProcessor
 	}
 )
 
@@ -50,6 +74,10 @@ type GoqueProcessor struct {
 	gracefulCtxCancel context.CancelFunc
 
 	taskStorage storages.Task
+	inFlight    *inFlightTasks
+	workers     *workersPool
+	// shared is the membership in a shared worker pool, nil if the processor runs its own pool.
+	shared *sharedPoolMember
 
 	fetcher      *taskFetcher
 	processor    *taskProcessor
@@ -67,6 +95,7 @@ func NewGoqueProcessor(
 	p := &GoqueProcessor{
 		gracefulStoppedCh: make(chan struct{}),
 		taskStorage:       taskStorage,
+		inFlight:          newInFlightTasks(),
 		queueCleaner:      internalprocessors.NewQueueCleaner(taskStorage, taskType),
 		queueHealer:       internalprocessors.NewQueueHealer(taskStorage, taskType),
 	}
@@ -76,6 +105,10 @@ func NewGoqueProcessor(
 		maxTasks: defaultFetchMaxTasks,
 		tick:     defaultFetchTick,
 		timeout:  defaultFetchTimeout,
+		lease: entity.TaskLease{
+			Owner:    defaultLeaseOwner(),
+			Duration: defaultLeaseDuration,
+		},
 	}
 	p.processor = &taskProcessor{
 		taskProcessor:      processor,
@@ -83,7 +116,7 @@ func NewGoqueProcessor(
 		workerPanicHandler: p.workersPanicHandler,
 		timeout:            defaultProcessorTimeout,
 		maxAttempts:        defaultProcessorMaxAttempts,
-		nextAttemptAtFunc:  StaticNextAttemptAtFunc(defaultProcessorStaticNextAttemptPeriod),
+		backoff:            StaticNextAttemptAtFunc(defaultProcessorStaticNextAttemptPeriod),
 		hooksBeforeProcessing: []HookBeforeProcessing{
 			p.updateTaskStateBeforeProcessing,
 			p.metricsBeforeProcessing,
@@ -92,7 +125,8 @@ func NewGoqueProcessor(
 			p.updateTaskState,
 			p.metricsAfterProcessing,
 		},
-		verboseLogging: true,
+		verboseLogging:    true,
+		cancelCheckPeriod: defaultProcessorCancelCheckPeriod,
 	}
 
 	for _, opt := range opts {
@@ -103,6 +137,8 @@ func NewGoqueProcessor(
 		p.processor.hooksBeforeProcessing = append(p.processor.hooksBeforeProcessing, LoggingBeforeProcessing)
 		p.processor.hooksAfterProcessing = append(p.processor.hooksAfterProcessing, LoggingAfterProcessing)
 	}
+	p.processor.handler = chainMiddlewares(p.processor.taskProcessor, p.processor.middlewares)
+	p.workers = newWorkersPool(taskType, p.processor.workers, p.fetcher.tick, p.processor.autoscale)
 
 	return p
 }
@@ -124,12 +160,20 @@ func (p *GoqueProcessor) Run(ctx context.Context) error {
 
 	ctx, p.gracefulCtxCancel = context.WithCancel(ctx)
 
-	metrics.SetTasksWorkersTotal(p.fetcher.taskType, p.processor.workers)
+	if p.shared != nil {
+		err := p.shared.pool.attach(ctx, p.shared)
+		if err != nil {
+			xlog.Error(ctx, "failed to join shared worker pool", xfield.Error(err))
+			return err
+		}
+
+		go p.runWithSharedWorkerPool(ctx)
+		return nil
+	}
 
-	workerPool, err := ants.NewPool(p.processor.workers,
+	workerPool, err := p.workers.open(
 		ants.WithPanicHandler(p.processor.workerPanicHandler(ctx)),
 	)
-
 	if err != nil {
 		xlog.Error(ctx, "failed to create pool", xfield.Error(err))
 		return err
@@ -155,6 +199,13 @@ func (p *GoqueProcessor) runWithWorkerPool(ctx context.Context, workerPool *ants
 	defer close(p.gracefulStoppedCh)
 	defer workerPool.Release()
 
+	var backgroundWG sync.WaitGroup
+	defer backgroundWG.Wait()
+
+	wakeupCh := make(chan struct{}, 1)
+	stopLeaseRenewer := p.runBackgroundJobs(ctx, &backgroundWG, wakeupCh)
+	defer stopLeaseRenewer()
+
 	ticker := time.NewTicker(p.fetcher.tick)
 	defer ticker.Stop()
 
@@ -174,13 +225,64 @@ func (p *GoqueProcessor) runWithWorkerPool(ctx context.Context, workerPool *ants
 			if err != nil {
 				xlog.Error(ctx, "failed to fetch and process tasks", xfield.Error(err))
 			}
+		case <-wakeupCh:
+			err := p.fetchAndProcess(ctx, workerPool)
+			if err != nil {
+				xlog.Error(ctx, "failed to fetch and process notified tasks", xfield.Error(err))
+			}
 		}
 	}
 }
 
+// runBackgroundJobs starts the lease renewer, the task listener waking up the fetcher through wakeupCh
+// and the task canceler. The lease renewer keeps running after ctx is canceled until the returned func
+// is called, so the leases outlive the graceful shutdown.
+func (p *GoqueProcessor) runBackgroundJobs(ctx context.Context, wg *sync.WaitGroup, wakeupCh chan<- struct{}) context.CancelFunc {
+	leaseCtx, stopLeaseRenewer := context.WithCancel(context.WithoutCancel(ctx))
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		p.runLeaseRenewer(leaseCtx)
+	}()
+
+	if p.fetcher.listenNotify {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p.runTaskListener(ctx, wakeupCh)
+		}()
+	}
+	if p.processor.cancelCheckPeriod > 0 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p.runTaskCanceler(ctx, p.processor.cancelCheckPeriod)
+		}()
+	}
+
+	return stopLeaseRenewer
+}
+
 func (p *GoqueProcessor) fetchAndProcess(ctx context.Context, workerPool *ants.Pool) error {
-	for _, task := range p.fetchTasks(ctx) {
+	tasks := p.fetchTasks(ctx)
+	p.checkBacklog(ctx)
+	p.workers.autoscaleTo(ctx, len(tasks), int64(len(tasks)) >= p.fetcher.maxTasks)
+
+	return p.submitTasks(ctx, workerPool, tasks, nil)
+}
+
+// submitTasks holds the fetched tasks and submits them to the worker pool.
+// afterTask, if not nil, is called once a task is released.
+func (p *GoqueProcessor) submitTasks(ctx context.Context, workerPool *ants.Pool, tasks []*entity.Task, afterTask func()) error {
+	p.inFlight.hold(lo.Map(tasks, func(task *entity.Task, _ int) uuid.UUID { return task.ID })...)
+
+	for i, task := range tasks {
 		err := workerPool.Submit(func() {
+			if afterTask != nil {
+				defer afterTask()
+			}
+			defer p.inFlight.release(task.ID)
+
 			ctx := goquectx.WithValues(ctx, task.Metadata)
 			ctx, span := xlog.WithOperationSpan(ctx, "queue_processor.fetchAndProcess",
 				xfield.String("taskID", task.ID.String()),
@@ -194,6 +296,8 @@ func (p *GoqueProcessor) fetchAndProcess(ctx context.Context, workerPool *ants.P
 			default:
 			}
 
+			done := p.workers.track()
+			defer done()
 			p.doProcessTask(ctx, task)
 		})
 		if err != nil {
@@ -201,6 +305,8 @@ func (p *GoqueProcessor) fetchAndProcess(ctx context.Context, workerPool *ants.P
 				xfield.Error(err),
 				xfield.String("taskID", task.ID.String()),
 			)
+			// the rest of the tasks is left to the healer once their leases expire
+			p.inFlight.release(lo.Map(tasks[i:], func(task *entity.Task, _ int) uuid.UUID { return task.ID })...)
 			return err
 		}
 	}
@@ -208,6 +314,12 @@ func (p *GoqueProcessor) fetchAndProcess(ctx context.Context, workerPool *ants.P
 }
 
 func (p *GoqueProcessor) fetchTasks(ctx context.Context) []*entity.Task {
+	return p.fetchTasksUpTo(ctx, p.fetcher.maxTasks)
+}
+
+// fetchTasksUpTo fetches at most limit tasks, no more than the fetcher max tasks.
+func (p *GoqueProcessor) fetchTasksUpTo(ctx context.Context, limit int64) []*entity.Task {
+	limit = min(limit, p.fetcher.maxTasks)
 	ctx, span := xlog.WithOperationSpan(ctx, "queue_processor.fetchTasks",
 		xfield.Duration("timeout", p.fetcher.timeout),
 	)
@@ -216,13 +328,25 @@ func (p *GoqueProcessor) fetchTasks(ctx context.Context) []*entity.Task {
 	ctx, cancel := context.WithTimeout(ctx, p.fetcher.timeout)
 	defer cancel()
 
-	tasks, err := p.taskStorage.GetTasksForProcessing(ctx, p.fetcher.taskType, p.fetcher.maxTasks)
+	if p.isPaused(ctx) {
+		return []*entity.Task{}
+	}
+
+	maxTasks := p.takeRateLimitTokens(ctx, limit)
+	if maxTasks == 0 {
+		p.returnRateLimitTokens(ctx, limit, maxTasks, 0)
+		return []*entity.Task{}
+	}
+
+	tasks, err := p.taskStorage.GetTasksForProcessing(ctx, p.fetcher.taskType, maxTasks, p.fetcher.lease, p.fetcher.concurrencyLimitPerKey)
 	if err != nil {
+		p.returnRateLimitTokens(ctx, limit, maxTasks, 0)
 		metrics.SetOperationsTotal(p.fetcher.taskType, entity.OperationFetch, 0)
 		xlog.Error(ctx, "failed to fetch tasks", xfield.Error(err))
 		return []*entity.Task{}
 	}
 
+	p.returnRateLimitTokens(ctx, limit, maxTasks, len(tasks))
 	metrics.SetOperationsTotal(p.fetcher.taskType, entity.OperationFetch, len(tasks))
 
 	return tasks
@@ -251,27 +375,44 @@ func (p *GoqueProcessor) callHooksAfter(ctx context.Context, task *entity.Task,
 func (p *GoqueProcessor) doProcessTask(ctx context.Context, task *entity.Task) {
 	p.callHooksBefore(ctx, task)
 
-	taskErr := p.processTask(ctx, task)
+	var taskErr error
+	switch {
+	case task.Status == entity.TaskStatusCanceled:
+		// the task was canceled by a user while it was waiting for a worker
+		taskErr = entity.ErrTaskCancelRequested
+	case !p.inFlight.holds(task.ID):
+		// the task was changed concurrently while it was waiting for a worker, it is not ours anymore
+		taskErr = entity.ErrTaskStateConflict
+	default:
+		taskErr = p.processTask(ctx, task)
+	}
 
 	p.callHooksAfter(ctx, task, taskErr)
 }
 
 func (p *GoqueProcessor) processTask(ctx context.Context, task *entity.Task) error {
+	timeout := p.taskTimeout(task)
 	ctx, span := xlog.WithOperationSpan(ctx, "queue_processor.processTask",
-		xfield.Duration("timeout", p.processor.timeout),
+		xfield.Duration("timeout", timeout),
 	)
 	defer span.End()
 
-	ctx, cancel := context.WithTimeout(ctx, p.processor.timeout)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
+	ctx, untrack := p.inFlight.track(ctx, task.ID)
+	defer untrack()
+	ctx = withTaskCheckpointer(ctx, p.taskStorage, task)
 
 	promTimer := prometheus.NewTimer(metrics.TaskProcessingDurationSecondsObserver(task.Type, entity.OperationProcessing))
 	defer promTimer.ObserveDuration()
 
-	err := p.processor.taskProcessor.ProcessTask(ctx, task)
+	err := p.processor.handler.ProcessTask(ctx, task)
 	switch {
+	case errors.Is(context.Cause(ctx), entity.ErrTaskCancelRequested):
+		xlog.Info(ctx, "task processing canceled by user request", xfield.Error(err))
+		return entity.ErrTaskCancelRequested
 	case errors.Is(err, context.DeadlineExceeded):
-		return fmt.Errorf("%w: %s. %w", entity.ErrTaskTimeout, p.processor.timeout, err)
+		return fmt.Errorf("%w: %s. %w", entity.ErrTaskTimeout, timeout, err)
 	case err != nil:
 		xlog.Error(ctx, "failed to process task", xfield.Error(err))
 		return err
@@ -280,6 +421,17 @@ func (p *GoqueProcessor) processTask(ctx context.Context, task *entity.Task) err
 	return nil
 }
 
+// taskTimeout returns the processing timeout of the task, the processor default unless the task overrides it.
+func (p *GoqueProcessor) taskTimeout(task *entity.Task) time.Duration {
+	return lo.FromPtrOr(task.Timeout, p.processor.timeout)
+}
+
+// taskMaxAttempts returns the maximum number of attempts of the task, the processor default
+// unless the task overrides it.
+func (p *GoqueProcessor) taskMaxAttempts(task *entity.Task) int32 {
+	return lo.FromPtrOr(task.MaxAttempts, p.processor.maxAttempts)
+}
+
 func (p *GoqueProcessor) workersPanicHandler(ctx context.Context) func(any) {
 	return func(a any) {
 		xlog.Error(ctx, "worker pool panic",
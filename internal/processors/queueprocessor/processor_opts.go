@@ -3,6 +3,8 @@ package queueprocessor
 import (
 	"context"
 	"time"
+
+	"github.com/ruko1202/goque/internal/entity"
 )
 
 // GoqueProcessorOpts is a function type for configuring GoqueProcessor options.
@@ -29,7 +31,64 @@ func WithTaskFetcherTimeout(timeout time.Duration) GoqueProcessorOpts {
 	}
 }
 
+// WithTaskFetcherListenNotify enables fetching tasks as soon as they are added to the queue.
+// The processor listens to new task notifications (PostgreSQL LISTEN/NOTIFY) and falls back
+// to the WithTaskFetcherTick polling while the listener is down.
+// It is a no-op for storages without notifications support (MySQL, SQLite).
+func WithTaskFetcherListenNotify() GoqueProcessorOpts {
+	return func(p *GoqueProcessor) {
+		p.fetcher.listenNotify = true
+	}
+}
+
+// WithTaskFetcherRateLimit limits the fetcher to tokens tasks per interval with up to burst tasks accumulated
+// while idle (tokens if not positive). The limit is a token bucket stored in the database (goque_rate_limit),
+// so it is shared by all processors of the task type across instances. A non-positive tokens or interval disables it.
+func WithTaskFetcherRateLimit(tokens int64, interval time.Duration, burst int64) GoqueProcessorOpts {
+	return func(p *GoqueProcessor) {
+		if tokens <= 0 || interval <= 0 {
+			p.fetcher.rateLimit = nil
+			return
+		}
+		p.fetcher.rateLimit = &entity.RateLimit{
+			Tokens:   tokens,
+			Interval: interval,
+			Burst:    burst,
+		}
+	}
+}
+
+// WithConcurrencyLimitPerKey limits the number of tasks in flight (pending or processing) per concurrency key
+// across all processors sharing the database, see entity.WithTaskConcurrencyKey. Tasks of any type with
+// the same key count towards the limit. A non-positive limit disables it.
+func WithConcurrencyLimitPerKey(limit int64) GoqueProcessorOpts {
+	return func(p *GoqueProcessor) {
+		p.fetcher.concurrencyLimitPerKey = max(limit, 0)
+	}
+}
+
+// WithTaskLeaseDuration sets how long the processor owns the fetched tasks without renewal.
+// Leases are renewed in the background while the tasks are held, so the duration only bounds
+// how long the tasks of a crashed processor wait for the healer.
+func WithTaskLeaseDuration(duration time.Duration) GoqueProcessorOpts {
+	if duration <= 0 {
+		duration = defaultLeaseDuration
+	}
+	return func(p *GoqueProcessor) {
+		p.fetcher.lease.Duration = duration
+	}
+}
+
+// WithTaskLeaseOwner sets the lease owner stamped on the fetched tasks (goque_task.locked_by).
+// It must be unique per processor instance. Defaults to "<hostname>-<pid>-<uuid>".
+func WithTaskLeaseOwner(owner string) GoqueProcessorOpts {
+	return func(p *GoqueProcessor) {
+		p.fetcher.lease.Owner = owner
+	}
+}
+
 // WithTaskProcessingTimeout sets the maximum execution time for a single task.
+// A task created with WithTaskTimeout overrides it.
 func WithTaskProcessingTimeout(timeout time.Duration) GoqueProcessorOpts {
 	return func(p *GoqueProcessor) {
 		p.processor.timeout = timeout
@@ -43,6 +102,53 @@ func WithWorkersCount(count int) GoqueProcessorOpts {
 	}
 }
 
+// WithWorkersAutoscale makes the processor tune the number of workers between minWorkers and maxWorkers
+// by the load: the size of the fetched batches, whether they come full, the processing latency
+// and the backlog of the tasks of the type waiting in the queue, counted every 30 seconds.
+// The pool starts with the WithWorkersCount workers kept within the range.
+// A non-positive minWorkers or maxWorkers less than minWorkers disables autoscaling.
+func WithWorkersAutoscale(minWorkers, maxWorkers int) GoqueProcessorOpts {
+	return func(p *GoqueProcessor) {
+		if minWorkers <= 0 || maxWorkers < minWorkers {
+			p.processor.autoscale = nil
+			return
+		}
+		p.processor.autoscale = &workersAutoscale{
+			minWorkers: minWorkers,
+			maxWorkers: maxWorkers,
+		}
+	}
+}
+
+// WithSharedWorkerPool runs the processor on the worker pool shared with other processors instead of its own.
+// The pool's scheduler fetches the tasks of the type when it has free workers: at least minWorkers of them
+// are kept for the type while it has tasks, and the rest are split among the types by weight.
+// Types with a low weight still get workers regularly, so they are never starved.
+// WithWorkersCount, WithWorkersAutoscale and WithTaskFetcherTick don't apply to such a processor,
+// WithTaskFetcherMaxTasks still caps a single fetch. A nil pool disables it.
+func WithSharedWorkerPool(pool *SharedWorkerPool, weight, minWorkers int) GoqueProcessorOpts {
+	return func(p *GoqueProcessor) {
+		if pool == nil {
+			p.shared = nil
+			return
+		}
+		p.shared = &sharedPoolMember{
+			pool:       pool,
+			processor:  p,
+			weight:     max(weight, 1),
+			minWorkers: max(minWorkers, 0),
+		}
+	}
+}
+
+// WithMiddlewares wraps the task processor with middlewares, the first one being the outermost.
+// They run inside the processing, within the task timeout and cancellation.
+func WithMiddlewares(middlewares ...Middleware) GoqueProcessorOpts {
+	return func(p *GoqueProcessor) {
+		p.processor.middlewares = append(p.processor.middlewares, middlewares...)
+	}
+}
+
 // WithWorkersPanicHandler sets a custom panic handler for worker pool panics.
 func WithWorkersPanicHandler(handler func(ctx context.Context) func(any)) GoqueProcessorOpts {
 	return func(p *GoqueProcessor) {
@@ -51,6 +157,7 @@ func WithWorkersPanicHandler(handler func(ctx context.Context) func(any)) GoqueP
 }
 
 // WithTaskProcessingMaxAttempts sets the maximum number of retry attempts for failed tasks.
+// A task created with WithTaskMaxAttempts overrides it.
 func WithTaskProcessingMaxAttempts(maxAttempts int32) GoqueProcessorOpts {
 	if maxAttempts <= 0 {
 		maxAttempts = 1
@@ -61,9 +168,47 @@ func WithTaskProcessingMaxAttempts(maxAttempts int32) GoqueProcessorOpts {
 }
 
 // WithTaskProcessingNextAttemptAtFunc sets a custom function to calculate the next retry time.
+// A nil function resets it to the default static retry period.
 func WithTaskProcessingNextAttemptAtFunc(nextAttemptAt NextAttemptAtFunc) GoqueProcessorOpts {
+	if nextAttemptAt == nil {
+		return WithTaskProcessingBackoff(nil)
+	}
+	return WithTaskProcessingBackoff(nextAttemptAt)
+}
+
+// WithTaskProcessingBackoff sets a backoff strategy to calculate the next retry time from the task and its error.
+// A nil strategy resets it to the default static retry period.
+func WithTaskProcessingBackoff(backoff BackoffStrategy) GoqueProcessorOpts {
+	if backoff == nil {
+		backoff = StaticNextAttemptAtFunc(defaultProcessorStaticNextAttemptPeriod)
+	}
+	return func(p *GoqueProcessor) {
+		p.processor.backoff = backoff
+	}
+}
+
+// WithTaskCancelCheckPeriod sets how often the processor checks whether the tasks it is processing
+// were canceled by a user. The processing context of such a task is canceled with
+// entity.ErrTaskCancelRequested as the cause (see context.Cause). A zero or negative period disables the checks.
+func WithTaskCancelCheckPeriod(period time.Duration) GoqueProcessorOpts {
+	return func(p *GoqueProcessor) {
+		p.processor.cancelCheckPeriod = period
+	}
+}
+
+// WithDeadLetterQueue moves tasks that ran out of attempts to the dead letters table (goque_task_dead)
+// instead of leaving them in the queue with the attempts_left status until the cleaner removes them.
+// Dead tasks are kept until they are requeued or purged via the TaskQueueManager.
+func WithDeadLetterQueue() GoqueProcessorOpts {
+	return func(p *GoqueProcessor) {
+		p.processor.deadLetterQueue = true
+	}
+}
+
+// WithDeadLetterHandler sets a callback called once a task runs out of attempts and its state is saved.
+func WithDeadLetterHandler(handler DeadLetterHandler) GoqueProcessorOpts {
 	return func(p *GoqueProcessor) {
-		p.processor.nextAttemptAtFunc = nextAttemptAt
+		p.processor.deadLetterHandler = handler
 	}
 }
 
@@ -102,7 +247,8 @@ func WithCleanerPeriod(period time.Duration) GoqueProcessorOpts {
 	}
 }
 
-// WithHealerUpdatedAtTimeAgo sets the time threshold for considering a task as stuck.
+// WithHealerUpdatedAtTimeAgo sets the time threshold for considering a task fetched without a lease as stuck.
+// Leased tasks are stuck once their lease expires, see WithTaskLeaseDuration.
 func WithHealerUpdatedAtTimeAgo(updatedAtTimeAgo time.Duration) GoqueProcessorOpts {
 	return func(q *GoqueProcessor) {
 		q.queueHealer.SetUpdatedAtTimeAgo(updatedAtTimeAgo)
@@ -18,6 +18,8 @@ import (
 	"go.uber.org/zap/zaptest/observer"
 
 	"github.com/ruko1202/goque/internal/entity"
+	"github.com/ruko1202/goque/internal/pkg/generated/mocks/mock_storages"
+	"github.com/ruko1202/goque/internal/storages/dbentity"
 	"github.com/ruko1202/goque/internal/utils/xtime"
 	"github.com/ruko1202/goque/test/testutils"
 )
@@ -41,6 +43,8 @@ func TestGoqueProcessor(t *testing.T) {
 			CreatedAt:     now,
 			UpdatedAt:     nil,
 			NextAttemptAt: now,
+			Checkpoint:    lo.ToPtr(`{"offset":10}`),
+			Progress:      50,
 		}
 
 		processedTasks := atomic.Int32{}
@@ -70,6 +74,8 @@ func TestGoqueProcessor(t *testing.T) {
 				DoAndReturn(func(_ context.Context, taskID uuid.UUID, task *entity.Task) error {
 					assert.Equal(t, task.ID, taskID)
 					assert.Equal(t, entity.TaskStatusDone, task.Status)
+					assert.Nil(t, task.Checkpoint)
+					assert.EqualValues(t, entity.MaxTaskProgress, task.Progress)
 					return nil
 				}),
 		)
@@ -137,6 +143,7 @@ func TestGoqueProcessor(t *testing.T) {
 					assert.Equal(t, entity.TaskStatusError, task.Status)
 					assert.Equal(t, "attempt 1: task processing timeout: 100ms. context deadline exceeded\n", lo.FromPtr(task.Errors))
 					testutils.AssertTimeInWithDelta(t, now.Add(time.Minute).In(time.UTC), task.NextAttemptAt, time.Minute)
+					assert.InDelta(t, time.Minute, lo.FromPtr(task.RetryDelay), float64(time.Second))
 					return nil
 				}),
 		)
@@ -149,6 +156,59 @@ func TestGoqueProcessor(t *testing.T) {
 		goqueProc.Stop()
 	})
 
+	t.Run("task timeout override", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+		task := &entity.Task{
+			ID:            uuid.New(),
+			Type:          "type[task timeout override]",
+			ExternalID:    uuid.NewString(),
+			Payload:       "test payload",
+			Status:        entity.TaskStatusPending,
+			Errors:        nil,
+			CreatedAt:     now,
+			UpdatedAt:     nil,
+			NextAttemptAt: now,
+			Timeout:       lo.ToPtr(100 * time.Millisecond),
+		}
+
+		processedTasks := atomic.Int32{}
+		goqueProc, mocks := initGoqueProcessorWithMocks(t,
+			task.Type,
+			TaskProcessorFunc(func(ctx context.Context, _ *entity.Task) error {
+				defer processedTasks.Add(1)
+
+				<-ctx.Done()
+				return ctx.Err()
+			}),
+			WithTaskFetcherTick(100*time.Millisecond),
+			WithTaskProcessingTimeout(time.Minute),
+		)
+
+		defaultFetcherMock(mocks, task.Type, []*entity.Task{task})
+
+		gomock.InOrder(
+			mocks.taskStorage.EXPECT().
+				UpdateTask(gomock.Any(), task.ID, task).
+				Return(nil),
+			mocks.taskStorage.EXPECT().
+				UpdateTask(gomock.Any(), task.ID, task).
+				DoAndReturn(func(_ context.Context, _ uuid.UUID, task *entity.Task) error {
+					assert.Equal(t, entity.TaskStatusError, task.Status)
+					assert.Equal(t, "attempt 1: task processing timeout: 100ms. context deadline exceeded\n", lo.FromPtr(task.Errors))
+					return nil
+				}),
+		)
+
+		err := goqueProc.Run(ctx)
+		require.NoError(t, err)
+		require.Eventually(t, func() bool {
+			return processedTasks.Load() == 1
+		}, time.Second*2, time.Millisecond*100)
+		goqueProc.Stop()
+	})
+
 	t.Run("max attempts", func(t *testing.T) {
 		t.Parallel()
 		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
@@ -204,6 +264,515 @@ func TestGoqueProcessor(t *testing.T) {
 		goqueProc.Stop()
 	})
 
+	t.Run("task max attempts override", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+		task := &entity.Task{
+			ID:            uuid.New(),
+			Type:          "type[task max attempts override]",
+			ExternalID:    uuid.NewString(),
+			Payload:       "test payload",
+			Status:        entity.TaskStatusPending,
+			Errors:        nil,
+			CreatedAt:     now,
+			UpdatedAt:     nil,
+			NextAttemptAt: now,
+			MaxAttempts:   lo.ToPtr[int32](1),
+		}
+
+		processedTasks := atomic.Int32{}
+		goqueProc, mocks := initGoqueProcessorWithMocks(t,
+			task.Type,
+			TaskProcessorFunc(func(_ context.Context, _ *entity.Task) error {
+				processedTasks.Add(1)
+				return errors.New("task processing error")
+			}),
+			WithTaskFetcherTick(100*time.Millisecond),
+			WithTaskProcessingMaxAttempts(5),
+		)
+
+		defaultFetcherMock(mocks, task.Type, []*entity.Task{task})
+
+		gomock.InOrder(
+			mocks.taskStorage.EXPECT().
+				UpdateTask(gomock.Any(), task.ID, task).
+				Return(nil),
+			mocks.taskStorage.EXPECT().
+				UpdateTask(gomock.Any(), task.ID, task).
+				DoAndReturn(func(_ context.Context, _ uuid.UUID, task *entity.Task) error {
+					assert.Equal(t, entity.TaskStatusAttemptsLeft, task.Status)
+					assert.Equal(t, "attempt 1: task processing error\n", lo.FromPtr(task.Errors))
+					return nil
+				}),
+		)
+
+		err := goqueProc.Run(ctx)
+		require.NoError(t, err)
+		require.Eventually(t, func() bool {
+			return processedTasks.Load() == 1
+		}, time.Second*2, time.Millisecond*100)
+		goqueProc.Stop()
+	})
+
+	t.Run("processing results", func(t *testing.T) {
+		t.Parallel()
+
+		processingErr := errors.New("task processing error")
+		testCases := map[string]struct {
+			taskErr    error
+			assertTask func(t *testing.T, task *entity.Task)
+		}{
+			"permanent": {
+				taskErr: entity.Permanent(processingErr),
+				assertTask: func(t *testing.T, task *entity.Task) {
+					t.Helper()
+					assert.Equal(t, entity.TaskStatusAttemptsLeft, task.Status)
+					assert.Equal(t, int32(1), task.Attempts)
+					assert.Equal(t, "attempt 1: permanent: task processing error\n", lo.FromPtr(task.Errors))
+				},
+			},
+			"retry after": {
+				taskErr: entity.RetryAfter(processingErr, 2*time.Hour),
+				assertTask: func(t *testing.T, task *entity.Task) {
+					t.Helper()
+					assert.Equal(t, entity.TaskStatusError, task.Status)
+					assert.Equal(t, int32(1), task.Attempts)
+					assert.Equal(t, "attempt 1: task processing error (retry after 2h0m0s)\n", lo.FromPtr(task.Errors))
+					testutils.AssertTimeInWithDelta(t, xtime.Now().Add(2*time.Hour), task.NextAttemptAt, time.Minute)
+				},
+			},
+			"snooze": {
+				taskErr: entity.Snooze(time.Hour),
+				assertTask: func(t *testing.T, task *entity.Task) {
+					t.Helper()
+					assert.Equal(t, entity.TaskStatusNew, task.Status)
+					assert.Equal(t, int32(0), task.Attempts)
+					assert.Equal(t, "attempt 0: snoozed for 1h0m0s\n", lo.FromPtr(task.Errors))
+					testutils.AssertTimeInWithDelta(t, xtime.Now().Add(time.Hour), task.NextAttemptAt, time.Minute)
+				},
+			},
+		}
+
+		for name, tt := range testCases {
+			t.Run(name, func(t *testing.T) {
+				t.Parallel()
+				ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+				task := &entity.Task{
+					ID:            uuid.New(),
+					Type:          "type[processing results " + name + "]",
+					ExternalID:    uuid.NewString(),
+					Payload:       "test payload",
+					Status:        entity.TaskStatusPending,
+					Errors:        nil,
+					CreatedAt:     now,
+					UpdatedAt:     nil,
+					NextAttemptAt: now,
+				}
+
+				processedTasks := atomic.Int32{}
+				goqueProc, mocks := initGoqueProcessorWithMocks(t,
+					task.Type,
+					TaskProcessorFunc(func(_ context.Context, _ *entity.Task) error {
+						processedTasks.Add(1)
+						return tt.taskErr
+					}),
+					WithTaskFetcherTick(100*time.Millisecond),
+					WithTaskProcessingMaxAttempts(5),
+					WithTaskProcessingNextAttemptAtFunc(StaticNextAttemptAtFunc(time.Minute)),
+				)
+
+				defaultFetcherMock(mocks, task.Type, []*entity.Task{task})
+
+				gomock.InOrder(
+					mocks.taskStorage.EXPECT().
+						UpdateTask(gomock.Any(), task.ID, task).
+						Return(nil),
+					mocks.taskStorage.EXPECT().
+						UpdateTask(gomock.Any(), task.ID, task).
+						DoAndReturn(func(_ context.Context, _ uuid.UUID, task *entity.Task) error {
+							tt.assertTask(t, task)
+							return nil
+						}),
+				)
+
+				err := goqueProc.Run(ctx)
+				require.NoError(t, err)
+				require.Eventually(t, func() bool {
+					return processedTasks.Load() == 1
+				}, time.Second*2, time.Millisecond*100)
+				goqueProc.Stop()
+			})
+		}
+	})
+
+	t.Run("dead letter queue", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+		task := &entity.Task{
+			ID:            uuid.New(),
+			Type:          "type[dead letter queue",
+			ExternalID:    uuid.NewString(),
+			Payload:       "test payload",
+			Status:        entity.TaskStatusPending,
+			Errors:        nil,
+			CreatedAt:     now,
+			UpdatedAt:     nil,
+			NextAttemptAt: now,
+		}
+
+		taskErr := errors.New("task processing error")
+		deadTasks := make(chan *entity.Task, 1)
+		goqueProc, mocks := initGoqueProcessorWithMocks(t,
+			task.Type,
+			TaskProcessorFunc(func(_ context.Context, _ *entity.Task) error {
+				return taskErr
+			}),
+			WithTaskFetcherTick(100*time.Millisecond),
+			WithTaskProcessingMaxAttempts(1),
+			WithDeadLetterQueue(),
+			WithDeadLetterHandler(func(_ context.Context, task *entity.Task, err error) {
+				assert.ErrorIs(t, err, taskErr)
+				deadTasks <- task
+			}),
+		)
+
+		defaultFetcherMock(mocks, task.Type, []*entity.Task{task})
+
+		gomock.InOrder(
+			mocks.taskStorage.EXPECT().
+				UpdateTask(gomock.Any(), gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, taskID uuid.UUID, task *entity.Task) error {
+					assert.Equal(t, task.ID, taskID)
+					assert.Equal(t, entity.TaskStatusProcessing, task.Status)
+					return nil
+				}),
+			mocks.taskStorage.EXPECT().
+				MoveTaskToDead(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, task *entity.Task) error {
+					assert.Equal(t, entity.TaskStatusAttemptsLeft, task.Status)
+					assert.Equal(t, "attempt 1: task processing error\n", lo.FromPtr(task.Errors))
+					return nil
+				}),
+		)
+
+		err := goqueProc.Run(ctx)
+		require.NoError(t, err)
+		select {
+		case deadTask := <-deadTasks:
+			require.Equal(t, task.ID, deadTask.ID)
+		case <-time.After(2 * time.Second):
+			require.Fail(t, "dead letter handler is not called")
+		}
+		goqueProc.Stop()
+	})
+
+	t.Run("cancel in-flight task", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+		task := &entity.Task{
+			ID:            uuid.New(),
+			Type:          "type[cancel in-flight task]",
+			ExternalID:    uuid.NewString(),
+			Payload:       "test payload",
+			Status:        entity.TaskStatusPending,
+			Errors:        nil,
+			CreatedAt:     now,
+			UpdatedAt:     nil,
+			NextAttemptAt: now,
+		}
+
+		causes := make(chan error, 1)
+		finishedTasks := make(chan *entity.Task, 1)
+		goqueProc, mocks := initGoqueProcessorWithMocks(t,
+			task.Type,
+			TaskProcessorFunc(func(ctx context.Context, _ *entity.Task) error {
+				<-ctx.Done()
+				causes <- context.Cause(ctx)
+				return ctx.Err()
+			}),
+			WithTaskFetcherTick(100*time.Millisecond),
+			WithTaskCancelCheckPeriod(50*time.Millisecond),
+			WithHooksAfterProcessing(func(_ context.Context, task *entity.Task, _ error) {
+				finishedTasks <- task
+			}),
+		)
+
+		defaultFetcherMock(mocks, task.Type, []*entity.Task{task})
+
+		// only the processing status is saved, the canceled one is set by the user
+		mocks.taskStorage.EXPECT().
+			UpdateTask(gomock.Any(), task.ID, task).
+			DoAndReturn(func(_ context.Context, _ uuid.UUID, task *entity.Task) error {
+				assert.Equal(t, entity.TaskStatusProcessing, task.Status)
+				return nil
+			})
+		mocks.taskStorage.EXPECT().
+			GetTasks(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, filter *dbentity.GetTasksFilter, _ int64) ([]*entity.Task, error) {
+				assert.Equal(t, []uuid.UUID{task.ID}, filter.IDs)
+				assert.Equal(t, entity.TaskStatusCanceled, lo.FromPtr(filter.Status))
+				return []*entity.Task{{ID: task.ID, Status: entity.TaskStatusCanceled}}, nil
+			}).
+			MinTimes(1)
+
+		err := goqueProc.Run(ctx)
+		require.NoError(t, err)
+		select {
+		case cause := <-causes:
+			require.ErrorIs(t, cause, entity.ErrTaskCancelRequested)
+		case <-time.After(2 * time.Second):
+			require.Fail(t, "task processing is not canceled")
+		}
+		select {
+		case finishedTask := <-finishedTasks:
+			require.Equal(t, entity.TaskStatusCanceled, finishedTask.Status)
+		case <-time.After(2 * time.Second):
+			require.Fail(t, "task processing is not finished")
+		}
+		goqueProc.Stop()
+	})
+
+	t.Run("task canceled before final update", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+		task := &entity.Task{
+			ID:            uuid.New(),
+			Type:          "type[task canceled before final update]",
+			ExternalID:    uuid.NewString(),
+			Payload:       "test payload",
+			Status:        entity.TaskStatusPending,
+			Errors:        nil,
+			CreatedAt:     now,
+			UpdatedAt:     nil,
+			NextAttemptAt: now,
+		}
+
+		finishedTasks := make(chan *entity.Task, 1)
+		goqueProc, mocks := initGoqueProcessorWithMocks(t,
+			task.Type,
+			NoopTaskProcessor(),
+			WithTaskFetcherTick(100*time.Millisecond),
+			WithHooksAfterProcessing(func(_ context.Context, task *entity.Task, _ error) {
+				finishedTasks <- task
+			}),
+		)
+
+		defaultFetcherMock(mocks, task.Type, []*entity.Task{task})
+
+		gomock.InOrder(
+			mocks.taskStorage.EXPECT().
+				UpdateTask(gomock.Any(), task.ID, task).
+				Return(nil),
+			mocks.taskStorage.EXPECT().
+				UpdateTask(gomock.Any(), task.ID, task).
+				DoAndReturn(func(_ context.Context, _ uuid.UUID, task *entity.Task) error {
+					assert.Equal(t, entity.TaskStatusDone, task.Status)
+					return entity.ErrTaskCancelRequested
+				}),
+		)
+
+		err := goqueProc.Run(ctx)
+		require.NoError(t, err)
+		select {
+		case finishedTask := <-finishedTasks:
+			require.Equal(t, entity.TaskStatusCanceled, finishedTask.Status)
+		case <-time.After(2 * time.Second):
+			require.Fail(t, "task processing is not finished")
+		}
+		goqueProc.Stop()
+	})
+
+	t.Run("task changed concurrently before processing", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+		task := &entity.Task{
+			ID:            uuid.New(),
+			Type:          "type[task changed concurrently]",
+			ExternalID:    uuid.NewString(),
+			Payload:       "test payload",
+			Status:        entity.TaskStatusPending,
+			Errors:        nil,
+			CreatedAt:     now,
+			UpdatedAt:     nil,
+			NextAttemptAt: now,
+		}
+
+		var processed atomic.Bool
+		finishedTasks := make(chan error, 1)
+		goqueProc, mocks := initGoqueProcessorWithMocks(t,
+			task.Type,
+			TaskProcessorFunc(func(_ context.Context, _ *entity.Task) error {
+				processed.Store(true)
+				return nil
+			}),
+			WithTaskFetcherTick(100*time.Millisecond),
+			WithHooksAfterProcessing(func(_ context.Context, _ *entity.Task, taskErr error) {
+				finishedTasks <- taskErr
+			}),
+		)
+
+		defaultFetcherMock(mocks, task.Type, []*entity.Task{task})
+
+		// the healer reclaimed the task, nothing is written after the conflict
+		mocks.taskStorage.EXPECT().
+			UpdateTask(gomock.Any(), task.ID, task).
+			Return(entity.ErrTaskStateConflict)
+
+		err := goqueProc.Run(ctx)
+		require.NoError(t, err)
+		select {
+		case taskErr := <-finishedTasks:
+			require.ErrorIs(t, taskErr, entity.ErrTaskStateConflict)
+			require.False(t, processed.Load())
+			require.Empty(t, goqueProc.inFlight.ids())
+		case <-time.After(2 * time.Second):
+			require.Fail(t, "task processing is not finished")
+		}
+		goqueProc.Stop()
+	})
+
+	t.Run("rate limit", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+		taskType := "type[rate limit]"
+		limit := entity.RateLimit{Tokens: 100, Interval: time.Second, Burst: 10}
+		goqueProc, mocks := initGoqueProcessorWithMocks(t,
+			taskType,
+			NoopTaskProcessor(),
+			WithTaskFetcherMaxTasks(defaultFetchMaxTasks),
+			WithTaskFetcherRateLimit(limit.Tokens, limit.Interval, limit.Burst),
+		)
+
+		task := entity.NewTask(taskType, "test payload")
+		mocks.taskStorage.EXPECT().
+			IsTaskTypePaused(gomock.Any(), taskType).
+			Return(false, nil).
+			AnyTimes()
+		gomock.InOrder(
+			// claims only as many tasks as tokens allow and returns the unused ones
+			mocks.taskStorage.EXPECT().
+				TakeRateLimitTokens(gomock.Any(), taskType, limit, defaultFetchMaxTasks).
+				Return(int64(3), nil),
+			mocks.taskStorage.EXPECT().
+				GetTasksForProcessing(gomock.Any(), taskType, int64(3), gomock.Any(), int64(0)).
+				Return([]*entity.Task{task}, nil),
+			mocks.taskStorage.EXPECT().
+				ReturnRateLimitTokens(gomock.Any(), taskType, limit, int64(2)).
+				Return(nil),
+			// no tokens, no fetch
+			mocks.taskStorage.EXPECT().
+				TakeRateLimitTokens(gomock.Any(), taskType, limit, defaultFetchMaxTasks).
+				Return(int64(0), nil),
+			// storage errors take no tokens
+			mocks.taskStorage.EXPECT().
+				TakeRateLimitTokens(gomock.Any(), taskType, limit, defaultFetchMaxTasks).
+				Return(int64(0), errors.New("some error")),
+		)
+
+		require.Equal(t, []*entity.Task{task}, goqueProc.fetchTasks(ctx))
+		require.Empty(t, goqueProc.fetchTasks(ctx))
+		require.Empty(t, goqueProc.fetchTasks(ctx))
+	})
+
+	t.Run("pause", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+		taskType := "type[pause]"
+		goqueProc, mocks := initGoqueProcessorWithMocks(t,
+			taskType,
+			NoopTaskProcessor(),
+		)
+
+		task := entity.NewTask(taskType, "test payload")
+		gomock.InOrder(
+			// paused types are not fetched
+			mocks.taskStorage.EXPECT().
+				IsTaskTypePaused(gomock.Any(), taskType).
+				Return(true, nil),
+			// storage errors skip the fetch
+			mocks.taskStorage.EXPECT().
+				IsTaskTypePaused(gomock.Any(), taskType).
+				Return(false, errors.New("some error")),
+			// resumed types are fetched again
+			mocks.taskStorage.EXPECT().
+				IsTaskTypePaused(gomock.Any(), taskType).
+				Return(false, nil),
+			mocks.taskStorage.EXPECT().
+				GetTasksForProcessing(gomock.Any(), taskType, defaultFetchMaxTasks, gomock.Any(), int64(0)).
+				Return([]*entity.Task{task}, nil),
+		)
+
+		require.Empty(t, goqueProc.fetchTasks(ctx))
+		require.True(t, goqueProc.fetcher.paused.Load())
+		require.Empty(t, goqueProc.fetchTasks(ctx))
+		require.Equal(t, []*entity.Task{task}, goqueProc.fetchTasks(ctx))
+		require.False(t, goqueProc.fetcher.paused.Load())
+	})
+
+	t.Run("renew leases of in-flight tasks", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+		task := &entity.Task{
+			ID:            uuid.New(),
+			Type:          "type[renew leases]",
+			ExternalID:    uuid.NewString(),
+			Payload:       "test payload",
+			Status:        entity.TaskStatusPending,
+			Errors:        nil,
+			CreatedAt:     now,
+			UpdatedAt:     nil,
+			NextAttemptAt: now,
+		}
+
+		renewals := atomic.Int32{}
+		processedTasks := atomic.Int32{}
+		goqueProc, mocks := initGoqueProcessorWithMocks(t,
+			task.Type,
+			TaskProcessorFunc(func(_ context.Context, _ *entity.Task) error {
+				defer processedTasks.Add(1)
+				// outlive a couple of lease renewals
+				time.Sleep(300 * time.Millisecond)
+				return nil
+			}),
+			WithTaskFetcherTick(100*time.Millisecond),
+			WithTaskLeaseDuration(150*time.Millisecond),
+			WithTaskLeaseOwner("test-owner"),
+		)
+
+		defaultFetcherMock(mocks, task.Type, []*entity.Task{task})
+		mocks.taskStorage.EXPECT().
+			UpdateTask(gomock.Any(), task.ID, task).
+			Return(nil).
+			Times(2)
+		mocks.taskStorage.EXPECT().
+			RenewTaskLeases(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, taskIDs []uuid.UUID, lease entity.TaskLease) error {
+				assert.Equal(t, []uuid.UUID{task.ID}, taskIDs)
+				assert.Equal(t, entity.TaskLease{Owner: "test-owner", Duration: 150 * time.Millisecond}, lease)
+				renewals.Add(1)
+				return nil
+			}).
+			MinTimes(1)
+
+		err := goqueProc.Run(ctx)
+		require.NoError(t, err)
+		require.Eventually(t, func() bool {
+			return processedTasks.Load() == 1
+		}, time.Second*2, time.Millisecond*100)
+		goqueProc.Stop()
+
+		require.GreaterOrEqual(t, renewals.Load(), int32(1))
+	})
+
 	t.Run("task canceled", func(t *testing.T) {
 		t.Parallel()
 		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
@@ -426,4 +995,60 @@ func TestGoqueProcessor(t *testing.T) {
 			require.NotEqual(t, "process task successfully", logEntry.Message)
 		}
 	})
+
+	t.Run("fetch on task notification", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+		taskType := "type[listen notify]"
+		ctrl := gomock.NewController(t)
+		taskStorage := mock_storages.NewMockTask(ctrl)
+		taskListener := mock_storages.NewMockTaskListener(ctrl)
+
+		notifications := make(chan struct{}, 1)
+		taskListener.EXPECT().
+			ListenTasks(gomock.Any(), taskType).
+			DoAndReturn(func(ctx context.Context, _ entity.TaskType) (<-chan struct{}, error) {
+				go func() {
+					<-ctx.Done()
+					close(notifications)
+				}()
+				return notifications, nil
+			})
+
+		fetches := atomic.Int32{}
+		taskStorage.EXPECT().
+			IsTaskTypePaused(gomock.Any(), taskType).
+			Return(false, nil).
+			AnyTimes()
+		taskStorage.EXPECT().
+			GetTasksForProcessing(gomock.Any(), taskType, defaultFetchMaxTasks, gomock.Any(), int64(0)).
+			DoAndReturn(func(_ context.Context, _ entity.TaskType, _ int64, _ entity.TaskLease, _ int64) ([]*entity.Task, error) {
+				fetches.Add(1)
+				return []*entity.Task{}, nil
+			}).
+			AnyTimes()
+
+		goqueProc := NewGoqueProcessor(
+			struct {
+				*mock_storages.MockTask
+				*mock_storages.MockTaskListener
+			}{taskStorage, taskListener},
+			taskType,
+			NoopTaskProcessor(),
+			// the ticker must not fire during the test
+			WithTaskFetcherTick(time.Hour),
+			WithTaskFetcherListenNotify(),
+		)
+
+		err := goqueProc.Run(ctx)
+		require.NoError(t, err)
+
+		notifications <- struct{}{}
+		require.Eventually(t, func() bool {
+			return fetches.Load() == 1
+		}, time.Second*2, time.Millisecond*10)
+
+		goqueProc.Stop()
+	})
 }
@@ -0,0 +1,50 @@
+package queueprocessor
+
+import (
+	"context"
+
+	"github.com/ruko1202/xlog"
+	"github.com/ruko1202/xlog/xfield"
+
+	"github.com/ruko1202/goque/internal/metrics"
+)
+
+// takeRateLimitTokens returns how many of maxTasks tasks the fetcher may claim now. Without a rate limit
+// it is maxTasks, otherwise the tokens taken from the shared bucket.
+// Storage errors take no tokens, so the limit is never exceeded.
+func (p *GoqueProcessor) takeRateLimitTokens(ctx context.Context, maxTasks int64) int64 {
+	if p.fetcher.rateLimit == nil {
+		return maxTasks
+	}
+
+	tokens, err := p.taskStorage.TakeRateLimitTokens(ctx, p.fetcher.taskType, *p.fetcher.rateLimit, maxTasks)
+	if err != nil {
+		xlog.Error(ctx, "failed to take rate limit tokens", xfield.Error(err))
+		return 0
+	}
+
+	return tokens
+}
+
+// returnRateLimitTokens records the rate limit metrics of the fetch and returns the tokens
+// not spent on the fetched tasks to the shared bucket.
+func (p *GoqueProcessor) returnRateLimitTokens(ctx context.Context, maxTasks, taken int64, fetched int) {
+	if p.fetcher.rateLimit == nil {
+		return
+	}
+
+	metrics.AddRateLimitTokensUsed(p.fetcher.taskType, fetched)
+	// every token was spent, but the fetcher could claim more tasks
+	if int64(fetched) == taken && taken < maxTasks {
+		metrics.IncRateLimitThrottled(p.fetcher.taskType)
+	}
+
+	unused := taken - int64(fetched)
+	if unused <= 0 {
+		return
+	}
+	err := p.taskStorage.ReturnRateLimitTokens(ctx, p.fetcher.taskType, *p.fetcher.rateLimit, unused)
+	if err != nil {
+		xlog.Error(ctx, "failed to return rate limit tokens", xfield.Error(err))
+	}
+}
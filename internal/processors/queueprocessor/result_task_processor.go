@@ -0,0 +1,77 @@
+package queueprocessor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/goccy/go-json"
+	"github.com/samber/lo"
+
+	"github.com/ruko1202/goque/internal/entity"
+)
+
+// ResultTaskProcessor defines the interface for processing tasks that return a result.
+// The result is marshaled to JSON and stored with the task when it is done.
+type ResultTaskProcessor interface {
+	ProcessTask(ctx context.Context, task *entity.Task) (any, error)
+}
+
+// ResultTaskProcessorFunc is a function type that implements the ResultTaskProcessor interface.
+type ResultTaskProcessorFunc func(ctx context.Context, task *entity.Task) (any, error)
+
+// ProcessTask executes the task processing function.
+func (f ResultTaskProcessorFunc) ProcessTask(ctx context.Context, task *entity.Task) (any, error) {
+	return f(ctx, task)
+}
+
+// NewResultTaskProcessor wraps a result task processor for use with RegisterProcessor.
+func NewResultTaskProcessor(processor ResultTaskProcessor) TaskProcessor {
+	return TaskProcessorFunc(func(ctx context.Context, task *entity.Task) error {
+		result, err := processor.ProcessTask(ctx, task)
+		if err != nil {
+			return err
+		}
+
+		return setTaskResult(task, result)
+	})
+}
+
+// TypedResultTaskProcessor defines the interface for processing typed task payloads returning a typed result.
+type TypedResultTaskProcessor[T, R any] interface {
+	ProcessTask(ctx context.Context, task *entity.TypedTask[T]) (R, error)
+}
+
+// TypedResultTaskProcessorFunc is a function type that implements the TypedResultTaskProcessor interface.
+type TypedResultTaskProcessorFunc[T, R any] func(ctx context.Context, task *entity.TypedTask[T]) (R, error)
+
+// ProcessTask executes the typed task processing function.
+func (f TypedResultTaskProcessorFunc[T, R]) ProcessTask(ctx context.Context, task *entity.TypedTask[T]) (R, error) {
+	return f(ctx, task)
+}
+
+// NewTypedResultTaskProcessor wraps a typed result task processor for use with RegisterProcessor.
+// The payload is decoded as by NewTypedTaskProcessor.
+func NewTypedResultTaskProcessor[T, R any](processor TypedResultTaskProcessor[T, R], opts ...GoqueTypedProcessorOpts[T]) TaskProcessor {
+	return NewTypedTaskProcessor(
+		TypedTaskProcessorFunc[T](func(ctx context.Context, task *entity.TypedTask[T]) error {
+			result, err := processor.ProcessTask(ctx, task)
+			if err != nil {
+				return err
+			}
+
+			return setTaskResult(task.Task, result)
+		}),
+		opts...,
+	)
+}
+
+// setTaskResult stores the result with the task, it's saved along with the done status.
+func setTaskResult(task *entity.Task, result any) error {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("%w: %s task result: %w", entity.ErrResultMarshal, task.Type, err)
+	}
+	task.Result = lo.ToPtr(string(data))
+
+	return nil
+}
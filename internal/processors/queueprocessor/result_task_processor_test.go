@@ -0,0 +1,86 @@
+package queueprocessor
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ruko1202/xlog"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/ruko1202/goque/internal/entity"
+)
+
+type typedResult struct {
+	Length int `json:"length"`
+}
+
+func TestResultTaskProcessor(t *testing.T) {
+	t.Parallel()
+	ctx := xlog.ContextWithLogger(context.Background(), xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+	t.Run("ok", func(t *testing.T) {
+		t.Parallel()
+
+		task := entity.NewTask("result", entity.NoTaskPayload)
+		processor := NewResultTaskProcessor(
+			ResultTaskProcessorFunc(func(_ context.Context, _ *entity.Task) (any, error) {
+				return map[string]string{"status": "ok"}, nil
+			}),
+		)
+
+		err := processor.ProcessTask(ctx, task)
+		require.NoError(t, err)
+		require.NotNil(t, task.Result)
+		require.JSONEq(t, `{"status":"ok"}`, *task.Result)
+	})
+
+	t.Run("processing error", func(t *testing.T) {
+		t.Parallel()
+
+		errProcessing := errors.New("processing error")
+		task := entity.NewTask("result", entity.NoTaskPayload)
+		processor := NewResultTaskProcessor(
+			ResultTaskProcessorFunc(func(_ context.Context, _ *entity.Task) (any, error) {
+				return "partial", errProcessing
+			}),
+		)
+
+		err := processor.ProcessTask(ctx, task)
+		require.ErrorIs(t, err, errProcessing)
+		require.Nil(t, task.Result)
+	})
+
+	t.Run("marshal error", func(t *testing.T) {
+		t.Parallel()
+
+		task := entity.NewTask("result", entity.NoTaskPayload)
+		processor := NewResultTaskProcessor(
+			ResultTaskProcessorFunc(func(_ context.Context, _ *entity.Task) (any, error) {
+				return make(chan int), nil
+			}),
+		)
+
+		err := processor.ProcessTask(ctx, task)
+		require.ErrorIs(t, err, entity.ErrResultMarshal)
+		require.Nil(t, task.Result)
+	})
+
+	t.Run("typed", func(t *testing.T) {
+		t.Parallel()
+
+		task := entity.NewTask("typed result", `{"value":"four"}`)
+		processor := NewTypedResultTaskProcessor(
+			TypedResultTaskProcessorFunc[typedPayload, typedResult](func(_ context.Context, task *entity.TypedTask[typedPayload]) (typedResult, error) {
+				return typedResult{Length: len(task.Payload.Value)}, nil
+			}),
+		)
+		goqueProc, _ := initGoqueProcessorWithMocks(t, task.Type, processor)
+
+		err := goqueProc.processTask(ctx, task)
+		require.NoError(t, err)
+		require.NotNil(t, task.Result)
+		require.JSONEq(t, `{"length":4}`, *task.Result)
+	})
+}
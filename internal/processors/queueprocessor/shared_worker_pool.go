@@ -0,0 +1,255 @@
+package queueprocessor
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/panjf2000/ants/v2"
+	"github.com/ruko1202/xlog"
+	"github.com/ruko1202/xlog/xfield"
+	"github.com/samber/lo"
+)
+
+// SharedWorkerPoolOpts is a functional option for configuring SharedWorkerPool.
+type SharedWorkerPoolOpts func(*SharedWorkerPool)
+
+// WithSharedWorkerPoolFetchTick sets how often the scheduler fetches tasks for the free workers.
+func WithSharedWorkerPoolFetchTick(tick time.Duration) SharedWorkerPoolOpts {
+	return func(s *SharedWorkerPool) {
+		s.tick = tick
+	}
+}
+
+// SharedWorkerPool is a worker pool shared by the processors of several task types, see WithSharedWorkerPool.
+// Instead of a fetch loop per processor, a single scheduler fetches tasks for the free workers,
+// splitting them among the task types by their min workers and weights.
+type SharedWorkerPool struct {
+	workers int
+	tick    time.Duration
+
+	// mu guards the members and the pool. The scheduler holds it for the whole schedule,
+	// so no tasks are fetched for a member once it has left the pool.
+	mu                 sync.Mutex
+	members            []*sharedPoolMember
+	pool               *ants.Pool
+	stopScheduler      context.CancelFunc
+	schedulerStoppedCh chan struct{}
+
+	wakeupCh chan struct{}
+	// backlog reports that the last schedule left tasks in the queue, so a released worker wakes up the scheduler.
+	backlog atomic.Bool
+}
+
+// sharedPoolMember is a processor running on a shared worker pool.
+type sharedPoolMember struct {
+	pool       *SharedWorkerPool
+	processor  *GoqueProcessor
+	weight     int
+	minWorkers int
+
+	// ctx is the run context of the processor.
+	ctx context.Context
+	// credit is the share of workers the member is owed but didn't get as a whole worker yet.
+	// It lets low-weight members get a worker regularly even when few workers are free.
+	credit float64
+}
+
+// NewSharedWorkerPool creates a worker pool of the given size to be shared by processors.
+// It runs while at least one of its processors runs.
+func NewSharedWorkerPool(workers int, opts ...SharedWorkerPoolOpts) *SharedWorkerPool {
+	s := &SharedWorkerPool{
+		workers:  max(workers, 1),
+		tick:     defaultFetchTick,
+		wakeupCh: make(chan struct{}, 1),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// attach adds the member to the pool, starting the pool with the first member.
+func (s *SharedWorkerPool) attach(ctx context.Context, member *sharedPoolMember) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.pool == nil {
+		schedulerCtx := xlog.WithOperation(context.WithoutCancel(ctx), "shared_worker_pool")
+		pool, err := ants.NewPool(s.workers,
+			ants.WithPanicHandler(member.processor.processor.workerPanicHandler(schedulerCtx)),
+		)
+		if err != nil {
+			return err
+		}
+
+		s.pool = pool
+		schedulerCtx, s.stopScheduler = context.WithCancel(schedulerCtx)
+		s.schedulerStoppedCh = make(chan struct{})
+		go s.runScheduler(schedulerCtx, s.schedulerStoppedCh)
+	}
+
+	member.ctx = ctx
+	s.members = append(s.members, member)
+
+	return nil
+}
+
+// detach removes the member from the pool. Once the last member has left, the returned func
+// stops the pool; it must be called after the tasks of the members are released.
+func (s *SharedWorkerPool) detach(member *sharedPoolMember) func() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.members = lo.Without(s.members, member)
+	if len(s.members) > 0 || s.pool == nil {
+		return func() {}
+	}
+
+	pool, stopScheduler, schedulerStoppedCh := s.pool, s.stopScheduler, s.schedulerStoppedCh
+	s.pool = nil
+
+	return func() {
+		stopScheduler()
+		<-schedulerStoppedCh
+		pool.Release()
+	}
+}
+
+func (s *SharedWorkerPool) wakeup() {
+	select {
+	case s.wakeupCh <- struct{}{}:
+	default:
+	}
+}
+
+func (s *SharedWorkerPool) runScheduler(ctx context.Context, stoppedCh chan<- struct{}) {
+	defer close(stoppedCh)
+
+	xlog.Info(ctx, "start shared worker pool", xfield.Int("workers", s.workers))
+
+	ticker := time.NewTicker(s.tick)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.schedule(ctx)
+		case <-s.wakeupCh:
+			s.schedule(ctx)
+		}
+	}
+}
+
+// schedule fetches tasks for the free workers. The members that fetched their whole share may have more
+// tasks, so the workers left by the others are shared among them again until all workers are busy
+// or no member has more tasks.
+func (s *SharedWorkerPool) schedule(ctx context.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.pool == nil {
+		return
+	}
+
+	busy := 0
+	for _, member := range s.members {
+		busy += member.processor.inFlight.count()
+	}
+	free := s.workers - busy
+
+	hungry := s.members
+	for free > 0 && len(hungry) > 0 {
+		shares := s.shares(hungry, free)
+
+		next := make([]*sharedPoolMember, 0, len(hungry))
+		for i, member := range hungry {
+			if shares[i] == 0 {
+				next = append(next, member)
+				continue
+			}
+
+			requested := min(int64(shares[i]), member.processor.fetcher.maxTasks)
+			tasks := member.processor.fetchTasksUpTo(member.ctx, requested)
+			free -= len(tasks)
+			if int64(len(tasks)) < requested {
+				// no more tasks for now, the credit is not carried over an idle period
+				member.credit = min(member.credit, 0)
+			} else {
+				next = append(next, member)
+			}
+
+			err := member.processor.submitTasks(member.ctx, s.pool, tasks, s.afterTask)
+			if err != nil {
+				xlog.Error(ctx, "failed to submit tasks to shared worker pool",
+					xfield.Error(err),
+					xfield.String("task_type", member.processor.fetcher.taskType),
+				)
+			}
+		}
+		hungry = next
+	}
+
+	s.backlog.Store(len(hungry) > 0)
+}
+
+// shares splits the free workers among the members: first up to their min workers,
+// then the rest by weight, the workers left after rounding going to the members owed the most.
+func (s *SharedWorkerPool) shares(members []*sharedPoolMember, free int) []int {
+	shares := make([]int, len(members))
+	totalWeight := 0
+	for i, member := range members {
+		guaranteed := min(max(member.minWorkers-member.processor.inFlight.count(), 0), free)
+		shares[i] = guaranteed
+		free -= guaranteed
+		totalWeight += member.weight
+	}
+	if free == 0 {
+		return shares
+	}
+
+	for _, member := range members {
+		member.credit += float64(free*member.weight) / float64(totalWeight)
+	}
+	for ; free > 0; free-- {
+		owed := 0
+		for i, member := range members {
+			if member.credit > members[owed].credit {
+				owed = i
+			}
+		}
+		shares[owed]++
+		members[owed].credit--
+	}
+
+	return shares
+}
+
+func (s *SharedWorkerPool) afterTask() {
+	if s.backlog.Load() {
+		s.wakeup()
+	}
+}
+
+// runWithSharedWorkerPool runs the processor on the shared worker pool until ctx is canceled,
+// then leaves the pool and waits for its tasks to be released.
+func (p *GoqueProcessor) runWithSharedWorkerPool(ctx context.Context) {
+	defer close(p.gracefulStoppedCh)
+
+	var backgroundWG sync.WaitGroup
+	defer backgroundWG.Wait()
+
+	stopLeaseRenewer := p.runBackgroundJobs(ctx, &backgroundWG, p.shared.pool.wakeupCh)
+	defer stopLeaseRenewer()
+
+	<-ctx.Done()
+
+	stopPool := p.shared.pool.detach(p.shared)
+	xlog.Info(ctx, "wait jobs before leaving shared worker pool", xfield.Int("tasks count", p.inFlight.count()))
+	p.inFlight.wait()
+	stopPool()
+}
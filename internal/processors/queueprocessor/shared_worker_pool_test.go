@@ -0,0 +1,134 @@
+package queueprocessor
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ruko1202/xlog"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/ruko1202/goque/internal/entity"
+)
+
+func TestSharedWorkerPool_Shares(t *testing.T) {
+	t.Parallel()
+
+	newMembers := func(t *testing.T, pool *SharedWorkerPool, weights ...int) []*sharedPoolMember {
+		t.Helper()
+		members := make([]*sharedPoolMember, 0, len(weights))
+		for _, weight := range weights {
+			goqueProc, _ := initGoqueProcessorWithMocks(t, "type[shares]", NoopTaskProcessor(),
+				WithSharedWorkerPool(pool, weight, 0),
+			)
+			members = append(members, goqueProc.shared)
+		}
+		return members
+	}
+
+	t.Run("split by weight", func(t *testing.T) {
+		t.Parallel()
+
+		pool := NewSharedWorkerPool(10)
+		members := newMembers(t, pool, 3, 1, 1)
+
+		require.Equal(t, []int{6, 2, 2}, pool.shares(members, 10))
+	})
+
+	t.Run("min workers first", func(t *testing.T) {
+		t.Parallel()
+
+		pool := NewSharedWorkerPool(10)
+		members := newMembers(t, pool, 9, 1)
+		members[1].minWorkers = 3
+
+		require.Equal(t, []int{1, 3}, pool.shares(members, 4))
+	})
+
+	t.Run("low weight is not starved", func(t *testing.T) {
+		t.Parallel()
+
+		pool := NewSharedWorkerPool(10)
+		members := newMembers(t, pool, 9, 1)
+
+		received := make([]int, len(members))
+		for range 20 {
+			for i, share := range pool.shares(members, 1) {
+				received[i] += share
+			}
+		}
+		require.Equal(t, []int{18, 2}, received)
+	})
+}
+
+func TestSharedWorkerPool(t *testing.T) {
+	t.Parallel()
+	ctx := xlog.ContextWithLogger(context.Background(), xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+	pool := NewSharedWorkerPool(3, WithSharedWorkerPoolFetchTick(100*time.Millisecond))
+
+	var (
+		mu             sync.Mutex
+		firstLimits    = make(map[entity.TaskType]int64)
+		processedTasks atomic.Int32
+	)
+	newProcessor := func(taskType entity.TaskType, weight int) *GoqueProcessor {
+		goqueProc, mocks := initGoqueProcessorWithMocks(t, taskType,
+			TaskProcessorFunc(func(_ context.Context, _ *entity.Task) error {
+				processedTasks.Add(1)
+				return nil
+			}),
+			WithSharedWorkerPool(pool, weight, 0),
+		)
+
+		mocks.taskStorage.EXPECT().
+			IsTaskTypePaused(gomock.Any(), taskType).
+			Return(false, nil).
+			AnyTimes()
+		mocks.taskStorage.EXPECT().
+			GetTasksForProcessing(gomock.Any(), taskType, gomock.Any(), gomock.Any(), int64(0)).
+			DoAndReturn(func(_ context.Context, _ entity.TaskType, limit int64, _ entity.TaskLease, _ int64) ([]*entity.Task, error) {
+				mu.Lock()
+				defer mu.Unlock()
+				if _, ok := firstLimits[taskType]; ok {
+					return []*entity.Task{}, nil
+				}
+				firstLimits[taskType] = limit
+				return []*entity.Task{entity.NewTask(taskType, "test payload")}, nil
+			}).
+			AnyTimes()
+		mocks.taskStorage.EXPECT().
+			UpdateTask(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil).
+			AnyTimes()
+
+		return goqueProc
+	}
+
+	heavy := newProcessor("type[shared heavy]", 2)
+	light := newProcessor("type[shared light]", 1)
+
+	_, err := heavy.SetWorkersCount(10)
+	require.Error(t, err)
+
+	require.NoError(t, heavy.Run(ctx))
+	require.NoError(t, light.Run(ctx))
+
+	require.Eventually(t, func() bool {
+		return processedTasks.Load() == 2
+	}, 2*time.Second, 10*time.Millisecond)
+
+	mu.Lock()
+	require.Equal(t, map[entity.TaskType]int64{
+		"type[shared heavy]": 2,
+		"type[shared light]": 1,
+	}, firstLimits)
+	mu.Unlock()
+
+	heavy.Stop()
+	light.Stop()
+}
@@ -0,0 +1,56 @@
+package queueprocessor
+
+import (
+	"context"
+	"time"
+
+	"github.com/ruko1202/xlog"
+	"github.com/ruko1202/xlog/xfield"
+	"github.com/samber/lo"
+
+	"github.com/ruko1202/goque/internal/entity"
+	"github.com/ruko1202/goque/internal/storages/dbentity"
+)
+
+// runTaskCanceler periodically checks whether the in-flight tasks were canceled by a user
+// and cancels their processing context with entity.ErrTaskCancelRequested as the cause.
+func (p *GoqueProcessor) runTaskCanceler(ctx context.Context, period time.Duration) {
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.cancelRequestedTasks(ctx)
+		}
+	}
+}
+
+func (p *GoqueProcessor) cancelRequestedTasks(ctx context.Context) {
+	taskIDs := p.inFlight.ids()
+	if len(taskIDs) == 0 {
+		return
+	}
+
+	ctx, span := xlog.WithOperationSpan(ctx, "queue_processor.cancelRequestedTasks",
+		xfield.Int("in_flight", len(taskIDs)),
+	)
+	defer span.End()
+
+	tasks, err := p.taskStorage.GetTasks(ctx, &dbentity.GetTasksFilter{
+		IDs:    taskIDs,
+		Status: lo.ToPtr(entity.TaskStatusCanceled),
+	}, int64(len(taskIDs)))
+	if err != nil {
+		xlog.Error(ctx, "failed to check canceled tasks", xfield.Error(err))
+		return
+	}
+
+	for _, task := range tasks {
+		if p.inFlight.cancel(task.ID, entity.ErrTaskCancelRequested) {
+			xlog.Info(ctx, "cancel in-flight task", xfield.String("taskID", task.ID.String()))
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package queueprocessor
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ruko1202/xlog"
+	"github.com/ruko1202/xlog/xfield"
+)
+
+// defaultLeaseOwner identifies the processor instance among the other hosts and processes.
+func defaultLeaseOwner() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+	return fmt.Sprintf("%s-%d-%s", hostname, os.Getpid(), uuid.NewString())
+}
+
+// runLeaseRenewer keeps extending the leases of the tasks held by the processor,
+// so the healer doesn't reclaim tasks that are still being worked on.
+func (p *GoqueProcessor) runLeaseRenewer(ctx context.Context) {
+	ticker := time.NewTicker(p.fetcher.lease.Duration / leaseRenewalsPerDuration)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.renewLeases(ctx)
+		}
+	}
+}
+
+func (p *GoqueProcessor) renewLeases(ctx context.Context) {
+	taskIDs := p.inFlight.ids()
+	if len(taskIDs) == 0 {
+		return
+	}
+
+	ctx, span := xlog.WithOperationSpan(ctx, "queue_processor.renewLeases",
+		xfield.Int("in_flight", len(taskIDs)),
+	)
+	defer span.End()
+
+	err := p.taskStorage.RenewTaskLeases(ctx, taskIDs, p.fetcher.lease)
+	if err != nil {
+		xlog.Error(ctx, "failed to renew task leases", xfield.Error(err))
+	}
+}
@@ -0,0 +1,45 @@
+package queueprocessor
+
+import (
+	"context"
+	"time"
+
+	"github.com/ruko1202/xlog"
+	"github.com/ruko1202/xlog/xfield"
+
+	"github.com/ruko1202/goque/internal/storages"
+)
+
+// runTaskListener keeps a subscription to new task notifications and wakes up the fetcher
+// through wakeupCh. The fetcher ticker keeps working regardless, so while the listener is down
+// tasks are still fetched, just with the tick latency.
+func (p *GoqueProcessor) runTaskListener(ctx context.Context, wakeupCh chan<- struct{}) {
+	listener, ok := p.taskStorage.(storages.TaskListener)
+	if !ok {
+		xlog.Info(ctx, "storage doesn't support task notifications, fetching by ticker only")
+		return
+	}
+
+	for {
+		notifications, err := listener.ListenTasks(ctx, p.fetcher.taskType)
+		if err != nil {
+			xlog.Warn(ctx, "failed to listen task notifications, fetching by ticker only",
+				xfield.Error(err),
+				xfield.Duration("reconnect_delay", defaultListenReconnectDelay),
+			)
+		} else {
+			for range notifications {
+				select {
+				case wakeupCh <- struct{}{}:
+				default:
+				}
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(defaultListenReconnectDelay):
+		}
+	}
+}
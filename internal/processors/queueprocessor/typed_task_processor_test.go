@@ -104,6 +104,7 @@ func TestTypedTaskProcessor(t *testing.T) {
 					}),
 			)
 
+			goqueProc.inFlight.hold(task.ID)
 			goqueProc.doProcessTask(ctx, task)
 		})
 	})
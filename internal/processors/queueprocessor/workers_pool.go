@@ -0,0 +1,268 @@
+package queueprocessor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/panjf2000/ants/v2"
+	"github.com/ruko1202/xlog"
+	"github.com/ruko1202/xlog/xfield"
+	"github.com/samber/lo"
+
+	"github.com/ruko1202/goque/internal/entity"
+	"github.com/ruko1202/goque/internal/metrics"
+	"github.com/ruko1202/goque/internal/storages/dbentity"
+	"github.com/ruko1202/goque/internal/utils/xtime"
+)
+
+const (
+	// autoscaleHeadroom is the share of workers kept above the estimated load.
+	autoscaleHeadroom = 1.25
+	// autoscaleLatencyWeight is the weight of the latest processing latency in its moving average.
+	autoscaleLatencyWeight = 0.2
+	// autoscaleBacklogCheckPeriod is how often the tasks waiting in the queue are counted,
+	// the pool is sized to drain them within this period.
+	autoscaleBacklogCheckPeriod = 30 * time.Second
+)
+
+// workersAutoscale is the range the worker pool is tuned within.
+type workersAutoscale struct {
+	minWorkers int
+	maxWorkers int
+}
+
+// workersPool is the worker pool of the processor resizable at runtime, manually or by the autoscaler.
+type workersPool struct {
+	taskType  entity.TaskType
+	tick      time.Duration
+	autoscale *workersAutoscale
+
+	mu   sync.Mutex
+	pool *ants.Pool
+	size int
+	// latency is the moving average of the processing latency.
+	latency time.Duration
+	// backlog is the number of tasks waiting in the queue as of backlogCheckedAt.
+	backlog          int64
+	backlogCheckedAt time.Time
+
+	// busy is the number of workers processing a task.
+	busy atomic.Int64
+}
+
+func newWorkersPool(taskType entity.TaskType, size int, tick time.Duration, autoscale *workersAutoscale) *workersPool {
+	w := &workersPool{
+		taskType:  taskType,
+		tick:      tick,
+		autoscale: autoscale,
+	}
+	w.size = w.clamp(size)
+	return w
+}
+
+// open creates the ants pool of the current size.
+func (w *workersPool) open(opts ...ants.Option) (*ants.Pool, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	pool, err := ants.NewPool(w.size, opts...)
+	if err != nil {
+		return nil, err
+	}
+	w.pool = pool
+	metrics.SetTasksWorkersTotal(w.taskType, w.size)
+
+	return pool, nil
+}
+
+// count returns the current number of workers.
+func (w *workersPool) count() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.size
+}
+
+// resize sets the number of workers, kept within the autoscale range if it is enabled,
+// and returns the number set.
+func (w *workersPool) resize(size int) int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.resizeLocked(size)
+}
+
+func (w *workersPool) resizeLocked(size int) int {
+	w.size = w.clamp(size)
+	if w.pool != nil {
+		w.pool.Tune(w.size)
+	}
+	metrics.SetTasksWorkersTotal(w.taskType, w.size)
+
+	return w.size
+}
+
+func (w *workersPool) clamp(size int) int {
+	if w.autoscale != nil {
+		size = min(max(size, w.autoscale.minWorkers), w.autoscale.maxWorkers)
+	}
+	return max(size, 1)
+}
+
+// track marks a worker busy until the returned func is called and records the processing latency.
+func (w *workersPool) track() func() {
+	w.busy.Add(1)
+	start := xtime.Now()
+
+	return func() {
+		w.busy.Add(-1)
+		if w.autoscale == nil {
+			return
+		}
+
+		latency := xtime.Now().Sub(start)
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		if w.latency == 0 {
+			w.latency = latency
+			return
+		}
+		w.latency += time.Duration(autoscaleLatencyWeight * float64(latency-w.latency))
+	}
+}
+
+// autoscaleTo tunes the pool for the tasks just fetched. No-op unless autoscaling is enabled.
+func (w *workersPool) autoscaleTo(ctx context.Context, fetched int, batchFull bool) {
+	if w.autoscale == nil {
+		return
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	current := w.size
+	desired := w.desiredWorkers(current, int(w.busy.Load()), fetched, batchFull)
+	if desired == current {
+		return
+	}
+
+	w.resizeLocked(desired)
+	xlog.Debug(ctx, "workers autoscaled",
+		xfield.Int("from", current),
+		xfield.Int("to", w.size),
+		xfield.Int("fetched", fetched),
+		xfield.Duration("latency", w.latency),
+	)
+}
+
+// desiredWorkers estimates the number of workers needed:
+//   - the load of the fetched tasks by Little's law: tasks per tick times the processing latency, with headroom;
+//   - at least enough workers to start the fetched tasks right away;
+//   - enough workers to drain the backlog of the queue within autoscaleBacklogCheckPeriod at the processing latency;
+//   - twice as many while fetch batches come full, as more tasks are waiting in the queue.
+//
+// The pool grows at once and shrinks by half of the excess per fetch, so a single quiet tick doesn't drain it.
+func (w *workersPool) desiredWorkers(current, busy, fetched int, batchFull bool) int {
+	desired := busy
+	if w.tick > 0 {
+		load := float64(fetched) * w.latency.Seconds() / w.tick.Seconds()
+		desired = max(desired, int(math.Ceil(load*autoscaleHeadroom)))
+	}
+	if backlog := busy + fetched - current; backlog > 0 {
+		desired = max(desired, current+backlog)
+	}
+	if w.backlog > 0 {
+		drain := float64(w.backlog) * w.latency.Seconds() / autoscaleBacklogCheckPeriod.Seconds()
+		desired = max(desired, busy+int(math.Ceil(drain)))
+	}
+	if batchFull {
+		desired = max(desired, current*2)
+	}
+	if desired < current {
+		desired = current - (current-desired+1)/2
+	}
+
+	return w.clamp(desired)
+}
+
+// backlogCheckDue reports whether the backlog should be counted again and marks it counted.
+// Always false unless autoscaling is enabled.
+func (w *workersPool) backlogCheckDue() bool {
+	if w.autoscale == nil {
+		return false
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	now := xtime.Now()
+	if now.Sub(w.backlogCheckedAt) < autoscaleBacklogCheckPeriod {
+		return false
+	}
+	w.backlogCheckedAt = now
+
+	return true
+}
+
+func (w *workersPool) setBacklog(backlog int64) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.backlog = backlog
+}
+
+// checkBacklog counts the tasks of the type due in the queue, new or to be retried, for the autoscaler
+// at most once per autoscaleBacklogCheckPeriod. The tasks scheduled or retried later can't be fetched yet,
+// so they are not counted. The count is bounded by the fetch timeout, on failure the previous count is kept
+// until the next check.
+func (p *GoqueProcessor) checkBacklog(ctx context.Context) {
+	if !p.workers.backlogCheckDue() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, p.fetcher.timeout)
+	defer cancel()
+
+	waiting := []entity.TaskStatus{entity.TaskStatusNew, entity.TaskStatusError}
+	stats, err := p.taskStorage.Stats(ctx, &dbentity.GetTasksFilter{
+		TaskType:        lo.ToPtr(p.fetcher.taskType),
+		Statuses:        waiting,
+		NextAttemptAtTo: lo.ToPtr(xtime.Now()),
+	})
+	if err != nil {
+		xlog.Warn(ctx, "failed to count the backlog for autoscaling", xfield.Error(err))
+		return
+	}
+
+	backlog := int64(0)
+	for _, typeStats := range stats {
+		for _, status := range waiting {
+			backlog += typeStats.Counts[status]
+		}
+	}
+	p.workers.setBacklog(backlog)
+}
+
+// SetWorkersCount changes the number of workers at runtime. With autoscaling enabled the count is kept
+// within the autoscale range and may be changed again by the autoscaler. Returns the number of workers set.
+// Not supported for a processor running on a shared worker pool.
+func (p *GoqueProcessor) SetWorkersCount(count int) (int, error) {
+	if p.shared != nil {
+		return 0, errors.New("processor runs on a shared worker pool")
+	}
+	if count <= 0 {
+		return 0, fmt.Errorf("workers count must be positive, got %d", count)
+	}
+
+	return p.workers.resize(count), nil
+}
+
+// WorkersCount returns the current number of workers.
+func (p *GoqueProcessor) WorkersCount() int {
+	return p.workers.count()
+}
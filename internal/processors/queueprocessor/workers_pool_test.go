@@ -0,0 +1,167 @@
+package queueprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ruko1202/xlog"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/ruko1202/goque/internal/entity"
+	"github.com/ruko1202/goque/internal/storages/dbentity"
+	"github.com/ruko1202/goque/internal/utils/xtime"
+)
+
+func TestWorkersPool_DesiredWorkers(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		current   int
+		busy      int
+		fetched   int
+		batchFull bool
+		latency   time.Duration
+		backlog   int64
+		expected  int
+	}{
+		"should_keep_size_when_load_fits": {
+			current: 10, busy: 4, fetched: 4, latency: 2 * time.Second,
+			expected: 10,
+		},
+		"should_grow_by_latency_load": {
+			// 10 tasks per 1s tick taking 2s each keep 20 workers busy, plus headroom
+			current: 10, busy: 10, fetched: 10, latency: 2 * time.Second,
+			expected: 25,
+		},
+		"should_grow_to_start_fetched_tasks": {
+			current: 10, busy: 8, fetched: 5,
+			expected: 13,
+		},
+		"should_grow_to_drain_backlog": {
+			// 300 waiting tasks taking 2s each are drained by 20 workers within the 30s check period
+			current: 10, busy: 10, latency: 2 * time.Second, backlog: 300,
+			expected: 30,
+		},
+		"should_double_on_full_batch": {
+			current: 10, fetched: 10, batchFull: true,
+			expected: 20,
+		},
+		"should_shrink_by_half_of_excess": {
+			current: 20, busy: 2,
+			expected: 11,
+		},
+		"should_not_grow_above_max": {
+			current: 40, fetched: 10, batchFull: true,
+			expected: 50,
+		},
+		"should_not_shrink_below_min": {
+			current:  3,
+			expected: 2,
+		},
+	}
+
+	for name, tt := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			w := newWorkersPool("test", tt.current, time.Second, &workersAutoscale{minWorkers: 2, maxWorkers: 50})
+			w.latency = tt.latency
+			w.backlog = tt.backlog
+
+			require.Equal(t, tt.expected, w.desiredWorkers(tt.current, tt.busy, tt.fetched, tt.batchFull))
+		})
+	}
+}
+
+func TestWorkersPool_Resize(t *testing.T) {
+	t.Parallel()
+	ctx := xlog.ContextWithLogger(context.Background(), xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+	t.Run("fixed size", func(t *testing.T) {
+		t.Parallel()
+
+		goqueProc, _ := initGoqueProcessorWithMocks(t, "type[resize fixed]", NoopTaskProcessor(),
+			WithWorkersCount(3),
+		)
+		workerPool, err := goqueProc.workers.open()
+		require.NoError(t, err)
+		defer workerPool.Release()
+
+		count, err := goqueProc.SetWorkersCount(7)
+		require.NoError(t, err)
+		require.Equal(t, 7, count)
+		require.Equal(t, 7, goqueProc.WorkersCount())
+		require.Equal(t, 7, workerPool.Cap())
+
+		_, err = goqueProc.SetWorkersCount(0)
+		require.Error(t, err)
+		require.Equal(t, 7, goqueProc.WorkersCount())
+
+		// fixed pools are not autoscaled
+		goqueProc.workers.autoscaleTo(ctx, 100, true)
+		require.Equal(t, 7, workerPool.Cap())
+	})
+
+	t.Run("autoscale", func(t *testing.T) {
+		t.Parallel()
+
+		goqueProc, _ := initGoqueProcessorWithMocks(t, "type[resize autoscale]", NoopTaskProcessor(),
+			WithWorkersCount(1),
+			WithWorkersAutoscale(2, 8),
+		)
+		require.Equal(t, 2, goqueProc.WorkersCount())
+
+		workerPool, err := goqueProc.workers.open()
+		require.NoError(t, err)
+		defer workerPool.Release()
+
+		count, err := goqueProc.SetWorkersCount(100)
+		require.NoError(t, err)
+		require.Equal(t, 8, count)
+
+		goqueProc.workers.autoscaleTo(ctx, 0, false)
+		require.Equal(t, 4, goqueProc.WorkersCount())
+		require.Equal(t, 4, workerPool.Cap())
+
+		goqueProc.workers.autoscaleTo(ctx, 10, true)
+		require.Equal(t, 8, goqueProc.WorkersCount())
+		require.Equal(t, 8, workerPool.Cap())
+	})
+}
+
+func TestGoqueProcessor_CheckBacklog(t *testing.T) {
+	t.Parallel()
+	ctx := xlog.ContextWithLogger(context.Background(), xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+	taskType := "type[check backlog]"
+	goqueProc, mocks := initGoqueProcessorWithMocks(t, taskType, NoopTaskProcessor(),
+		WithWorkersAutoscale(2, 8),
+	)
+	mocks.taskStorage.EXPECT().
+		Stats(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, filter *dbentity.GetTasksFilter) ([]*entity.TaskStats, error) {
+			_, hasDeadline := ctx.Deadline()
+			require.True(t, hasDeadline)
+			require.Equal(t, lo.ToPtr(taskType), filter.TaskType)
+			require.Equal(t, []entity.TaskStatus{entity.TaskStatusNew, entity.TaskStatusError}, filter.Statuses)
+			// only the due tasks are counted
+			require.NotNil(t, filter.NextAttemptAtTo)
+			require.WithinDuration(t, xtime.Now(), *filter.NextAttemptAtTo, time.Second)
+
+			return []*entity.TaskStats{{
+				TaskType: taskType,
+				Counts:   map[entity.TaskStatus]int64{entity.TaskStatusNew: 40, entity.TaskStatusError: 2},
+			}}, nil
+		})
+
+	goqueProc.checkBacklog(ctx)
+	require.EqualValues(t, 42, goqueProc.workers.backlog)
+
+	// counted at most once per check period
+	goqueProc.checkBacklog(ctx)
+	require.EqualValues(t, 42, goqueProc.workers.backlog)
+}
@@ -0,0 +1,16 @@
+package queuemanager
+
+// AddTasksOpts is a functional option for configuring AddTasksToQueue.
+type AddTasksOpts func(*addTasksOptions)
+
+type addTasksOptions struct {
+	skipDuplicates bool
+}
+
+// WithSkipDuplicates makes AddTasksToQueue skip duplicate tasks instead of failing the whole batch.
+// Skipped tasks are reported via *entity.TasksError with ErrDuplicateTask.
+func WithSkipDuplicates() AddTasksOpts {
+	return func(o *addTasksOptions) {
+		o.skipDuplicates = true
+	}
+}
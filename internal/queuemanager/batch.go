@@ -0,0 +1,57 @@
+package queuemanager
+
+import (
+	"context"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/ruko1202/xlog"
+	"github.com/ruko1202/xlog/xfield"
+
+	"github.com/ruko1202/goque/internal/entity"
+	"github.com/ruko1202/goque/internal/metrics"
+)
+
+// AddBatch adds the tasks to the queue as a batch in a single transaction and returns the batch.
+// The completion task onComplete, if any, waits until all the tasks of the batch reach a terminal
+// status, whether done or failed, and is queued exactly once then. A batch without tasks
+// is completed at once. Returns entity.ErrInvalidBatch if the completion task can't run after the batch,
+// see entity.NewBatch, and *entity.TasksError describing the failed tasks if some of them are invalid;
+// no task is added then. The batch is completed in the transaction finishing its last task, which may be
+// a caller's tx attached to ctx, so the completion task is queued only once that tx commits.
+func (m *TaskQueueManager) AddBatch(ctx context.Context, tasks []*entity.Task, onComplete *entity.Task) (*entity.Batch, error) {
+	ctx, span := xlog.WithOperationSpan(xlog.ContextWithTracer(ctx, m.tracer), "task_queue_manager.AddBatch",
+		xfield.Int("tasks_count", len(tasks)),
+	)
+	defer span.End()
+
+	batch, err := entity.NewBatch(tasks, onComplete)
+	if err != nil {
+		return nil, err
+	}
+
+	batchTasks := slices.Clone(tasks)
+	if onComplete != nil {
+		batchTasks = append(batchTasks, onComplete)
+	}
+	observeTaskPayloads(ctx, batchTasks...)
+
+	if err := m.taskStorage.AddBatch(ctx, batch, tasks, onComplete); err != nil {
+		return nil, err
+	}
+	for _, task := range batchTasks {
+		metrics.IncProcessingTasks(task.Type, task.Status)
+	}
+
+	return batch, nil
+}
+
+// GetBatch retrieves the batch with the counts of its done and failed tasks to report the progress of the batch.
+func (m *TaskQueueManager) GetBatch(ctx context.Context, batchID uuid.UUID) (*entity.Batch, error) {
+	ctx, span := xlog.WithOperationSpan(xlog.ContextWithTracer(ctx, m.tracer), "task_queue_manager.GetBatch",
+		xfield.String("batch_id", batchID.String()),
+	)
+	defer span.End()
+
+	return m.taskStorage.GetBatch(ctx, batchID)
+}
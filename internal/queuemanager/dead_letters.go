@@ -0,0 +1,62 @@
+package queuemanager
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/ruko1202/xlog"
+
+	"github.com/ruko1202/goque/internal/entity"
+	"github.com/ruko1202/goque/internal/storages/dbentity"
+)
+
+// GetDeadTasks retrieves dead-lettered tasks matching the filter, the most recently moved first.
+// A nil filter matches all dead tasks.
+func (m *TaskQueueManager) GetDeadTasks(ctx context.Context, filter *dbentity.DeadTasksFilter, limit int64) ([]*entity.Task, error) {
+	ctx, span := xlog.WithOperationSpan(xlog.ContextWithTracer(ctx, m.tracer), "task_queue_manager.GetDeadTasks")
+	defer span.End()
+
+	return m.taskStorage.GetDeadTasks(ctx, deadTasksFilterOrAll(filter), limit)
+}
+
+// GetDeadTask retrieves a single dead-lettered task by its ID.
+func (m *TaskQueueManager) GetDeadTask(ctx context.Context, taskID uuid.UUID) (*entity.Task, error) {
+	ctx, span := xlog.WithOperationSpan(xlog.ContextWithTracer(ctx, m.tracer), "task_queue_manager.GetDeadTask")
+	defer span.End()
+
+	tasks, err := m.taskStorage.GetDeadTasks(ctx, &dbentity.DeadTasksFilter{IDs: []uuid.UUID{taskID}}, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(tasks) == 0 {
+		return nil, fmt.Errorf("dead task %s: %w", taskID, sql.ErrNoRows)
+	}
+
+	return tasks[0], nil
+}
+
+// RequeueDeadTasks moves dead-lettered tasks matching the filter back to the queue
+// with status new and fresh attempts. A nil filter matches all dead tasks.
+func (m *TaskQueueManager) RequeueDeadTasks(ctx context.Context, filter *dbentity.DeadTasksFilter) ([]*entity.Task, error) {
+	ctx, span := xlog.WithOperationSpan(xlog.ContextWithTracer(ctx, m.tracer), "task_queue_manager.RequeueDeadTasks")
+	defer span.End()
+
+	return m.taskStorage.RequeueDeadTasks(ctx, deadTasksFilterOrAll(filter))
+}
+
+// PurgeDeadTasks deletes dead-lettered tasks matching the filter. A nil filter matches all dead tasks.
+func (m *TaskQueueManager) PurgeDeadTasks(ctx context.Context, filter *dbentity.DeadTasksFilter) ([]*entity.Task, error) {
+	ctx, span := xlog.WithOperationSpan(xlog.ContextWithTracer(ctx, m.tracer), "task_queue_manager.PurgeDeadTasks")
+	defer span.End()
+
+	return m.taskStorage.DeleteDeadTasks(ctx, deadTasksFilterOrAll(filter))
+}
+
+func deadTasksFilterOrAll(filter *dbentity.DeadTasksFilter) *dbentity.DeadTasksFilter {
+	if filter == nil {
+		return &dbentity.DeadTasksFilter{}
+	}
+	return filter
+}
@@ -0,0 +1,95 @@
+package queuemanager
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	"github.com/ruko1202/xlog"
+	"github.com/ruko1202/xlog/xfield"
+
+	"github.com/ruko1202/goque/internal/entity"
+	"github.com/ruko1202/goque/internal/metrics"
+	"github.com/ruko1202/goque/internal/storages/dbentity"
+)
+
+// PauseTaskType stops fetching the tasks of the type by all processors until it is resumed.
+// The tasks already fetched are processed as usual.
+func (m *TaskQueueManager) PauseTaskType(ctx context.Context, taskType entity.TaskType) error {
+	ctx, span := xlog.WithOperationSpan(xlog.ContextWithTracer(ctx, m.tracer), "task_queue_manager.PauseTaskType",
+		xfield.String("task_type", taskType),
+	)
+	defer span.End()
+
+	err := m.taskStorage.PauseTaskType(ctx, taskType)
+	if err != nil {
+		return err
+	}
+
+	metrics.SetTaskTypePaused(taskType, true)
+	xlog.Info(ctx, "task type paused")
+
+	return nil
+}
+
+// ResumeTaskType lets processors fetch the tasks of the paused type again.
+func (m *TaskQueueManager) ResumeTaskType(ctx context.Context, taskType entity.TaskType) error {
+	ctx, span := xlog.WithOperationSpan(xlog.ContextWithTracer(ctx, m.tracer), "task_queue_manager.ResumeTaskType",
+		xfield.String("task_type", taskType),
+	)
+	defer span.End()
+
+	err := m.taskStorage.ResumeTaskType(ctx, taskType)
+	if err != nil {
+		return err
+	}
+
+	metrics.SetTaskTypePaused(taskType, false)
+	xlog.Info(ctx, "task type resumed")
+
+	return nil
+}
+
+// IsPaused reports whether the task type is paused.
+func (m *TaskQueueManager) IsPaused(ctx context.Context, taskType entity.TaskType) (bool, error) {
+	ctx, span := xlog.WithOperationSpan(xlog.ContextWithTracer(ctx, m.tracer), "task_queue_manager.IsPaused",
+		xfield.String("task_type", taskType),
+	)
+	defer span.End()
+
+	return m.taskStorage.IsTaskTypePaused(ctx, taskType)
+}
+
+// markPausedTaskTypes flags the stats of the paused types and adds empty stats for the paused types
+// without tasks, unless the filter is for another type.
+func markPausedTaskTypes(stats []*entity.TaskStats, pausedTypes []entity.TaskType, filter *dbentity.GetTasksFilter) []*entity.TaskStats {
+	if len(pausedTypes) == 0 {
+		return stats
+	}
+
+	statsByType := make(map[entity.TaskType]*entity.TaskStats, len(stats))
+	for _, typeStats := range stats {
+		statsByType[typeStats.TaskType] = typeStats
+	}
+
+	for _, taskType := range pausedTypes {
+		if typeStats, ok := statsByType[taskType]; ok {
+			typeStats.Paused = true
+			continue
+		}
+		if filter != nil && filter.TaskType != nil && *filter.TaskType != taskType {
+			continue
+		}
+		stats = append(stats, &entity.TaskStats{
+			TaskType: taskType,
+			Counts:   make(map[entity.TaskStatus]int64),
+			Paused:   true,
+		})
+	}
+
+	slices.SortFunc(stats, func(a, b *entity.TaskStats) int {
+		return cmp.Compare(a.TaskType, b.TaskType)
+	})
+
+	return stats
+}
@@ -5,8 +5,11 @@ package queuemanager
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"sync"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/ruko1202/xlog"
@@ -19,11 +22,16 @@ import (
 	"github.com/ruko1202/goque/internal/metrics"
 	"github.com/ruko1202/goque/internal/storages"
 	"github.com/ruko1202/goque/internal/storages/dbentity"
+	"github.com/ruko1202/goque/internal/utils/xtime"
 	"github.com/ruko1202/goque/internal/utils/xtracer"
 )
 
 const (
 	bigPayloadSize = 100 * 1024 // 100KB
+
+	// maxStateConflictRetries bounds the attempts of a read-modify-write of a task
+	// that keeps losing the race to concurrent state transitions.
+	maxStateConflictRetries = 3
 )
 
 // TaskQueueManager provides a high-level API for managing tasks in the queue.
@@ -38,13 +46,18 @@ type TaskQueueManager struct {
 	// *sqlx.DB ("sql: database is closed") or leave spans unended.
 	// Violates critical rule #8 (no goroutine leaks) if dropped.
 	asyncWG sync.WaitGroup
+
+	taskWaiters          *taskWaiters
+	waitTaskPollInterval time.Duration
 }
 
 // NewTaskQueueManager creates a new TaskQueueManager instance with the specified task storage.
 func NewTaskQueueManager(taskStorage storages.Task) *TaskQueueManager {
 	return &TaskQueueManager{
-		taskStorage: taskStorage,
-		tracer:      xtracer.GetTracer(),
+		taskStorage:          taskStorage,
+		tracer:               xtracer.GetTracer(),
+		taskWaiters:          newTaskWaiters(taskStorage),
+		waitTaskPollInterval: defaultWaitTaskPollInterval,
 	}
 }
 
@@ -88,15 +101,8 @@ func (m *TaskQueueManager) AddTaskToQueue(ctx context.Context, task *entity.Task
 	ctx, span := xlog.WithOperationSpan(xlog.ContextWithTracer(ctx, m.tracer), "task_queue_manager.AddTaskToQueue")
 	defer span.End()
 
-	metrics.SetTaskPayloadSize(task.Type, len(task.Payload))
-	metrics.IncProcessingTasks(task.Type, entity.TaskStatusNew)
-
-	if len(task.Payload) > bigPayloadSize {
-		xlog.Warn(ctx, "big payload size detected - may cause performance problems",
-			xfield.Int("payload_size", len(task.Payload)),
-			xfield.String("task_id", task.ID.String()),
-			xfield.String("task_type", task.Type))
-	}
+	observeTaskPayloads(ctx, task)
+	metrics.IncProcessingTasks(task.Type, task.Status)
 
 	err := m.taskStorage.AddTask(ctx, task)
 	if err != nil {
@@ -106,6 +112,107 @@ func (m *TaskQueueManager) AddTaskToQueue(ctx context.Context, task *entity.Task
 	return nil
 }
 
+// AddTaskOrGet adds a task to the queue unless a task with the same type and external ID exists.
+// Returns the added task and true, or the already existing task and false.
+func (m *TaskQueueManager) AddTaskOrGet(ctx context.Context, task *entity.Task) (*entity.Task, bool, error) {
+	ctx, span := xlog.WithOperationSpan(xlog.ContextWithTracer(ctx, m.tracer), "task_queue_manager.AddTaskOrGet")
+	defer span.End()
+
+	observeTaskPayloads(ctx, task)
+
+	storedTask, created, err := m.taskStorage.AddTaskOrGet(ctx, task)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if created {
+		metrics.IncProcessingTasks(task.Type, task.Status)
+	}
+
+	return storedTask, created, nil
+}
+
+// AddTasksToQueue adds tasks to the queue in a single transaction.
+// Returns *entity.TasksError describing the failed tasks if some of them are invalid
+// or, with WithSkipDuplicates, were skipped as duplicates.
+func (m *TaskQueueManager) AddTasksToQueue(ctx context.Context, tasks []*entity.Task, opts ...AddTasksOpts) error {
+	ctx, span := xlog.WithOperationSpan(xlog.ContextWithTracer(ctx, m.tracer), "task_queue_manager.AddTasksToQueue",
+		xfield.Int("tasks_count", len(tasks)),
+	)
+	defer span.End()
+
+	options := &addTasksOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	observeTaskPayloads(ctx, tasks...)
+
+	err := m.taskStorage.AddTasks(ctx, tasks, options.skipDuplicates)
+
+	var tasksErr *entity.TasksError
+	if err != nil && !errors.As(err, &tasksErr) {
+		return err
+	}
+	for _, task := range tasks {
+		if tasksErr != nil && tasksErr.Errors[task.ID] != nil {
+			continue
+		}
+		metrics.IncProcessingTasks(task.Type, task.Status)
+	}
+
+	return err
+}
+
+// observeTaskPayloads records the payload sizes of the tasks being added and warns about the big ones.
+func observeTaskPayloads(ctx context.Context, tasks ...*entity.Task) {
+	for _, task := range tasks {
+		metrics.SetTaskPayloadSize(task.Type, len(task.Payload))
+
+		if len(task.Payload) > bigPayloadSize {
+			xlog.Warn(ctx, "big payload size detected - may cause performance problems",
+				xfield.Int("payload_size", len(task.Payload)),
+				xfield.String("task_id", task.ID.String()),
+				xfield.String("task_type", task.Type))
+		}
+	}
+}
+
+// AddTaskToQueueAt adds a task to the queue to be processed not earlier than runAt.
+// A task scheduled in the future is inserted as new and is not fetched before runAt,
+// a task with dependencies keeps waiting for them.
+func (m *TaskQueueManager) AddTaskToQueueAt(ctx context.Context, task *entity.Task, runAt time.Time) error {
+	ctx, span := xlog.WithOperationSpan(xlog.ContextWithTracer(ctx, m.tracer), "task_queue_manager.AddTaskToQueueAt",
+		xfield.Time("run_at", runAt),
+	)
+	defer span.End()
+
+	if runAt.IsZero() {
+		return fmt.Errorf("%w: run time is not set", entity.ErrInvalidSchedule)
+	}
+	if !slices.Contains([]entity.TaskStatus{entity.TaskStatusNew, entity.TaskStatusWaiting}, task.Status) {
+		return fmt.Errorf("%w: task in status %q can't be scheduled", entity.ErrInvalidSchedule, task.Status)
+	}
+
+	task.ScheduleAt(runAt)
+
+	return m.AddTaskToQueue(ctx, task)
+}
+
+// AddTaskToQueueAfter adds a task to the queue to be processed after the delay passes.
+func (m *TaskQueueManager) AddTaskToQueueAfter(ctx context.Context, task *entity.Task, delay time.Duration) error {
+	ctx, span := xlog.WithOperationSpan(xlog.ContextWithTracer(ctx, m.tracer), "task_queue_manager.AddTaskToQueueAfter",
+		xfield.Duration("delay", delay),
+	)
+	defer span.End()
+
+	if delay < 0 {
+		return fmt.Errorf("%w: negative delay %s", entity.ErrInvalidSchedule, delay)
+	}
+
+	return m.AddTaskToQueueAt(ctx, task, xtime.Now().Add(delay))
+}
+
 // GetTask retrieves a single task by its ID from the queue.
 func (m *TaskQueueManager) GetTask(ctx context.Context, taskID uuid.UUID) (*entity.Task, error) {
 	ctx, span := xlog.WithOperationSpan(xlog.ContextWithTracer(ctx, m.tracer), "task_queue_manager.GetTask")
@@ -123,33 +230,100 @@ func (m *TaskQueueManager) GetTasks(ctx context.Context, filter *dbentity.GetTas
 	return m.taskStorage.GetTasks(ctx, filter, limit)
 }
 
+// Stats returns per-type task counts by status, the queue lag and the oldest processing task.
+// The filter narrows the tasks taken into account; nil means all tasks.
+func (m *TaskQueueManager) Stats(ctx context.Context, filter *dbentity.GetTasksFilter) ([]*entity.TaskStats, error) {
+	ctx, span := xlog.WithOperationSpan(xlog.ContextWithTracer(ctx, m.tracer), "task_queue_manager.Stats")
+	defer span.End()
+
+	stats, err := m.taskStorage.Stats(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	pausedTypes, err := m.taskStorage.GetPausedTaskTypes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get paused task types: %w", err)
+	}
+
+	return markPausedTaskTypes(stats, pausedTypes, filter), nil
+}
+
 // ResetAttempts resets the retry attempts counter for a task and sets its status back to new.
-// This allows a failed task to be retried from the beginning.
+// This allows a failed task to be retried from the beginning. A reset racing a concurrent
+// state transition of the task is retried on its latest state.
 func (m *TaskQueueManager) ResetAttempts(ctx context.Context, taskID uuid.UUID) error {
 	ctx, span := xlog.WithOperationSpan(xlog.ContextWithTracer(ctx, m.tracer), "task_queue_manager.ResetAttempts")
 	defer span.End()
 
-	return m.taskStorage.ResetAttempts(ctx, taskID)
+	return retryOnStateConflict(func() error {
+		return m.taskStorage.ResetAttempts(ctx, taskID)
+	})
 }
 
 // CancelTask marks a non-terminal task as canceled.
+// If the task changes concurrently, the cancellation is decided again on its latest state.
 func (m *TaskQueueManager) CancelTask(ctx context.Context, taskID uuid.UUID) error {
 	ctx, span := xlog.WithOperationSpan(xlog.ContextWithTracer(ctx, m.tracer), "task_queue_manager.CancelTask")
 	defer span.End()
 
-	task, err := m.GetTask(ctx, taskID)
-	if err != nil {
-		return fmt.Errorf("get task for cancellation: %w", err)
-	}
+	return retryOnStateConflict(func() error {
+		task, err := m.GetTask(ctx, taskID)
+		if err != nil {
+			return fmt.Errorf("get task for cancellation: %w", err)
+		}
+
+		if task.IsInTerminalState() {
+			return nil
+		}
+
+		task.Status = entity.TaskStatusCanceled
+		if err := m.taskStorage.UpdateTask(ctx, taskID, task); err != nil {
+			return fmt.Errorf("cancel task: %w", err)
+		}
 
-	if task.IsInTerminalState() {
 		return nil
-	}
+	})
+}
+
+// CancelTasks marks non-terminal tasks matching the filter as canceled and returns their number.
+// The filter must have at least one criterion.
+func (m *TaskQueueManager) CancelTasks(ctx context.Context, filter *dbentity.GetTasksFilter) (int64, error) {
+	ctx, span := xlog.WithOperationSpan(xlog.ContextWithTracer(ctx, m.tracer), "task_queue_manager.CancelTasks")
+	defer span.End()
+
+	return m.taskStorage.CancelTasks(ctx, filter)
+}
 
-	task.Status = entity.TaskStatusCanceled
-	if err := m.taskStorage.UpdateTask(ctx, taskID, task); err != nil {
-		return fmt.Errorf("cancel task: %w", err)
+// RetryTasks resets the attempts of tasks matching the filter and sets them back to status new,
+// skipping tasks in flight. Returns the number of retried tasks.
+// The filter must have at least one criterion.
+func (m *TaskQueueManager) RetryTasks(ctx context.Context, filter *dbentity.GetTasksFilter) (int64, error) {
+	ctx, span := xlog.WithOperationSpan(xlog.ContextWithTracer(ctx, m.tracer), "task_queue_manager.RetryTasks")
+	defer span.End()
+
+	return m.taskStorage.RetryTasks(ctx, filter)
+}
+
+// DeleteTasks removes tasks matching the filter and returns their number.
+// The filter must have at least one criterion.
+func (m *TaskQueueManager) DeleteTasks(ctx context.Context, filter *dbentity.GetTasksFilter) (int64, error) {
+	ctx, span := xlog.WithOperationSpan(xlog.ContextWithTracer(ctx, m.tracer), "task_queue_manager.DeleteTasks")
+	defer span.End()
+
+	return m.taskStorage.DeleteTasksByFilter(ctx, filter)
+}
+
+// retryOnStateConflict runs the read-modify-write of a task again while it loses the race
+// to a concurrent state transition, so the outcome depends on the latest task state.
+func retryOnStateConflict(fn func() error) error {
+	var err error
+	for range maxStateConflictRetries {
+		err = fn()
+		if !errors.Is(err, entity.ErrTaskStateConflict) {
+			return err
+		}
 	}
 
-	return nil
+	return err
 }
@@ -2,16 +2,20 @@ package queuemanager
 
 import (
 	"context"
+	"database/sql"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
 	"github.com/ruko1202/goque/internal/entity"
 	"github.com/ruko1202/goque/internal/pkg/generated/mocks/mock_storages"
+	"github.com/ruko1202/goque/internal/storages/dbentity"
 )
 
 func TestTaskQueueManager_CancelTask(t *testing.T) {
@@ -66,6 +70,72 @@ func TestTaskQueueManager_CancelTask(t *testing.T) {
 				require.NoError(t, err)
 			},
 		},
+		"should_not_update_task_when_it_is_done_concurrently": {
+			task: &entity.Task{ID: uuid.New(), Status: entity.TaskStatusProcessing},
+			prepare: func(storage *mock_storages.MockTask, task *entity.Task) {
+				gomock.InOrder(
+					storage.EXPECT().
+						GetTask(gomock.Any(), task.ID).
+						Return(&entity.Task{ID: task.ID, Status: entity.TaskStatusProcessing}, nil),
+					storage.EXPECT().
+						UpdateTask(gomock.Any(), task.ID, gomock.Any()).
+						Return(entity.ErrTaskStateConflict),
+					storage.EXPECT().
+						GetTask(gomock.Any(), task.ID).
+						Return(&entity.Task{ID: task.ID, Status: entity.TaskStatusDone, Version: 1}, nil),
+				)
+			},
+			assertFunc: func(t *testing.T, err error) {
+				t.Helper()
+				require.NoError(t, err)
+			},
+		},
+		"should_cancel_task_on_its_latest_state_after_conflict": {
+			task: &entity.Task{ID: uuid.New(), Status: entity.TaskStatusPending},
+			prepare: func(storage *mock_storages.MockTask, task *entity.Task) {
+				gomock.InOrder(
+					storage.EXPECT().
+						GetTask(gomock.Any(), task.ID).
+						Return(&entity.Task{ID: task.ID, Status: entity.TaskStatusPending}, nil),
+					storage.EXPECT().
+						UpdateTask(gomock.Any(), task.ID, gomock.Any()).
+						Return(entity.ErrTaskStateConflict),
+					storage.EXPECT().
+						GetTask(gomock.Any(), task.ID).
+						Return(&entity.Task{ID: task.ID, Status: entity.TaskStatusProcessing, Version: 1}, nil),
+					storage.EXPECT().
+						UpdateTask(gomock.Any(), task.ID, gomock.Any()).
+						DoAndReturn(func(_ context.Context, _ uuid.UUID, task *entity.Task) error {
+							assert.Equal(t, entity.TaskStatusCanceled, task.Status)
+							assert.Equal(t, int64(1), task.Version)
+							return nil
+						}),
+				)
+			},
+			assertFunc: func(t *testing.T, err error) {
+				t.Helper()
+				require.NoError(t, err)
+			},
+		},
+		"should_return_conflict_when_retries_are_exhausted": {
+			task: &entity.Task{ID: uuid.New(), Status: entity.TaskStatusNew},
+			prepare: func(storage *mock_storages.MockTask, task *entity.Task) {
+				storage.EXPECT().
+					GetTask(gomock.Any(), task.ID).
+					DoAndReturn(func(_ context.Context, taskID uuid.UUID) (*entity.Task, error) {
+						return &entity.Task{ID: taskID, Status: entity.TaskStatusNew}, nil
+					}).
+					Times(maxStateConflictRetries)
+				storage.EXPECT().
+					UpdateTask(gomock.Any(), task.ID, gomock.Any()).
+					Return(entity.ErrTaskStateConflict).
+					Times(maxStateConflictRetries)
+			},
+			assertFunc: func(t *testing.T, err error) {
+				t.Helper()
+				require.ErrorIs(t, err, entity.ErrTaskStateConflict)
+			},
+		},
 	}
 
 	for name, tt := range testCases {
@@ -84,12 +154,522 @@ func TestTaskQueueManager_CancelTask(t *testing.T) {
 	}
 }
 
+func TestTaskQueueManager_AddTaskToQueueAt(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		task       *entity.Task
+		runAt      time.Time
+		prepare    func(storage *mock_storages.MockTask)
+		assertFunc func(t *testing.T, task *entity.Task, err error)
+	}{
+		"should_return_error_when_run_at_is_zero": {
+			task:    entity.NewTask("test", entity.NoTaskPayload),
+			prepare: func(_ *mock_storages.MockTask) {},
+			assertFunc: func(t *testing.T, _ *entity.Task, err error) {
+				t.Helper()
+				require.ErrorIs(t, err, entity.ErrInvalidSchedule)
+			},
+		},
+		"should_return_error_when_task_is_fetched": {
+			task:  &entity.Task{ID: uuid.New(), Status: entity.TaskStatusPending},
+			runAt: time.Now().Add(time.Hour),
+			prepare: func(_ *mock_storages.MockTask) {
+			},
+			assertFunc: func(t *testing.T, _ *entity.Task, err error) {
+				t.Helper()
+				require.ErrorIs(t, err, entity.ErrInvalidSchedule)
+			},
+		},
+		"should_return_error_when_task_is_not_new": {
+			task:    &entity.Task{ID: uuid.New(), Status: entity.TaskStatusDone},
+			runAt:   time.Now().Add(time.Hour),
+			prepare: func(_ *mock_storages.MockTask) {},
+			assertFunc: func(t *testing.T, _ *entity.Task, err error) {
+				t.Helper()
+				require.ErrorIs(t, err, entity.ErrInvalidSchedule)
+			},
+		},
+		"should_add_new_task_when_run_at_is_in_future": {
+			task:  entity.NewTask("test", entity.NoTaskPayload),
+			runAt: time.Now().Add(time.Hour),
+			prepare: func(storage *mock_storages.MockTask) {
+				storage.EXPECT().
+					AddTask(gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			assertFunc: func(t *testing.T, task *entity.Task, err error) {
+				t.Helper()
+				require.NoError(t, err)
+				require.Equal(t, entity.TaskStatusNew, task.Status)
+			},
+		},
+		"should_add_new_task_when_run_at_is_in_past": {
+			task:  entity.NewTask("test", entity.NoTaskPayload),
+			runAt: time.Now().Add(-time.Hour),
+			prepare: func(storage *mock_storages.MockTask) {
+				storage.EXPECT().
+					AddTask(gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			assertFunc: func(t *testing.T, task *entity.Task, err error) {
+				t.Helper()
+				require.NoError(t, err)
+				require.Equal(t, entity.TaskStatusNew, task.Status)
+			},
+		},
+		"should_add_waiting_task_when_it_has_dependencies": {
+			task:  entity.NewTask("test", entity.NoTaskPayload, entity.WithTaskDependsOn(uuid.New())),
+			runAt: time.Now().Add(time.Hour),
+			prepare: func(storage *mock_storages.MockTask) {
+				storage.EXPECT().
+					AddTask(gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			assertFunc: func(t *testing.T, task *entity.Task, err error) {
+				t.Helper()
+				require.NoError(t, err)
+				require.Equal(t, entity.TaskStatusWaiting, task.Status)
+			},
+		},
+	}
+
+	for name, tt := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			storage := mock_storages.NewMockTask(ctrl)
+			tt.prepare(storage)
+
+			manager := NewTaskQueueManager(storage)
+
+			err := manager.AddTaskToQueueAt(context.Background(), tt.task, tt.runAt)
+			tt.assertFunc(t, tt.task, err)
+			if err == nil {
+				require.Equal(t, tt.runAt, tt.task.NextAttemptAt)
+			}
+		})
+	}
+}
+
+func TestTaskQueueManager_AddTaskToQueueAfter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should_return_error_when_delay_is_negative", func(t *testing.T) {
+		t.Parallel()
+
+		manager := NewTaskQueueManager(mock_storages.NewMockTask(gomock.NewController(t)))
+
+		err := manager.AddTaskToQueueAfter(context.Background(), entity.NewTask("test", entity.NoTaskPayload), -time.Second)
+		require.ErrorIs(t, err, entity.ErrInvalidSchedule)
+	})
+
+	t.Run("should_schedule_task_after_delay", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		storage := mock_storages.NewMockTask(ctrl)
+		storage.EXPECT().
+			AddTask(gomock.Any(), gomock.Any()).
+			Return(nil)
+
+		manager := NewTaskQueueManager(storage)
+
+		task := entity.NewTask("test", entity.NoTaskPayload)
+		err := manager.AddTaskToQueueAfter(context.Background(), task, time.Hour)
+		require.NoError(t, err)
+		require.Equal(t, entity.TaskStatusNew, task.Status)
+		require.WithinDuration(t, time.Now().Add(time.Hour), task.NextAttemptAt, time.Minute)
+	})
+}
+
 // TestTaskQueueManager_WaitAsyncEnqueues_Drains verifies that
 // WaitAsyncEnqueues blocks until every in-flight AsyncAddTaskToQueue
 // goroutine has completed. Critical for graceful shutdown — without
 // it Goque.Stop() returns while async writes are still in flight,
 // leading to "sql: database is closed" errors when the caller closes
 // the pool.
+func TestTaskQueueManager_AddTaskOrGet(t *testing.T) {
+	t.Parallel()
+
+	existing := entity.NewTaskWithExternalID("test", `{}`, "external-id")
+
+	testCases := map[string]struct {
+		prepare    func(storage *mock_storages.MockTask, task *entity.Task)
+		assertFunc func(t *testing.T, task, stored *entity.Task, created bool, err error)
+	}{
+		"should_return_created_task": {
+			prepare: func(storage *mock_storages.MockTask, task *entity.Task) {
+				storage.EXPECT().
+					AddTaskOrGet(gomock.Any(), task).
+					Return(task, true, nil)
+			},
+			assertFunc: func(t *testing.T, task, stored *entity.Task, created bool, err error) {
+				t.Helper()
+				require.NoError(t, err)
+				require.True(t, created)
+				require.Same(t, task, stored)
+			},
+		},
+		"should_return_existing_task": {
+			prepare: func(storage *mock_storages.MockTask, task *entity.Task) {
+				storage.EXPECT().
+					AddTaskOrGet(gomock.Any(), task).
+					Return(existing, false, nil)
+			},
+			assertFunc: func(t *testing.T, _, stored *entity.Task, created bool, err error) {
+				t.Helper()
+				require.NoError(t, err)
+				require.False(t, created)
+				require.Same(t, existing, stored)
+			},
+		},
+		"should_return_storage_error": {
+			prepare: func(storage *mock_storages.MockTask, task *entity.Task) {
+				storage.EXPECT().
+					AddTaskOrGet(gomock.Any(), task).
+					Return(nil, false, assert.AnError)
+			},
+			assertFunc: func(t *testing.T, _, stored *entity.Task, created bool, err error) {
+				t.Helper()
+				require.ErrorIs(t, err, assert.AnError)
+				require.False(t, created)
+				require.Nil(t, stored)
+			},
+		},
+	}
+
+	for name, tt := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			task := entity.NewTaskWithExternalID("test", `{}`, "external-id")
+
+			ctrl := gomock.NewController(t)
+			storage := mock_storages.NewMockTask(ctrl)
+			tt.prepare(storage, task)
+
+			manager := NewTaskQueueManager(storage)
+
+			stored, created, err := manager.AddTaskOrGet(context.Background(), task)
+			tt.assertFunc(t, task, stored, created, err)
+		})
+	}
+}
+
+func TestTaskQueueManager_AddTasksToQueue(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		opts       []AddTasksOpts
+		prepare    func(storage *mock_storages.MockTask, tasks []*entity.Task)
+		assertFunc func(t *testing.T, tasks []*entity.Task, err error)
+	}{
+		"should_add_tasks": {
+			prepare: func(storage *mock_storages.MockTask, tasks []*entity.Task) {
+				storage.EXPECT().
+					AddTasks(gomock.Any(), tasks, false).
+					Return(nil)
+			},
+			assertFunc: func(t *testing.T, _ []*entity.Task, err error) {
+				t.Helper()
+				require.NoError(t, err)
+			},
+		},
+		"should_pass_skip_duplicates": {
+			opts: []AddTasksOpts{WithSkipDuplicates()},
+			prepare: func(storage *mock_storages.MockTask, tasks []*entity.Task) {
+				tasksErr := entity.NewTasksError()
+				tasksErr.Add(tasks[0].ID, entity.ErrDuplicateTask)
+
+				storage.EXPECT().
+					AddTasks(gomock.Any(), tasks, true).
+					Return(tasksErr)
+			},
+			assertFunc: func(t *testing.T, tasks []*entity.Task, err error) {
+				t.Helper()
+				require.ErrorIs(t, err, entity.ErrDuplicateTask)
+
+				var tasksErr *entity.TasksError
+				require.ErrorAs(t, err, &tasksErr)
+				require.Len(t, tasksErr.Errors, 1)
+				require.Contains(t, tasksErr.Errors, tasks[0].ID)
+			},
+		},
+		"should_return_storage_error": {
+			prepare: func(storage *mock_storages.MockTask, tasks []*entity.Task) {
+				storage.EXPECT().
+					AddTasks(gomock.Any(), tasks, false).
+					Return(assert.AnError)
+			},
+			assertFunc: func(t *testing.T, _ []*entity.Task, err error) {
+				t.Helper()
+				require.ErrorIs(t, err, assert.AnError)
+			},
+		},
+	}
+
+	for name, tt := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			tasks := []*entity.Task{
+				entity.NewTask("test", `{}`),
+				entity.NewTask("test", `{}`),
+			}
+
+			ctrl := gomock.NewController(t)
+			storage := mock_storages.NewMockTask(ctrl)
+			tt.prepare(storage, tasks)
+
+			manager := NewTaskQueueManager(storage)
+
+			err := manager.AddTasksToQueue(context.Background(), tasks, tt.opts...)
+			tt.assertFunc(t, tasks, err)
+		})
+	}
+}
+
+func TestTaskQueueManager_AddWorkflowToQueue(t *testing.T) {
+	t.Parallel()
+
+	workflow := entity.NewWorkflow()
+	extract := workflow.Add(entity.NewTask("extract", `{}`))
+	workflow.Add(entity.NewTask("load", `{}`), extract)
+
+	ctrl := gomock.NewController(t)
+	storage := mock_storages.NewMockTask(ctrl)
+	storage.EXPECT().
+		AddTasks(gomock.Any(), workflow.Tasks(), false).
+		Return(nil)
+
+	manager := NewTaskQueueManager(storage)
+
+	err := manager.AddWorkflowToQueue(context.Background(), workflow)
+	require.NoError(t, err)
+}
+
+func TestTaskQueueManager_AddBatch(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		tasks      []*entity.Task
+		onComplete *entity.Task
+		prepare    func(storage *mock_storages.MockTask)
+		assertFunc func(t *testing.T, tasks []*entity.Task, onComplete *entity.Task, batch *entity.Batch, err error)
+	}{
+		"should_add_batch": {
+			tasks:      []*entity.Task{entity.NewTask("resize", `{}`), entity.NewTask("resize", `{}`)},
+			onComplete: entity.NewTask("notify", `{}`),
+			prepare: func(storage *mock_storages.MockTask) {
+				storage.EXPECT().
+					AddBatch(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			assertFunc: func(t *testing.T, tasks []*entity.Task, onComplete *entity.Task, batch *entity.Batch, err error) {
+				t.Helper()
+				require.NoError(t, err)
+				require.EqualValues(t, len(tasks), batch.Total)
+				require.Equal(t, &onComplete.ID, batch.CompletionTaskID)
+				require.Equal(t, entity.TaskStatusWaiting, onComplete.Status)
+				for _, task := range tasks {
+					require.Equal(t, &batch.ID, task.BatchID)
+				}
+			},
+		},
+		"should_return_error_when_completion_task_has_dependencies": {
+			tasks:      []*entity.Task{entity.NewTask("resize", `{}`)},
+			onComplete: entity.NewTask("notify", `{}`, entity.WithTaskDependsOn(uuid.New())),
+			prepare:    func(_ *mock_storages.MockTask) {},
+			assertFunc: func(t *testing.T, _ []*entity.Task, _ *entity.Task, batch *entity.Batch, err error) {
+				t.Helper()
+				require.ErrorIs(t, err, entity.ErrInvalidBatch)
+				require.Nil(t, batch)
+			},
+		},
+		"should_return_storage_error": {
+			tasks: []*entity.Task{entity.NewTask("resize", `{}`)},
+			prepare: func(storage *mock_storages.MockTask) {
+				storage.EXPECT().
+					AddBatch(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(assert.AnError)
+			},
+			assertFunc: func(t *testing.T, _ []*entity.Task, _ *entity.Task, batch *entity.Batch, err error) {
+				t.Helper()
+				require.ErrorIs(t, err, assert.AnError)
+				require.Nil(t, batch)
+			},
+		},
+	}
+
+	for name, tt := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			storage := mock_storages.NewMockTask(ctrl)
+			tt.prepare(storage)
+
+			manager := NewTaskQueueManager(storage)
+
+			batch, err := manager.AddBatch(context.Background(), tt.tasks, tt.onComplete)
+			tt.assertFunc(t, tt.tasks, tt.onComplete, batch, err)
+		})
+	}
+}
+
+func TestTaskQueueManager_GetDeadTask(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		task       *entity.Task
+		prepare    func(storage *mock_storages.MockTask, task *entity.Task)
+		assertFunc func(t *testing.T, task *entity.Task, dbTask *entity.Task, err error)
+	}{
+		"should_return_dead_task": {
+			task: &entity.Task{ID: uuid.New(), Status: entity.TaskStatusAttemptsLeft},
+			prepare: func(storage *mock_storages.MockTask, task *entity.Task) {
+				storage.EXPECT().
+					GetDeadTasks(gomock.Any(), &dbentity.DeadTasksFilter{IDs: []uuid.UUID{task.ID}}, int64(1)).
+					Return([]*entity.Task{task}, nil)
+			},
+			assertFunc: func(t *testing.T, task *entity.Task, dbTask *entity.Task, err error) {
+				t.Helper()
+				require.NoError(t, err)
+				require.Equal(t, task, dbTask)
+			},
+		},
+		"should_return_no_rows_when_dead_task_not_found": {
+			task: &entity.Task{ID: uuid.New()},
+			prepare: func(storage *mock_storages.MockTask, _ *entity.Task) {
+				storage.EXPECT().
+					GetDeadTasks(gomock.Any(), gomock.Any(), int64(1)).
+					Return([]*entity.Task{}, nil)
+			},
+			assertFunc: func(t *testing.T, _ *entity.Task, dbTask *entity.Task, err error) {
+				t.Helper()
+				require.ErrorIs(t, err, sql.ErrNoRows)
+				require.Nil(t, dbTask)
+			},
+		},
+		"should_return_storage_error": {
+			task: &entity.Task{ID: uuid.New()},
+			prepare: func(storage *mock_storages.MockTask, _ *entity.Task) {
+				storage.EXPECT().
+					GetDeadTasks(gomock.Any(), gomock.Any(), int64(1)).
+					Return(nil, assert.AnError)
+			},
+			assertFunc: func(t *testing.T, _ *entity.Task, _ *entity.Task, err error) {
+				t.Helper()
+				require.ErrorIs(t, err, assert.AnError)
+			},
+		},
+	}
+
+	for name, tt := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			storage := mock_storages.NewMockTask(ctrl)
+			tt.prepare(storage, tt.task)
+
+			manager := NewTaskQueueManager(storage)
+
+			dbTask, err := manager.GetDeadTask(context.Background(), tt.task.ID)
+			tt.assertFunc(t, tt.task, dbTask, err)
+		})
+	}
+}
+
+func TestTaskQueueManager_Stats(t *testing.T) {
+	t.Parallel()
+
+	statsOf := func(taskType entity.TaskType, paused bool, count int64) *entity.TaskStats {
+		stats := &entity.TaskStats{
+			TaskType: taskType,
+			Counts:   make(map[entity.TaskStatus]int64),
+			Paused:   paused,
+		}
+		if count > 0 {
+			stats.Counts[entity.TaskStatusNew] = count
+		}
+		return stats
+	}
+
+	testCases := map[string]struct {
+		filter     *dbentity.GetTasksFilter
+		prepare    func(storage *mock_storages.MockTask)
+		assertFunc func(t *testing.T, stats []*entity.TaskStats, err error)
+	}{
+		"should_mark_paused_task_types": {
+			prepare: func(storage *mock_storages.MockTask) {
+				storage.EXPECT().
+					Stats(gomock.Any(), gomock.Nil()).
+					Return([]*entity.TaskStats{statsOf("a", false, 1), statsOf("c", false, 2)}, nil)
+				storage.EXPECT().
+					GetPausedTaskTypes(gomock.Any()).
+					Return([]entity.TaskType{"b", "c"}, nil)
+			},
+			assertFunc: func(t *testing.T, stats []*entity.TaskStats, err error) {
+				t.Helper()
+				require.NoError(t, err)
+				require.Equal(t, []*entity.TaskStats{
+					statsOf("a", false, 1),
+					statsOf("b", true, 0),
+					statsOf("c", true, 2),
+				}, stats)
+			},
+		},
+		"should_skip_paused_task_types_of_other_type": {
+			filter: &dbentity.GetTasksFilter{TaskType: lo.ToPtr("a")},
+			prepare: func(storage *mock_storages.MockTask) {
+				storage.EXPECT().
+					Stats(gomock.Any(), gomock.Any()).
+					Return([]*entity.TaskStats{statsOf("a", false, 1)}, nil)
+				storage.EXPECT().
+					GetPausedTaskTypes(gomock.Any()).
+					Return([]entity.TaskType{"b"}, nil)
+			},
+			assertFunc: func(t *testing.T, stats []*entity.TaskStats, err error) {
+				t.Helper()
+				require.NoError(t, err)
+				require.Equal(t, []*entity.TaskStats{statsOf("a", false, 1)}, stats)
+			},
+		},
+		"should_return_paused_task_types_error": {
+			prepare: func(storage *mock_storages.MockTask) {
+				storage.EXPECT().
+					Stats(gomock.Any(), gomock.Any()).
+					Return([]*entity.TaskStats{}, nil)
+				storage.EXPECT().
+					GetPausedTaskTypes(gomock.Any()).
+					Return(nil, assert.AnError)
+			},
+			assertFunc: func(t *testing.T, _ []*entity.TaskStats, err error) {
+				t.Helper()
+				require.ErrorIs(t, err, assert.AnError)
+			},
+		},
+	}
+
+	for name, tt := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			storage := mock_storages.NewMockTask(ctrl)
+			tt.prepare(storage)
+
+			manager := NewTaskQueueManager(storage)
+
+			stats, err := manager.Stats(context.Background(), tt.filter)
+			tt.assertFunc(t, stats, err)
+		})
+	}
+}
+
 func TestTaskQueueManager_WaitAsyncEnqueues_Drains(t *testing.T) {
 	t.Parallel()
 
@@ -141,3 +721,172 @@ func TestTaskQueueManager_WaitAsyncEnqueues_Drains(t *testing.T) {
 		t.Fatal("WaitAsyncEnqueues did not unblock after the async enqueue completed")
 	}
 }
+
+func TestTaskQueueManager_GetTaskResult(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		prepare    func(storage *mock_storages.MockTask, taskID uuid.UUID)
+		assertFunc func(t *testing.T, result string, err error)
+	}{
+		"should_return_result_when_task_is_done": {
+			prepare: func(storage *mock_storages.MockTask, taskID uuid.UUID) {
+				gomock.InOrder(
+					storage.EXPECT().
+						GetTask(gomock.Any(), taskID).
+						Return(&entity.Task{ID: taskID, Status: entity.TaskStatusProcessing}, nil),
+					storage.EXPECT().
+						GetTask(gomock.Any(), taskID).
+						Return(&entity.Task{ID: taskID, Status: entity.TaskStatusDone, Result: lo.ToPtr(`{"pages":3}`)}, nil),
+				)
+			},
+			assertFunc: func(t *testing.T, result string, err error) {
+				t.Helper()
+				require.NoError(t, err)
+				require.JSONEq(t, `{"pages":3}`, result)
+			},
+		},
+		"should_return_empty_result_when_task_is_done_without_result": {
+			prepare: func(storage *mock_storages.MockTask, taskID uuid.UUID) {
+				storage.EXPECT().
+					GetTask(gomock.Any(), taskID).
+					Return(&entity.Task{ID: taskID, Status: entity.TaskStatusDone}, nil)
+			},
+			assertFunc: func(t *testing.T, result string, err error) {
+				t.Helper()
+				require.NoError(t, err)
+				require.Empty(t, result)
+			},
+		},
+		"should_return_task_failed_when_task_is_dead": {
+			prepare: func(storage *mock_storages.MockTask, taskID uuid.UUID) {
+				storage.EXPECT().
+					GetTask(gomock.Any(), taskID).
+					Return(nil, sql.ErrNoRows)
+				storage.EXPECT().
+					GetDeadTasks(gomock.Any(), &dbentity.DeadTasksFilter{IDs: []uuid.UUID{taskID}}, int64(1)).
+					Return([]*entity.Task{{
+						ID:     taskID,
+						Status: entity.TaskStatusAttemptsLeft,
+						Errors: lo.ToPtr("attempt 1: timeout\nattempt 2: report is too big\n"),
+					}}, nil)
+			},
+			assertFunc: func(t *testing.T, result string, err error) {
+				t.Helper()
+				require.ErrorIs(t, err, entity.ErrTaskFailed)
+				require.ErrorContains(t, err, "attempt 2: report is too big")
+				require.Empty(t, result)
+			},
+		},
+		"should_return_task_cancel_when_task_is_canceled": {
+			prepare: func(storage *mock_storages.MockTask, taskID uuid.UUID) {
+				storage.EXPECT().
+					GetTask(gomock.Any(), taskID).
+					Return(&entity.Task{ID: taskID, Status: entity.TaskStatusCanceled}, nil)
+			},
+			assertFunc: func(t *testing.T, _ string, err error) {
+				t.Helper()
+				require.ErrorIs(t, err, entity.ErrTaskCancel)
+			},
+		},
+		"should_return_no_rows_when_task_not_found": {
+			prepare: func(storage *mock_storages.MockTask, taskID uuid.UUID) {
+				storage.EXPECT().
+					GetTask(gomock.Any(), taskID).
+					Return(nil, sql.ErrNoRows)
+				storage.EXPECT().
+					GetDeadTasks(gomock.Any(), gomock.Any(), int64(1)).
+					Return([]*entity.Task{}, nil)
+			},
+			assertFunc: func(t *testing.T, _ string, err error) {
+				t.Helper()
+				require.ErrorIs(t, err, sql.ErrNoRows)
+			},
+		},
+		"should_return_context_error_when_task_is_not_finished_in_time": {
+			prepare: func(storage *mock_storages.MockTask, taskID uuid.UUID) {
+				storage.EXPECT().
+					GetTask(gomock.Any(), taskID).
+					Return(&entity.Task{ID: taskID, Status: entity.TaskStatusProcessing}, nil).
+					MinTimes(1)
+			},
+			assertFunc: func(t *testing.T, _ string, err error) {
+				t.Helper()
+				require.ErrorIs(t, err, context.DeadlineExceeded)
+			},
+		},
+	}
+
+	for name, tt := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			storage := mock_storages.NewMockTask(ctrl)
+			taskID := uuid.New()
+			tt.prepare(storage, taskID)
+
+			manager := NewTaskQueueManager(storage)
+			manager.waitTaskPollInterval = 10 * time.Millisecond
+
+			ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+			defer cancel()
+
+			result, err := manager.GetTaskResult(ctx, taskID)
+			tt.assertFunc(t, result, err)
+		})
+	}
+}
+
+func TestTaskQueueManager_WaitForTask_Notified(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	storage := struct {
+		*mock_storages.MockTask
+		*mock_storages.MockTaskListener
+	}{
+		MockTask:         mock_storages.NewMockTask(ctrl),
+		MockTaskListener: mock_storages.NewMockTaskListener(ctrl),
+	}
+	taskID := uuid.New()
+
+	notifications := make(chan uuid.UUID)
+	storage.MockTaskListener.EXPECT().
+		ListenFinishedTasks(gomock.Any()).
+		Return(notifications, nil)
+	var polls atomic.Int32
+	polled := make(chan struct{})
+	storage.MockTask.EXPECT().
+		GetTask(gomock.Any(), taskID).
+		DoAndReturn(func(_ context.Context, taskID uuid.UUID) (*entity.Task, error) {
+			if polls.Add(1) == 1 {
+				close(polled)
+				return &entity.Task{ID: taskID, Status: entity.TaskStatusProcessing}, nil
+			}
+			return &entity.Task{ID: taskID, Status: entity.TaskStatusDone}, nil
+		}).
+		Times(2)
+
+	manager := NewTaskQueueManager(storage)
+	// only the notification can wake up the waiting
+	manager.waitTaskPollInterval = time.Hour
+
+	go func() {
+		<-polled
+		notifications <- uuid.New()
+		notifications <- taskID
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	task, err := manager.WaitForTask(ctx, taskID)
+	require.NoError(t, err)
+	require.Equal(t, entity.TaskStatusDone, task.Status)
+
+	manager.taskWaiters.mu.Lock()
+	require.Empty(t, manager.taskWaiters.waiters)
+	require.Nil(t, manager.taskWaiters.stop)
+	manager.taskWaiters.mu.Unlock()
+}
@@ -0,0 +1,203 @@
+package queuemanager
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ruko1202/xlog"
+	"github.com/ruko1202/xlog/xfield"
+	"github.com/samber/lo"
+
+	"github.com/ruko1202/goque/internal/entity"
+	"github.com/ruko1202/goque/internal/storages"
+	"github.com/ruko1202/goque/internal/storages/dbentity"
+)
+
+const (
+	// defaultWaitTaskPollInterval is how often a waited task is read while no notification comes.
+	defaultWaitTaskPollInterval = time.Second
+	// defaultListenReconnectDelay is the delay before resubscribing to the finished task notifications.
+	defaultListenReconnectDelay = 5 * time.Second
+)
+
+// WaitForTask blocks until the task reaches a terminal status and returns it.
+// A task that ran out of attempts is looked up in the dead letters as well.
+// The task is polled; on PostgreSQL the waiting is woken up by LISTEN/NOTIFY right when the task finishes.
+func (m *TaskQueueManager) WaitForTask(ctx context.Context, taskID uuid.UUID) (*entity.Task, error) {
+	ctx, span := xlog.WithOperationSpan(xlog.ContextWithTracer(ctx, m.tracer), "task_queue_manager.WaitForTask",
+		xfield.String("task_id", taskID.String()),
+	)
+	defer span.End()
+
+	notified, release := m.taskWaiters.wait(ctx, taskID)
+	defer release()
+
+	ticker := time.NewTicker(m.waitTaskPollInterval)
+	defer ticker.Stop()
+
+	for {
+		task, finished, err := m.getFinishedTask(ctx, taskID)
+		if err != nil {
+			return nil, err
+		}
+		if finished {
+			return task, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		case <-notified:
+		}
+	}
+}
+
+// GetTaskResult waits for the task to finish and returns its JSON result, empty if the processor returned none.
+// A task that ran out of attempts returns entity.ErrTaskFailed and a canceled task entity.ErrTaskCancel,
+// both with the last error of the task.
+func (m *TaskQueueManager) GetTaskResult(ctx context.Context, taskID uuid.UUID) (string, error) {
+	ctx, span := xlog.WithOperationSpan(xlog.ContextWithTracer(ctx, m.tracer), "task_queue_manager.GetTaskResult",
+		xfield.String("task_id", taskID.String()),
+	)
+	defer span.End()
+
+	task, err := m.WaitForTask(ctx, taskID)
+	if err != nil {
+		return "", err
+	}
+
+	switch task.Status {
+	case entity.TaskStatusCanceled:
+		return "", taskFinalError(task, entity.ErrTaskCancel)
+	case entity.TaskStatusAttemptsLeft:
+		return "", taskFinalError(task, entity.ErrTaskFailed)
+	}
+
+	return lo.FromPtr(task.Result), nil
+}
+
+// getFinishedTask reads the task and reports whether it is in a terminal status.
+func (m *TaskQueueManager) getFinishedTask(ctx context.Context, taskID uuid.UUID) (*entity.Task, bool, error) {
+	task, err := m.taskStorage.GetTask(ctx, taskID)
+	if errors.Is(err, sql.ErrNoRows) {
+		deadTasks, deadErr := m.taskStorage.GetDeadTasks(ctx, &dbentity.DeadTasksFilter{IDs: []uuid.UUID{taskID}}, 1)
+		if deadErr != nil {
+			return nil, false, deadErr
+		}
+		if len(deadTasks) == 0 {
+			return nil, false, err
+		}
+		return deadTasks[0], true, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	return task, task.IsInTerminalState(), nil
+}
+
+func taskFinalError(task *entity.Task, err error) error {
+	lastErr := task.LastError()
+	if lastErr == "" {
+		return fmt.Errorf("task %s: %w", task.ID, err)
+	}
+
+	return fmt.Errorf("task %s: %w: %s", task.ID, err, lastErr)
+}
+
+// taskWaiters shares a single subscription to the finished task notifications among the WaitForTask calls.
+// The subscription is kept while anyone is waiting.
+type taskWaiters struct {
+	listener storages.TaskListener
+
+	mu      sync.Mutex
+	waiters map[uuid.UUID]map[chan struct{}]struct{}
+	stop    context.CancelFunc
+}
+
+func newTaskWaiters(taskStorage storages.Task) *taskWaiters {
+	listener, _ := taskStorage.(storages.TaskListener)
+	return &taskWaiters{
+		listener: listener,
+		waiters:  make(map[uuid.UUID]map[chan struct{}]struct{}),
+	}
+}
+
+// wait registers a waiter of the task. The returned channel is signaled when the task is notified as finished,
+// release must be called once the waiting is over. Without a listening storage the channel is never signaled.
+func (w *taskWaiters) wait(ctx context.Context, taskID uuid.UUID) (<-chan struct{}, func()) {
+	notified := make(chan struct{}, 1)
+	if w.listener == nil {
+		return notified, func() {}
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.waiters[taskID] == nil {
+		w.waiters[taskID] = make(map[chan struct{}]struct{})
+	}
+	w.waiters[taskID][notified] = struct{}{}
+	if w.stop == nil {
+		var listenCtx context.Context
+		listenCtx, w.stop = context.WithCancel(context.WithoutCancel(ctx))
+		go w.listen(listenCtx)
+	}
+
+	return notified, func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+
+		delete(w.waiters[taskID], notified)
+		if len(w.waiters[taskID]) == 0 {
+			delete(w.waiters, taskID)
+		}
+		if len(w.waiters) == 0 {
+			w.stop()
+			w.stop = nil
+		}
+	}
+}
+
+// listen keeps the subscription until ctx is done. While it is down the waiters rely on polling.
+func (w *taskWaiters) listen(ctx context.Context) {
+	for {
+		notifications, err := w.listener.ListenFinishedTasks(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				xlog.Warn(ctx, "failed to listen finished tasks, waiting by polling only",
+					xfield.Error(err),
+					xfield.Duration("reconnect_delay", defaultListenReconnectDelay),
+				)
+			}
+		} else {
+			for taskID := range notifications {
+				w.notify(taskID)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(defaultListenReconnectDelay):
+		}
+	}
+}
+
+func (w *taskWaiters) notify(taskID uuid.UUID) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for notified := range w.waiters[taskID] {
+		select {
+		case notified <- struct{}{}:
+		default:
+		}
+	}
+}
@@ -0,0 +1,23 @@
+package queuemanager
+
+import (
+	"context"
+
+	"github.com/ruko1202/xlog"
+	"github.com/ruko1202/xlog/xfield"
+
+	"github.com/ruko1202/goque/internal/entity"
+)
+
+// AddWorkflowToQueue adds all the tasks of the workflow to the queue in a single transaction.
+// The tasks depending on others wait in the waiting status until the dependencies are done.
+// Returns *entity.TasksError describing the failed tasks if some of them are invalid, depend on
+// missing or failed tasks or on each other in a cycle; no task is added then.
+func (m *TaskQueueManager) AddWorkflowToQueue(ctx context.Context, workflow *entity.Workflow) error {
+	ctx, span := xlog.WithOperationSpan(xlog.ContextWithTracer(ctx, m.tracer), "task_queue_manager.AddWorkflowToQueue",
+		xfield.Int("tasks_count", len(workflow.Tasks())),
+	)
+	defer span.End()
+
+	return m.AddTasksToQueue(ctx, workflow.Tasks())
+}
@@ -0,0 +1,23 @@
+package dbentity
+
+import "github.com/ruko1202/goque/internal/entity"
+
+// BatchTasksRow is a row of the batch tasks count grouped by status.
+type BatchTasksRow struct {
+	Status entity.TaskStatus `db:"goque_task.status"`
+	Count  int64             `db:"count"`
+}
+
+// CountBatchTasks sets the done and failed tasks count of the batch from the counts of its tasks
+// by status and the number of its dead-lettered tasks, which are all failed.
+func CountBatchTasks(batch *entity.Batch, rows []*BatchTasksRow, deadCount int64) {
+	batch.Done, batch.Failed = 0, deadCount
+	for _, row := range rows {
+		switch row.Status {
+		case entity.TaskStatusDone:
+			batch.Done += row.Count
+		case entity.TaskStatusCanceled, entity.TaskStatusAttemptsLeft:
+			batch.Failed += row.Count
+		}
+	}
+}
@@ -0,0 +1,24 @@
+package dbentity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ruko1202/goque/internal/entity"
+)
+
+func TestCountBatchTasks(t *testing.T) {
+	t.Parallel()
+
+	batch := &entity.Batch{Total: 10}
+	CountBatchTasks(batch, []*BatchTasksRow{
+		{Status: entity.TaskStatusDone, Count: 4},
+		{Status: entity.TaskStatusCanceled, Count: 1},
+		{Status: entity.TaskStatusAttemptsLeft, Count: 2},
+		{Status: entity.TaskStatusProcessing, Count: 1},
+	}, 2)
+
+	require.EqualValues(t, 4, batch.Done)
+	require.EqualValues(t, 5, batch.Failed)
+}
@@ -0,0 +1,38 @@
+package dbentity
+
+import "github.com/samber/lo"
+
+// ConcurrencyKeyCountRow is a row of the in-flight tasks count grouped by concurrency key.
+type ConcurrencyKeyCountRow struct {
+	ConcurrencyKey string `db:"goque_task.concurrency_key"`
+	Count          int64  `db:"count"`
+}
+
+// ConcurrencyKeys returns the distinct concurrency keys of the tasks, tasks without a key are skipped.
+func ConcurrencyKeys[T any](tasks []T, concurrencyKey func(T) *string) []string {
+	return lo.Uniq(lo.FilterMap(tasks, func(task T, _ int) (string, bool) {
+		key := concurrencyKey(task)
+		return lo.FromPtr(key), key != nil
+	}))
+}
+
+// LimitTasksPerConcurrencyKey keeps the tasks in order while their concurrency key has less than limit
+// tasks in flight, the kept tasks included. Tasks without a key are always kept.
+func LimitTasksPerConcurrencyKey[T any](tasks []T, concurrencyKey func(T) *string, inFlight []*ConcurrencyKeyCountRow, limit int64) []T {
+	counts := make(map[string]int64, len(inFlight))
+	for _, row := range inFlight {
+		counts[row.ConcurrencyKey] += row.Count
+	}
+
+	return lo.Filter(tasks, func(task T, _ int) bool {
+		key := concurrencyKey(task)
+		if key == nil {
+			return true
+		}
+		if counts[*key] >= limit {
+			return false
+		}
+		counts[*key]++
+		return true
+	})
+}
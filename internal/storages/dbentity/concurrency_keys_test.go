@@ -0,0 +1,36 @@
+package dbentity
+
+import (
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ruko1202/goque/internal/entity"
+)
+
+func TestLimitTasksPerConcurrencyKey(t *testing.T) {
+	t.Parallel()
+
+	newTask := func(key string) *entity.Task {
+		return entity.NewTask("test", entity.NoTaskPayload, entity.WithTaskConcurrencyKey(key))
+	}
+	concurrencyKey := func(task *entity.Task) *string { return task.ConcurrencyKey }
+
+	a1, a2, a3 := newTask("a"), newTask("a"), newTask("a")
+	b1, b2 := newTask("b"), newTask("b")
+	c1 := newTask("c")
+	unkeyed1, unkeyed2 := newTask(""), newTask("")
+	tasks := []*entity.Task{a1, unkeyed1, b1, a2, b2, c1, a3, unkeyed2}
+
+	require.ElementsMatch(t, []string{"a", "b", "c"}, ConcurrencyKeys(tasks, concurrencyKey))
+
+	limited := LimitTasksPerConcurrencyKey(tasks, concurrencyKey, []*ConcurrencyKeyCountRow{
+		{ConcurrencyKey: "a", Count: 1},
+		{ConcurrencyKey: "c", Count: 2},
+	}, 2)
+	require.Equal(t,
+		lo.Map([]*entity.Task{a1, unkeyed1, b1, b2, unkeyed2}, func(task *entity.Task, _ int) string { return task.ID.String() }),
+		lo.Map(limited, func(task *entity.Task, _ int) string { return task.ID.String() }),
+	)
+}
@@ -0,0 +1,63 @@
+package dbentity
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/ruko1202/goque/internal/entity"
+)
+
+// ErrInvalidCursor is returned when a pagination cursor can't be parsed.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// TaskCursor points to a task in the (created_at, id) order used by GetTasks
+// for keyset pagination.
+type TaskCursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+// NewTaskCursor returns a cursor pointing to the task.
+// Pass the last task of a page to get the cursor of the next page.
+func NewTaskCursor(task *entity.Task) *TaskCursor {
+	return &TaskCursor{
+		CreatedAt: task.CreatedAt,
+		ID:        task.ID,
+	}
+}
+
+// String encodes the cursor into an opaque URL-safe string.
+func (c *TaskCursor) String() string {
+	raw := c.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + c.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// ParseTaskCursor decodes a cursor produced by TaskCursor.String.
+func ParseTaskCursor(value string) (*TaskCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+
+	createdAtValue, idValue, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return nil, fmt.Errorf("%w: malformed value", ErrInvalidCursor)
+	}
+
+	createdAt, err := time.Parse(time.RFC3339Nano, createdAtValue)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+
+	id, err := uuid.Parse(idValue)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+
+	return &TaskCursor{CreatedAt: createdAt, ID: id}, nil
+}
@@ -0,0 +1,31 @@
+package dbentity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ruko1202/goque/internal/entity"
+)
+
+func TestTaskCursor(t *testing.T) {
+	t.Parallel()
+
+	task := entity.NewTask("test", `{}`)
+
+	cursor, err := ParseTaskCursor(NewTaskCursor(task).String())
+	require.NoError(t, err)
+	require.Equal(t, task.ID, cursor.ID)
+	require.True(t, task.CreatedAt.Equal(cursor.CreatedAt))
+	require.Equal(t, time.UTC, cursor.CreatedAt.Location())
+}
+
+func TestParseTaskCursor_Invalid(t *testing.T) {
+	t.Parallel()
+
+	for _, value := range []string{"", "!!!", "bm90LWEtY3Vyc29y", "MjAyNi0xMC0xN1QxMjowMDowMFp8bm90LWEtdXVpZA"} {
+		_, err := ParseTaskCursor(value)
+		require.ErrorIs(t, err, ErrInvalidCursor, value)
+	}
+}
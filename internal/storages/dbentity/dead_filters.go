@@ -0,0 +1,127 @@
+package dbentity
+
+import (
+	"time"
+
+	"github.com/go-jet/jet/v2/mysql"
+	"github.com/go-jet/jet/v2/postgres"
+	"github.com/go-jet/jet/v2/sqlite"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+
+	"github.com/ruko1202/goque/internal/entity"
+	"github.com/ruko1202/goque/internal/utils/xtime"
+
+	mysqltable "github.com/ruko1202/goque/internal/pkg/generated/mysql/goque/table"
+	pgtable "github.com/ruko1202/goque/internal/pkg/generated/postgres/public/table"
+	sqlitetable "github.com/ruko1202/goque/internal/pkg/generated/sqlite3/table"
+	"github.com/ruko1202/goque/internal/storages/dbutils"
+)
+
+// DeadTasksFilter defines filtering criteria for dead-lettered task queries.
+// All criteria are combined with AND, an empty filter matches all dead tasks.
+type DeadTasksFilter struct {
+	IDs      []uuid.UUID
+	TaskType *entity.TaskType
+	// UpdatedAtTimeAgo matches tasks moved to the dead letters at least this long ago.
+	UpdatedAtTimeAgo *time.Duration
+}
+
+// BindPgWhereExpr converts the filter to a PostgreSQL WHERE expression using go-jet.
+func (f *DeadTasksFilter) BindPgWhereExpr() postgres.BoolExpression {
+	expr := dbutils.NewPgWhereBuilder()
+
+	if len(f.IDs) > 0 {
+		expr.And(
+			pgtable.GoqueTaskDead.ID.IN(lo.Map(f.IDs, func(item uuid.UUID, _ int) postgres.Expression {
+				return postgres.UUID(item)
+			})...),
+		)
+	}
+
+	if f.TaskType != nil {
+		expr.And(
+			pgtable.GoqueTaskDead.Type.EQ(postgres.String(lo.FromPtr(f.TaskType))),
+		)
+	}
+
+	if f.UpdatedAtTimeAgo != nil {
+		expr.And(
+			pgtable.GoqueTaskDead.UpdatedAt.LT_EQ(
+				postgres.TimestampzT(xtime.Now().Add(-f.UpdatedAtTimeAgo.Abs())),
+			),
+		)
+	}
+
+	if expr.Expression() == nil {
+		return postgres.Bool(true)
+	}
+
+	return expr.Expression()
+}
+
+// BindMysqlWhereExpr converts the filter to a MySQL WHERE expression using go-jet.
+func (f *DeadTasksFilter) BindMysqlWhereExpr() mysql.BoolExpression {
+	expr := dbutils.NewMysqlWhereBuilder()
+
+	if len(f.IDs) > 0 {
+		expr.And(
+			mysqltable.GoqueTaskDead.ID.IN(lo.Map(f.IDs, func(item uuid.UUID, _ int) mysql.Expression {
+				return mysql.UUID(item)
+			})...),
+		)
+	}
+
+	if f.TaskType != nil {
+		expr.And(
+			mysqltable.GoqueTaskDead.Type.EQ(mysql.String(lo.FromPtr(f.TaskType))),
+		)
+	}
+
+	if f.UpdatedAtTimeAgo != nil {
+		expr.And(
+			mysqltable.GoqueTaskDead.UpdatedAt.LT_EQ(
+				mysql.TimestampT(xtime.Now().Add(-f.UpdatedAtTimeAgo.Abs())),
+			),
+		)
+	}
+
+	if expr.Expression() == nil {
+		return mysql.Bool(true)
+	}
+
+	return expr.Expression()
+}
+
+// BindSqliteWhereExpr converts the filter to a SQLite WHERE expression using go-jet.
+func (f *DeadTasksFilter) BindSqliteWhereExpr() sqlite.BoolExpression {
+	expr := dbutils.NewSqliteWhereBuilder()
+
+	if len(f.IDs) > 0 {
+		expr.And(
+			sqlitetable.GoqueTaskDead.ID.IN(lo.Map(f.IDs, func(item uuid.UUID, _ int) sqlite.Expression {
+				return sqlite.UUID(item)
+			})...),
+		)
+	}
+
+	if f.TaskType != nil {
+		expr.And(
+			sqlitetable.GoqueTaskDead.Type.EQ(sqlite.String(lo.FromPtr(f.TaskType))),
+		)
+	}
+
+	if f.UpdatedAtTimeAgo != nil {
+		expr.And(
+			sqlite.DATETIME(sqlitetable.GoqueTaskDead.UpdatedAt).LT_EQ(
+				sqlite.DATETIME(xtime.Now().Add(-f.UpdatedAtTimeAgo.Abs())),
+			),
+		)
+	}
+
+	if expr.Expression() == nil {
+		return sqlite.Bool(true)
+	}
+
+	return expr.Expression()
+}
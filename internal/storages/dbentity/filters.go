@@ -3,11 +3,16 @@ package dbentity
 
 import (
 	"errors"
+	"fmt"
+	"maps"
+	"slices"
 	"time"
+	"unicode/utf8"
 
 	"github.com/go-jet/jet/v2/mysql"
 	"github.com/go-jet/jet/v2/postgres"
 	"github.com/go-jet/jet/v2/sqlite"
+	"github.com/goccy/go-json"
 	"github.com/google/uuid"
 	"github.com/samber/lo"
 
@@ -20,13 +25,34 @@ import (
 	"github.com/ruko1202/goque/internal/storages/dbutils"
 )
 
+// ErrEmptyFilter is returned by bulk operations for a filter without criteria,
+// so that all tasks are not updated or deleted by mistake.
+var ErrEmptyFilter = errors.New("filter has no criteria")
+
 // GetTasksFilter defines filtering criteria for task queries.
+// All criteria are combined with AND, range bounds are inclusive.
+// Tasks are ordered by (created_at, id), which makes After usable for keyset pagination.
 type GetTasksFilter struct {
-	IDs              []uuid.UUID
-	TaskType         *entity.TaskType
-	Status           *entity.TaskStatus
-	Statuses         []entity.TaskStatus
-	UpdatedAtTimeAgo *time.Duration
+	IDs               []uuid.UUID
+	TaskType          *entity.TaskType
+	ExternalID        *string
+	ExternalIDPrefix  *string
+	Status            *entity.TaskStatus
+	Statuses          []entity.TaskStatus
+	Priority          *int32
+	AttemptsFrom      *int32
+	AttemptsTo        *int32
+	Metadata          map[string]string
+	CreatedAtFrom     *time.Time
+	CreatedAtTo       *time.Time
+	UpdatedAtTimeAgo  *time.Duration
+	NextAttemptAtFrom *time.Time
+	NextAttemptAtTo   *time.Time
+
+	// After returns only tasks following the cursor in the sort order.
+	After *TaskCursor
+	// Desc sorts tasks from the newest to the oldest.
+	Desc bool
 }
 
 // BindPgWhereExpr converts the filter to a PostgreSQL WHERE expression using go-jet.
@@ -49,6 +75,18 @@ func (f *GetTasksFilter) BindPgWhereExpr() (postgres.BoolExpression, error) {
 		)
 	}
 
+	if f.ExternalID != nil {
+		expr.And(
+			pgtable.GoqueTask.ExternalID.EQ(postgres.String(lo.FromPtr(f.ExternalID))),
+		)
+	}
+
+	if f.ExternalIDPrefix != nil {
+		expr.And(
+			pgtable.GoqueTask.ExternalID.LIKE(postgres.String(dbutils.LikePrefix(lo.FromPtr(f.ExternalIDPrefix)))),
+		)
+	}
+
 	if f.Status != nil {
 		expr.And(
 			pgtable.GoqueTask.Status.EQ(postgres.String(lo.FromPtr(f.Status))),
@@ -63,14 +101,37 @@ func (f *GetTasksFilter) BindPgWhereExpr() (postgres.BoolExpression, error) {
 		)
 	}
 
-	if f.UpdatedAtTimeAgo != nil {
+	if f.Priority != nil {
 		expr.And(
-			pgtable.GoqueTask.UpdatedAt.LT_EQ(
-				postgres.TimestampzT(xtime.Now().Add(-f.UpdatedAtTimeAgo.Abs())),
-			),
+			pgtable.GoqueTask.Priority.EQ(postgres.Int32(lo.FromPtr(f.Priority))),
+		)
+	}
+
+	if f.AttemptsFrom != nil {
+		expr.And(
+			pgtable.GoqueTask.Attempts.GT_EQ(postgres.Int32(lo.FromPtr(f.AttemptsFrom))),
+		)
+	}
+
+	if f.AttemptsTo != nil {
+		expr.And(
+			pgtable.GoqueTask.Attempts.LT_EQ(postgres.Int32(lo.FromPtr(f.AttemptsTo))),
+		)
+	}
+
+	if len(f.Metadata) > 0 {
+		metadata, err := json.Marshal(f.Metadata)
+		if err != nil {
+			return nil, err
+		}
+		expr.And(
+			postgres.RawBool("goque_task.metadata @> #metadata::jsonb", map[string]any{"#metadata": string(metadata)}),
 		)
 	}
 
+	f.bindPgTimeRanges(expr)
+	f.bindPgAfter(expr)
+
 	if expr == nil {
 		return nil, errors.New("no filter criteria specified")
 	}
@@ -97,6 +158,18 @@ func (f *GetTasksFilter) BindMysqlWhereExpr() (mysql.BoolExpression, error) {
 		)
 	}
 
+	if f.ExternalID != nil {
+		expr.And(
+			mysqltable.GoqueTask.ExternalID.EQ(mysql.String(lo.FromPtr(f.ExternalID))),
+		)
+	}
+
+	if f.ExternalIDPrefix != nil {
+		expr.And(
+			mysqltable.GoqueTask.ExternalID.LIKE(mysql.String(dbutils.LikePrefix(lo.FromPtr(f.ExternalIDPrefix)))),
+		)
+	}
+
 	if f.Status != nil {
 		expr.And(
 			mysqltable.GoqueTask.Status.EQ(mysql.String(lo.FromPtr(f.Status))),
@@ -111,14 +184,37 @@ func (f *GetTasksFilter) BindMysqlWhereExpr() (mysql.BoolExpression, error) {
 		)
 	}
 
-	if f.UpdatedAtTimeAgo != nil {
+	if f.Priority != nil {
 		expr.And(
-			mysqltable.GoqueTask.UpdatedAt.LT_EQ(
-				mysql.TimestampT(xtime.Now().Add(-f.UpdatedAtTimeAgo.Abs())),
-			),
+			mysqltable.GoqueTask.Priority.EQ(mysql.Int32(lo.FromPtr(f.Priority))),
+		)
+	}
+
+	if f.AttemptsFrom != nil {
+		expr.And(
+			mysqltable.GoqueTask.Attempts.GT_EQ(mysql.Int32(lo.FromPtr(f.AttemptsFrom))),
+		)
+	}
+
+	if f.AttemptsTo != nil {
+		expr.And(
+			mysqltable.GoqueTask.Attempts.LT_EQ(mysql.Int32(lo.FromPtr(f.AttemptsTo))),
+		)
+	}
+
+	if len(f.Metadata) > 0 {
+		metadata, err := json.Marshal(f.Metadata)
+		if err != nil {
+			return nil, err
+		}
+		expr.And(
+			mysql.RawBool("JSON_CONTAINS(goque_task.metadata, #metadata)", map[string]any{"#metadata": string(metadata)}),
 		)
 	}
 
+	f.bindMysqlTimeRanges(expr)
+	f.bindMysqlAfter(expr)
+
 	if expr == nil {
 		return nil, errors.New("no filter criteria specified")
 	}
@@ -145,6 +241,21 @@ func (f *GetTasksFilter) BindSqliteWhereExpr() (sqlite.BoolExpression, error) {
 		)
 	}
 
+	if f.ExternalID != nil {
+		expr.And(
+			sqlitetable.GoqueTask.ExternalID.EQ(sqlite.String(lo.FromPtr(f.ExternalID))),
+		)
+	}
+
+	if f.ExternalIDPrefix != nil {
+		// LIKE is case-insensitive in SQLite, unlike the external ID uniqueness.
+		prefix := lo.FromPtr(f.ExternalIDPrefix)
+		expr.And(
+			sqlite.SUBSTR(sqlitetable.GoqueTask.ExternalID, sqlite.Int(1), sqlite.Int(int64(utf8.RuneCountInString(prefix)))).
+				EQ(sqlite.String(prefix)),
+		)
+	}
+
 	if f.Status != nil {
 		expr.And(
 			sqlitetable.GoqueTask.Status.EQ(sqlite.String(lo.FromPtr(f.Status))),
@@ -159,6 +270,202 @@ func (f *GetTasksFilter) BindSqliteWhereExpr() (sqlite.BoolExpression, error) {
 		)
 	}
 
+	if f.Priority != nil {
+		expr.And(
+			sqlitetable.GoqueTask.Priority.EQ(sqlite.Int32(lo.FromPtr(f.Priority))),
+		)
+	}
+
+	if f.AttemptsFrom != nil {
+		expr.And(
+			sqlitetable.GoqueTask.Attempts.GT_EQ(sqlite.Int32(lo.FromPtr(f.AttemptsFrom))),
+		)
+	}
+
+	if f.AttemptsTo != nil {
+		expr.And(
+			sqlitetable.GoqueTask.Attempts.LT_EQ(sqlite.Int32(lo.FromPtr(f.AttemptsTo))),
+		)
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(f.Metadata)) {
+		expr.And(
+			sqlite.RawBool("json_extract(goque_task.metadata, #path) = #value", map[string]any{
+				"#path":  fmt.Sprintf("$.%q", key),
+				"#value": f.Metadata[key],
+			}),
+		)
+	}
+
+	f.bindSqliteTimeRanges(expr)
+	f.bindSqliteAfter(expr)
+
+	if expr == nil {
+		return nil, errors.New("no filter criteria specified")
+	}
+
+	return expr.Expression(), nil
+}
+
+// BindPgBulkWhereExpr is BindPgWhereExpr for bulk updates and deletes,
+// it fails with ErrEmptyFilter instead of matching all tasks.
+func (f *GetTasksFilter) BindPgBulkWhereExpr() (postgres.BoolExpression, error) {
+	expr, err := f.BindPgWhereExpr()
+	if err != nil {
+		return nil, err
+	}
+	if expr == nil {
+		return nil, ErrEmptyFilter
+	}
+	return expr, nil
+}
+
+// BindMysqlBulkWhereExpr is BindMysqlWhereExpr for bulk updates and deletes,
+// it fails with ErrEmptyFilter instead of matching all tasks.
+func (f *GetTasksFilter) BindMysqlBulkWhereExpr() (mysql.BoolExpression, error) {
+	expr, err := f.BindMysqlWhereExpr()
+	if err != nil {
+		return nil, err
+	}
+	if expr == nil {
+		return nil, ErrEmptyFilter
+	}
+	return expr, nil
+}
+
+// BindSqliteBulkWhereExpr is BindSqliteWhereExpr for bulk updates and deletes,
+// it fails with ErrEmptyFilter instead of matching all tasks.
+func (f *GetTasksFilter) BindSqliteBulkWhereExpr() (sqlite.BoolExpression, error) {
+	expr, err := f.BindSqliteWhereExpr()
+	if err != nil {
+		return nil, err
+	}
+	if expr == nil {
+		return nil, ErrEmptyFilter
+	}
+	return expr, nil
+}
+
+//nolint:dupl // Same as the other dialects but uses PostgreSQL-specific types
+func (f *GetTasksFilter) bindPgTimeRanges(expr *dbutils.PgWhereBuilder) {
+	if f.CreatedAtFrom != nil {
+		expr.And(
+			pgtable.GoqueTask.CreatedAt.GT_EQ(postgres.TimestampzT(lo.FromPtr(f.CreatedAtFrom))),
+		)
+	}
+
+	if f.CreatedAtTo != nil {
+		expr.And(
+			pgtable.GoqueTask.CreatedAt.LT_EQ(postgres.TimestampzT(lo.FromPtr(f.CreatedAtTo))),
+		)
+	}
+
+	if f.UpdatedAtTimeAgo != nil {
+		expr.And(
+			pgtable.GoqueTask.UpdatedAt.LT_EQ(
+				postgres.TimestampzT(xtime.Now().Add(-f.UpdatedAtTimeAgo.Abs())),
+			),
+		)
+	}
+
+	if f.NextAttemptAtFrom != nil {
+		expr.And(
+			pgtable.GoqueTask.NextAttemptAt.GT_EQ(postgres.TimestampzT(lo.FromPtr(f.NextAttemptAtFrom))),
+		)
+	}
+
+	if f.NextAttemptAtTo != nil {
+		expr.And(
+			pgtable.GoqueTask.NextAttemptAt.LT_EQ(postgres.TimestampzT(lo.FromPtr(f.NextAttemptAtTo))),
+		)
+	}
+}
+
+func (f *GetTasksFilter) bindPgAfter(expr *dbutils.PgWhereBuilder) {
+	if f.After != nil {
+		createdAt := postgres.TimestampzT(f.After.CreatedAt)
+		id := postgres.UUID(f.After.ID)
+		if f.Desc {
+			expr.And(
+				pgtable.GoqueTask.CreatedAt.LT(createdAt).
+					OR(pgtable.GoqueTask.CreatedAt.EQ(createdAt).AND(pgtable.GoqueTask.ID.LT(id))),
+			)
+		} else {
+			expr.And(
+				pgtable.GoqueTask.CreatedAt.GT(createdAt).
+					OR(pgtable.GoqueTask.CreatedAt.EQ(createdAt).AND(pgtable.GoqueTask.ID.GT(id))),
+			)
+		}
+	}
+}
+
+//nolint:dupl // Same as the other dialects but uses MySQL-specific types
+func (f *GetTasksFilter) bindMysqlTimeRanges(expr *dbutils.MysqlWhereBuilder) {
+	if f.CreatedAtFrom != nil {
+		expr.And(
+			mysqltable.GoqueTask.CreatedAt.GT_EQ(mysql.TimestampT(lo.FromPtr(f.CreatedAtFrom))),
+		)
+	}
+
+	if f.CreatedAtTo != nil {
+		expr.And(
+			mysqltable.GoqueTask.CreatedAt.LT_EQ(mysql.TimestampT(lo.FromPtr(f.CreatedAtTo))),
+		)
+	}
+
+	if f.UpdatedAtTimeAgo != nil {
+		expr.And(
+			mysqltable.GoqueTask.UpdatedAt.LT_EQ(
+				mysql.TimestampT(xtime.Now().Add(-f.UpdatedAtTimeAgo.Abs())),
+			),
+		)
+	}
+
+	if f.NextAttemptAtFrom != nil {
+		expr.And(
+			mysqltable.GoqueTask.NextAttemptAt.GT_EQ(mysql.TimestampT(lo.FromPtr(f.NextAttemptAtFrom))),
+		)
+	}
+
+	if f.NextAttemptAtTo != nil {
+		expr.And(
+			mysqltable.GoqueTask.NextAttemptAt.LT_EQ(mysql.TimestampT(lo.FromPtr(f.NextAttemptAtTo))),
+		)
+	}
+}
+
+func (f *GetTasksFilter) bindMysqlAfter(expr *dbutils.MysqlWhereBuilder) {
+	if f.After != nil {
+		createdAt := mysql.TimestampT(f.After.CreatedAt)
+		id := mysql.UUID(f.After.ID)
+		if f.Desc {
+			expr.And(
+				mysqltable.GoqueTask.CreatedAt.LT(createdAt).
+					OR(mysqltable.GoqueTask.CreatedAt.EQ(createdAt).AND(mysqltable.GoqueTask.ID.LT(id))),
+			)
+		} else {
+			expr.And(
+				mysqltable.GoqueTask.CreatedAt.GT(createdAt).
+					OR(mysqltable.GoqueTask.CreatedAt.EQ(createdAt).AND(mysqltable.GoqueTask.ID.GT(id))),
+			)
+		}
+	}
+}
+
+//nolint:dupl // Same as the other dialects but uses SQLite-specific types
+func (f *GetTasksFilter) bindSqliteTimeRanges(expr *dbutils.SqliteWhereBuilder) {
+	if f.CreatedAtFrom != nil {
+		expr.And(
+			sqlite.DATETIME(sqlitetable.GoqueTask.CreatedAt).GT_EQ(sqlite.DATETIME(lo.FromPtr(f.CreatedAtFrom))),
+		)
+	}
+
+	if f.CreatedAtTo != nil {
+		expr.And(
+			sqlite.DATETIME(sqlitetable.GoqueTask.CreatedAt).LT_EQ(sqlite.DATETIME(lo.FromPtr(f.CreatedAtTo))),
+		)
+	}
+
 	if f.UpdatedAtTimeAgo != nil {
 		expr.And(
 			sqlite.DATETIME(sqlitetable.GoqueTask.UpdatedAt).LT_EQ(
@@ -167,9 +474,58 @@ func (f *GetTasksFilter) BindSqliteWhereExpr() (sqlite.BoolExpression, error) {
 		)
 	}
 
-	if expr == nil {
-		return nil, errors.New("no filter criteria specified")
+	if f.NextAttemptAtFrom != nil {
+		expr.And(
+			sqlite.DATETIME(sqlitetable.GoqueTask.NextAttemptAt).GT_EQ(sqlite.DATETIME(lo.FromPtr(f.NextAttemptAtFrom))),
+		)
 	}
 
-	return expr.Expression(), nil
+	if f.NextAttemptAtTo != nil {
+		expr.And(
+			sqlite.DATETIME(sqlitetable.GoqueTask.NextAttemptAt).LT_EQ(sqlite.DATETIME(lo.FromPtr(f.NextAttemptAtTo))),
+		)
+	}
+}
+
+func (f *GetTasksFilter) bindSqliteAfter(expr *dbutils.SqliteWhereBuilder) {
+	if f.After != nil {
+		createdAt := sqlite.DATETIME(f.After.CreatedAt)
+		id := sqlite.UUID(f.After.ID)
+		if f.Desc {
+			expr.And(
+				sqlite.DATETIME(sqlitetable.GoqueTask.CreatedAt).LT(createdAt).
+					OR(sqlite.DATETIME(sqlitetable.GoqueTask.CreatedAt).EQ(createdAt).AND(sqlitetable.GoqueTask.ID.LT(id))),
+			)
+		} else {
+			expr.And(
+				sqlite.DATETIME(sqlitetable.GoqueTask.CreatedAt).GT(createdAt).
+					OR(sqlite.DATETIME(sqlitetable.GoqueTask.CreatedAt).EQ(createdAt).AND(sqlitetable.GoqueTask.ID.GT(id))),
+			)
+		}
+	}
+}
+
+// BindPgOrderBy returns the stable (created_at, id) ordering the After cursor relies on.
+func (f *GetTasksFilter) BindPgOrderBy() []postgres.OrderByClause {
+	if f.Desc {
+		return []postgres.OrderByClause{pgtable.GoqueTask.CreatedAt.DESC(), pgtable.GoqueTask.ID.DESC()}
+	}
+	return []postgres.OrderByClause{pgtable.GoqueTask.CreatedAt.ASC(), pgtable.GoqueTask.ID.ASC()}
+}
+
+// BindMysqlOrderBy returns the stable (created_at, id) ordering the After cursor relies on.
+func (f *GetTasksFilter) BindMysqlOrderBy() []mysql.OrderByClause {
+	if f.Desc {
+		return []mysql.OrderByClause{mysqltable.GoqueTask.CreatedAt.DESC(), mysqltable.GoqueTask.ID.DESC()}
+	}
+	return []mysql.OrderByClause{mysqltable.GoqueTask.CreatedAt.ASC(), mysqltable.GoqueTask.ID.ASC()}
+}
+
+// BindSqliteOrderBy returns the stable (created_at, id) ordering the After cursor relies on.
+func (f *GetTasksFilter) BindSqliteOrderBy() []sqlite.OrderByClause {
+	createdAt := sqlite.DATETIME(sqlitetable.GoqueTask.CreatedAt)
+	if f.Desc {
+		return []sqlite.OrderByClause{createdAt.DESC(), sqlitetable.GoqueTask.ID.DESC()}
+	}
+	return []sqlite.OrderByClause{createdAt.ASC(), sqlitetable.GoqueTask.ID.ASC()}
 }
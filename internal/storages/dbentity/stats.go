@@ -0,0 +1,58 @@
+package dbentity
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/ruko1202/goque/internal/entity"
+)
+
+// TaskStatsRow is a row of the stats query grouped by task type and status.
+type TaskStatsRow struct {
+	TaskType         entity.TaskType   `db:"goque_task.type"`
+	Status           entity.TaskStatus `db:"goque_task.status"`
+	Count            int64             `db:"count"`
+	OldestRunnableAt *time.Time        `db:"oldest_runnable_at"`
+	OldestUpdatedAt  *time.Time        `db:"oldest_updated_at"`
+	ProgressSum      int64             `db:"progress_sum"`
+}
+
+// BuildTaskStats folds the stats rows into per-type stats sorted by task type.
+func BuildTaskStats(rows []*TaskStatsRow) []*entity.TaskStats {
+	statsByType := make(map[entity.TaskType]*entity.TaskStats)
+	for _, row := range rows {
+		stats, ok := statsByType[row.TaskType]
+		if !ok {
+			stats = &entity.TaskStats{
+				TaskType: row.TaskType,
+				Counts:   make(map[entity.TaskStatus]int64),
+			}
+			statsByType[row.TaskType] = stats
+		}
+
+		stats.Counts[row.Status] += row.Count
+		stats.OldestRunnableAt = minTime(stats.OldestRunnableAt, row.OldestRunnableAt)
+		if row.Status == entity.TaskStatusProcessing {
+			stats.OldestProcessingAt = minTime(stats.OldestProcessingAt, row.OldestUpdatedAt)
+			if row.Count > 0 {
+				stats.ProcessingProgress = float64(row.ProgressSum) / float64(row.Count)
+			}
+		}
+	}
+
+	return slices.SortedFunc(maps.Values(statsByType), func(a, b *entity.TaskStats) int {
+		return cmp.Compare(a.TaskType, b.TaskType)
+	})
+}
+
+func minTime(a, b *time.Time) *time.Time {
+	if a == nil {
+		return b
+	}
+	if b == nil || a.Before(*b) {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,40 @@
+package dbentity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ruko1202/goque/internal/entity"
+)
+
+func TestBuildTaskStats(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	stats := BuildTaskStats([]*TaskStatsRow{
+		{TaskType: "b", Status: entity.TaskStatusNew, Count: 3, OldestRunnableAt: lo.ToPtr(now.Add(-time.Minute)), OldestUpdatedAt: nil},
+		{TaskType: "b", Status: entity.TaskStatusError, Count: 2, OldestRunnableAt: lo.ToPtr(now.Add(-time.Hour)), OldestUpdatedAt: lo.ToPtr(now)},
+		{TaskType: "b", Status: entity.TaskStatusProcessing, Count: 2, OldestUpdatedAt: lo.ToPtr(now.Add(-time.Second)), ProgressSum: 90},
+		{TaskType: "a", Status: entity.TaskStatusDone, Count: 5, OldestUpdatedAt: lo.ToPtr(now.Add(-time.Hour))},
+	})
+
+	require.Len(t, stats, 2)
+
+	require.Equal(t, "a", stats[0].TaskType)
+	require.Equal(t, map[entity.TaskStatus]int64{entity.TaskStatusDone: 5}, stats[0].Counts)
+	require.Nil(t, stats[0].OldestRunnableAt)
+	require.Nil(t, stats[0].OldestProcessingAt)
+	require.Zero(t, stats[0].ProcessingProgress)
+	require.Zero(t, stats[0].QueueLag(now))
+
+	require.Equal(t, "b", stats[1].TaskType)
+	require.Equal(t, int64(7), stats[1].Total())
+	require.Equal(t, now.Add(-time.Hour), *stats[1].OldestRunnableAt)
+	require.Equal(t, now.Add(-time.Second), *stats[1].OldestProcessingAt)
+	require.Equal(t, time.Hour, stats[1].QueueLag(now))
+	require.Equal(t, time.Second, stats[1].OldestProcessingAge(now))
+	require.InDelta(t, 45, stats[1].ProcessingProgress, 0.001)
+}
@@ -0,0 +1,139 @@
+package dbentity
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+
+	"github.com/ruko1202/goque/internal/entity"
+)
+
+// TaskDependency is a dependency of a waiting task on a task that is not done yet.
+type TaskDependency struct {
+	TaskID          uuid.UUID
+	DependsOnTaskID uuid.UUID
+}
+
+// ExternalTaskDependencies returns the distinct IDs of the tasks the tasks depend on, except for the tasks themselves.
+// Their statuses are read from the storage for BuildTaskDependencies.
+func ExternalTaskDependencies(tasks []*entity.Task) []uuid.UUID {
+	batch := lo.SliceToMap(tasks, func(task *entity.Task) (uuid.UUID, struct{}) {
+		return task.ID, struct{}{}
+	})
+
+	return lo.Uniq(lo.FlatMap(tasks, func(task *entity.Task, _ int) []uuid.UUID {
+		return lo.Reject(task.DependsOn, func(taskID uuid.UUID, _ int) bool {
+			_, ok := batch[taskID]
+			return ok
+		})
+	}))
+}
+
+// BuildTaskDependencies returns the dependencies to save along with the tasks added at once, given the statuses
+// of the tasks they depend on out of the batch. A task depending on tasks not done yet gets the waiting status,
+// a task whose dependencies are all done is ready to run as if it had none.
+// The tasks depending on missing, canceled or failed tasks, or on each other in a cycle,
+// are reported in *entity.TasksError.
+func BuildTaskDependencies(tasks []*entity.Task, statuses map[uuid.UUID]entity.TaskStatus) ([]TaskDependency, error) {
+	batch := lo.KeyBy(tasks, func(task *entity.Task) uuid.UUID {
+		return task.ID
+	})
+
+	tasksErr := entity.NewTasksError()
+	dependencies := make([]TaskDependency, 0)
+	for _, task := range tasks {
+		if len(task.DependsOn) == 0 {
+			continue
+		}
+
+		waiting := false
+		for _, dependsOnID := range lo.Uniq(task.DependsOn) {
+			status, ok := statuses[dependsOnID]
+			if _, inBatch := batch[dependsOnID]; inBatch {
+				status, ok = entity.TaskStatusNew, true
+			}
+
+			switch {
+			case !ok:
+				tasksErr.Add(task.ID, fmt.Errorf("%w: %s", entity.ErrTaskDependencyNotFound, dependsOnID))
+			case status == entity.TaskStatusDone:
+			case status == entity.TaskStatusCanceled, status == entity.TaskStatusAttemptsLeft:
+				tasksErr.Add(task.ID, fmt.Errorf("%w: task %s is %s", entity.ErrTaskDependencyFailed, dependsOnID, status))
+			default:
+				waiting = true
+				dependencies = append(dependencies, TaskDependency{TaskID: task.ID, DependsOnTaskID: dependsOnID})
+			}
+		}
+
+		switch {
+		case waiting:
+			task.Status = entity.TaskStatusWaiting
+		case task.Status == entity.TaskStatusWaiting:
+			task.Status = entity.TaskStatusNew
+		}
+	}
+
+	for _, taskID := range dependencyCycles(dependencies, batch) {
+		tasksErr.Add(taskID, entity.ErrTaskDependencyCycle)
+	}
+
+	return dependencies, tasksErr.ErrOrNil()
+}
+
+// InsertedTaskDependencies keeps the dependencies of the inserted tasks. A task depending on a task
+// of the batch that was not inserted, e.g. skipped as a duplicate, would wait forever,
+// so it is reported in *entity.TasksError.
+func InsertedTaskDependencies(dependencies []TaskDependency, batch []*entity.Task, insertedIDs []uuid.UUID) ([]TaskDependency, error) {
+	inserted := lo.Keyify(insertedIDs)
+	inBatch := lo.KeyBy(batch, func(task *entity.Task) uuid.UUID {
+		return task.ID
+	})
+
+	tasksErr := entity.NewTasksError()
+	dependencies = lo.Filter(dependencies, func(dependency TaskDependency, _ int) bool {
+		if _, ok := inserted[dependency.TaskID]; !ok {
+			return false
+		}
+		if _, ok := inBatch[dependency.DependsOnTaskID]; ok {
+			if _, ok := inserted[dependency.DependsOnTaskID]; !ok {
+				tasksErr.Add(dependency.TaskID, fmt.Errorf("%w: %s is not added", entity.ErrTaskDependencyNotFound, dependency.DependsOnTaskID))
+			}
+		}
+		return true
+	})
+
+	return dependencies, tasksErr.ErrOrNil()
+}
+
+// dependencyCycles returns the tasks of the batch that are in a dependency cycle or depend on one.
+func dependencyCycles(dependencies []TaskDependency, batch map[uuid.UUID]*entity.Task) []uuid.UUID {
+	pending := make(map[uuid.UUID]int)
+	dependents := make(map[uuid.UUID][]uuid.UUID)
+	for _, dependency := range dependencies {
+		if _, ok := batch[dependency.DependsOnTaskID]; !ok {
+			continue
+		}
+		pending[dependency.TaskID]++
+		dependents[dependency.DependsOnTaskID] = append(dependents[dependency.DependsOnTaskID], dependency.TaskID)
+	}
+
+	// release the tasks in topological order, the ones left are blocked by a cycle
+	ready := lo.Filter(lo.Keys(batch), func(taskID uuid.UUID, _ int) bool {
+		return pending[taskID] == 0
+	})
+	for len(ready) > 0 {
+		taskID := ready[len(ready)-1]
+		ready = ready[:len(ready)-1]
+		for _, dependentID := range dependents[taskID] {
+			pending[dependentID]--
+			if pending[dependentID] == 0 {
+				ready = append(ready, dependentID)
+			}
+		}
+	}
+
+	return lo.Keys(lo.PickBy(pending, func(_ uuid.UUID, count int) bool {
+		return count > 0
+	}))
+}
@@ -0,0 +1,104 @@
+package dbentity
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ruko1202/goque/internal/entity"
+)
+
+func TestBuildTaskDependencies(t *testing.T) {
+	t.Parallel()
+
+	newTask := func(dependsOn ...uuid.UUID) *entity.Task {
+		return entity.NewTask("test", entity.NoTaskPayload, entity.WithTaskDependsOn(dependsOn...))
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		t.Parallel()
+
+		queued, done := uuid.New(), uuid.New()
+		root := newTask()
+		child := newTask(root.ID, queued, queued)
+		released := newTask(done)
+		scheduled := newTask(done)
+		scheduled.ScheduleAt(time.Now().Add(time.Hour))
+		tasks := []*entity.Task{root, child, released, scheduled}
+
+		require.ElementsMatch(t, []uuid.UUID{queued, done}, ExternalTaskDependencies(tasks))
+
+		dependencies, err := BuildTaskDependencies(tasks, map[uuid.UUID]entity.TaskStatus{
+			queued: entity.TaskStatusProcessing,
+			done:   entity.TaskStatusDone,
+		})
+		require.NoError(t, err)
+		require.ElementsMatch(t, []TaskDependency{
+			{TaskID: child.ID, DependsOnTaskID: root.ID},
+			{TaskID: child.ID, DependsOnTaskID: queued},
+		}, dependencies)
+		require.Equal(t, entity.TaskStatusNew, root.Status)
+		require.Equal(t, entity.TaskStatusWaiting, child.Status)
+		require.Equal(t, entity.TaskStatusNew, released.Status)
+		require.Equal(t, entity.TaskStatusNew, scheduled.Status)
+	})
+
+	t.Run("failed dependencies", func(t *testing.T) {
+		t.Parallel()
+
+		missing, canceled := uuid.New(), uuid.New()
+		orphan, blocked := newTask(missing), newTask(canceled)
+
+		_, err := BuildTaskDependencies([]*entity.Task{orphan, blocked}, map[uuid.UUID]entity.TaskStatus{
+			canceled: entity.TaskStatusCanceled,
+		})
+		var tasksErr *entity.TasksError
+		require.True(t, errors.As(err, &tasksErr))
+		require.Len(t, tasksErr.Errors, 2)
+		require.ErrorIs(t, tasksErr.Errors[orphan.ID], entity.ErrTaskDependencyNotFound)
+		require.ErrorIs(t, tasksErr.Errors[blocked.ID], entity.ErrTaskDependencyFailed)
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		t.Parallel()
+
+		a, b, c := newTask(), newTask(), newTask()
+		a.DependsOn = []uuid.UUID{c.ID}
+		b.DependsOn = []uuid.UUID{a.ID}
+		c.DependsOn = []uuid.UUID{b.ID}
+		dependent, independent := newTask(c.ID), newTask()
+
+		_, err := BuildTaskDependencies([]*entity.Task{a, b, c, dependent, independent}, nil)
+		var tasksErr *entity.TasksError
+		require.True(t, errors.As(err, &tasksErr))
+		require.Len(t, tasksErr.Errors, 4)
+		for _, task := range []*entity.Task{a, b, c, dependent} {
+			require.ErrorIs(t, tasksErr.Errors[task.ID], entity.ErrTaskDependencyCycle)
+		}
+	})
+}
+
+func TestInsertedTaskDependencies(t *testing.T) {
+	t.Parallel()
+
+	root := entity.NewTask("test", entity.NoTaskPayload)
+	child := entity.NewTask("test", entity.NoTaskPayload, entity.WithTaskDependsOn(root.ID))
+	skipped := entity.NewTask("test", entity.NoTaskPayload, entity.WithTaskDependsOn(root.ID))
+	batch := []*entity.Task{root, child, skipped}
+	dependencies := []TaskDependency{
+		{TaskID: child.ID, DependsOnTaskID: root.ID},
+		{TaskID: skipped.ID, DependsOnTaskID: root.ID},
+	}
+
+	inserted, err := InsertedTaskDependencies(dependencies, batch, []uuid.UUID{root.ID, child.ID})
+	require.NoError(t, err)
+	require.Equal(t, dependencies[:1], inserted)
+
+	_, err = InsertedTaskDependencies(dependencies, batch, []uuid.UUID{child.ID})
+	var tasksErr *entity.TasksError
+	require.True(t, errors.As(err, &tasksErr))
+	require.ErrorIs(t, tasksErr.Errors[child.ID], entity.ErrTaskDependencyNotFound)
+}
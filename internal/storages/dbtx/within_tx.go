@@ -87,3 +87,15 @@ func commit(ctx context.Context, tx *sqlx.Tx) error {
 	}
 	return nil
 }
+
+// EnsureTx runs fn inside the *sqlx.Tx attached to ctx via WithTx if
+// there is one, otherwise inside a fresh tx opened by WithinTx. Use it
+// for multi-statement writes that must be atomic and still honor a
+// caller-owned outbox tx (e.g. batch inserts).
+func EnsureTx(ctx context.Context, db *sqlx.DB, fn func(ctx context.Context) error) error {
+	if _, ok := TxFromContext(ctx); ok {
+		return fn(ctx)
+	}
+
+	return WithinTx(ctx, db, fn)
+}
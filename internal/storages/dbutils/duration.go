@@ -0,0 +1,23 @@
+package dbutils
+
+import (
+	"time"
+
+	"github.com/samber/lo"
+)
+
+// DurationToMs converts an optional duration to milliseconds for storing in the database.
+func DurationToMs(d *time.Duration) *int64 {
+	if d == nil {
+		return nil
+	}
+	return lo.ToPtr(d.Milliseconds())
+}
+
+// DurationFromMs converts optional milliseconds stored in the database to a duration.
+func DurationFromMs(ms *int64) *time.Duration {
+	if ms == nil {
+		return nil
+	}
+	return lo.ToPtr(time.Duration(*ms) * time.Millisecond)
+}
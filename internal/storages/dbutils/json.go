@@ -1,8 +1,25 @@
 package dbutils
 
-import "github.com/goccy/go-json"
+import (
+	"github.com/goccy/go-json"
+
+	"github.com/ruko1202/goque/internal/entity"
+)
 
 // IsValidJSON checks if the provided string is valid JSON.
 func IsValidJSON(data string) bool {
 	return json.Valid([]byte(data))
 }
+
+// ValidateTasksPayload checks that every task payload is valid JSON.
+// Returns *entity.TasksError with ErrInvalidPayloadFormat for each invalid task.
+func ValidateTasksPayload(tasks []*entity.Task) error {
+	tasksErr := entity.NewTasksError()
+	for _, task := range tasks {
+		if !IsValidJSON(task.Payload) {
+			tasksErr.Add(task.ID, entity.ErrInvalidPayloadFormat)
+		}
+	}
+
+	return tasksErr.ErrOrNil()
+}
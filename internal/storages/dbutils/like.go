@@ -0,0 +1,11 @@
+package dbutils
+
+import "strings"
+
+var likeReplacer = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// LikePrefix returns a LIKE pattern matching strings starting with prefix.
+// Wildcards in prefix are escaped with the default '\' escape character.
+func LikePrefix(prefix string) string {
+	return likeReplacer.Replace(prefix) + "%"
+}
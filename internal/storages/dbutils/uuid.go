@@ -0,0 +1,26 @@
+package dbutils
+
+import (
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+)
+
+// UUIDToString converts an optional UUID to a string for storing in the databases without a UUID type.
+func UUIDToString(id *uuid.UUID) *string {
+	if id == nil {
+		return nil
+	}
+	return lo.ToPtr(id.String())
+}
+
+// UUIDFromString parses an optional UUID stored in the database as a string.
+func UUIDFromString(value *string) (*uuid.UUID, error) {
+	if value == nil {
+		return nil, nil
+	}
+	id, err := uuid.Parse(*value)
+	if err != nil {
+		return nil, err
+	}
+	return &id, nil
+}
@@ -15,13 +15,39 @@ import (
 // Task defines the interface for task storage operations.
 type Task interface {
 	AddTask(ctx context.Context, task *entity.Task) error
+	AddTasks(ctx context.Context, tasks []*entity.Task, skipDuplicates bool) error
+	AddTaskOrGet(ctx context.Context, task *entity.Task) (*entity.Task, bool, error)
+	AddBatch(ctx context.Context, batch *entity.Batch, tasks []*entity.Task, onComplete *entity.Task) error
+	GetBatch(ctx context.Context, id uuid.UUID) (*entity.Batch, error)
 	GetTask(ctx context.Context, id uuid.UUID) (*entity.Task, error)
 	GetTasks(ctx context.Context, filter *dbentity.GetTasksFilter, limit int64) ([]*entity.Task, error)
-	GetTasksForProcessing(ctx context.Context, taskType entity.TaskType, maxTasks int64) ([]*entity.Task, error)
+	GetTasksForProcessing(ctx context.Context, taskType entity.TaskType, maxTasks int64, lease entity.TaskLease, concurrencyLimitPerKey int64) ([]*entity.Task, error)
+	RenewTaskLeases(ctx context.Context, taskIDs []uuid.UUID, lease entity.TaskLease) error
 	UpdateTask(ctx context.Context, taskID uuid.UUID, task *entity.Task) error
+	SaveTaskCheckpoint(ctx context.Context, task *entity.Task) error
 	DeleteTasks(ctx context.Context, taskType entity.TaskType, statuses []entity.TaskStatus, updatedAtTimeAgo time.Duration) ([]*entity.Task, error)
 	CureTasks(ctx context.Context, taskType entity.TaskType, unhealthStatuses []entity.TaskStatus, updatedAtTimeAgo time.Duration, comment string) ([]*entity.Task, error)
 	ResetAttempts(ctx context.Context, taskID uuid.UUID) error
+	CancelTasks(ctx context.Context, filter *dbentity.GetTasksFilter) (int64, error)
+	RetryTasks(ctx context.Context, filter *dbentity.GetTasksFilter) (int64, error)
+	DeleteTasksByFilter(ctx context.Context, filter *dbentity.GetTasksFilter) (int64, error)
+	Stats(ctx context.Context, filter *dbentity.GetTasksFilter) ([]*entity.TaskStats, error)
+	MoveTaskToDead(ctx context.Context, task *entity.Task) error
+	GetDeadTasks(ctx context.Context, filter *dbentity.DeadTasksFilter, limit int64) ([]*entity.Task, error)
+	RequeueDeadTasks(ctx context.Context, filter *dbentity.DeadTasksFilter) ([]*entity.Task, error)
+	DeleteDeadTasks(ctx context.Context, filter *dbentity.DeadTasksFilter) ([]*entity.Task, error)
+	TakeRateLimitTokens(ctx context.Context, name string, limit entity.RateLimit, maxTokens int64) (int64, error)
+	ReturnRateLimitTokens(ctx context.Context, name string, limit entity.RateLimit, tokens int64) error
+	PauseTaskType(ctx context.Context, taskType entity.TaskType) error
+	ResumeTaskType(ctx context.Context, taskType entity.TaskType) error
+	IsTaskTypePaused(ctx context.Context, taskType entity.TaskType) (bool, error)
+	GetPausedTaskTypes(ctx context.Context) ([]entity.TaskType, error)
+}
+
+// TaskListener is implemented by storages able to notify about new and finished tasks (PostgreSQL LISTEN/NOTIFY).
+type TaskListener interface {
+	ListenTasks(ctx context.Context, taskType entity.TaskType) (<-chan struct{}, error)
+	ListenFinishedTasks(ctx context.Context) (<-chan uuid.UUID, error)
 }
 
 // AdvancedTaskStorage is used only for tests.
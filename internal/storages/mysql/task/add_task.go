@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/go-sql-driver/mysql"
+	"github.com/google/uuid"
 	"github.com/ruko1202/xlog"
 	"github.com/ruko1202/xlog/xfield"
 
@@ -14,7 +15,8 @@ import (
 	"github.com/ruko1202/goque/internal/storages/dbutils"
 )
 
-// AddTask inserts a new task into the database.
+// AddTask inserts a new task into the database. A task with dependencies is saved along with them,
+// see dbentity.BuildTaskDependencies.
 func (s *Storage) AddTask(ctx context.Context, task *entity.Task) error {
 	ctx, span := xlog.WithOperationSpan(ctx, "storage.AddTask",
 		xfield.String("db.type", "mysql"),
@@ -27,15 +29,17 @@ func (s *Storage) AddTask(ctx context.Context, task *entity.Task) error {
 	if !dbutils.IsValidJSON(task.Payload) {
 		return entity.ErrInvalidPayloadFormat
 	}
-	dbTask := toDBModel(ctx, task)
 
-	stmt := table.GoqueTask.
-		INSERT(table.GoqueTask.AllColumns).
-		MODEL(dbTask)
+	err := s.withTaskDependencies(ctx, []*entity.Task{task}, func(ctx context.Context) ([]uuid.UUID, error) {
+		stmt := table.GoqueTask.
+			INSERT(table.GoqueTask.AllColumns).
+			MODEL(toDBModel(ctx, task))
 
-	query, args := stmt.Sql()
+		query, args := stmt.Sql()
 
-	_, err := s.db.Executor(ctx).ExecContext(ctx, query, args...)
+		_, err := s.db.Executor(ctx).ExecContext(ctx, query, args...)
+		return []uuid.UUID{task.ID}, err
+	})
 	if err := handleError(err); err != nil {
 		xlog.Error(ctx, "failed to add task", xfield.Error(err))
 		return err
@@ -0,0 +1,228 @@
+package mysqltask
+
+import (
+	"context"
+
+	"github.com/go-jet/jet/v2/mysql"
+	"github.com/google/uuid"
+	"github.com/ruko1202/xlog"
+	"github.com/ruko1202/xlog/xfield"
+	"github.com/samber/lo"
+
+	"github.com/ruko1202/goque/internal/entity"
+	"github.com/ruko1202/goque/internal/pkg/generated/mysql/goque/model"
+	"github.com/ruko1202/goque/internal/pkg/generated/mysql/goque/table"
+	"github.com/ruko1202/goque/internal/storages/dbtx"
+	"github.com/ruko1202/goque/internal/storages/dbutils"
+)
+
+// addTasksChunkSize bounds the number of rows in a single INSERT statement.
+const addTasksChunkSize = 500
+
+// AddTasks inserts tasks into the database atomically.
+//
+// Payloads are validated first; if any is invalid nothing is inserted and
+// *entity.TasksError lists the invalid tasks.
+//
+// Without skipDuplicates a duplicate fails the whole batch with ErrDuplicateTask.
+// With skipDuplicates duplicates are skipped, the rest is inserted and
+// *entity.TasksError lists the skipped tasks with ErrDuplicateTask.
+//
+// Tasks with dependencies are saved along with them, see dbentity.BuildTaskDependencies.
+func (s *Storage) AddTasks(ctx context.Context, tasks []*entity.Task, skipDuplicates bool) error {
+	ctx, span := xlog.WithOperationSpan(ctx, "storage.AddTasks",
+		xfield.String("db.type", "mysql"),
+		xfield.Int("tasks_count", len(tasks)),
+	)
+	defer span.End()
+
+	if err := dbutils.ValidateTasksPayload(tasks); err != nil {
+		return err
+	}
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	tasksErr := entity.NewTasksError()
+	err := dbtx.EnsureTx(ctx, s.db.GetDB(), func(ctx context.Context) error {
+		return s.withTaskDependencies(ctx, tasks, func(ctx context.Context) ([]uuid.UUID, error) {
+			if !skipDuplicates {
+				for _, chunk := range lo.Chunk(tasks, addTasksChunkSize) {
+					if err := s.insertTasks(ctx, chunk); err != nil {
+						return nil, err
+					}
+				}
+				return lo.Map(tasks, func(task *entity.Task, _ int) uuid.UUID {
+					return task.ID
+				}), nil
+			}
+
+			insertedIDs := make([]uuid.UUID, 0, len(tasks))
+			for _, chunk := range lo.Chunk(tasks, addTasksChunkSize) {
+				chunkIDs, err := s.insertTasksSkipDuplicates(ctx, chunk)
+				if err != nil {
+					return nil, err
+				}
+				insertedIDs = append(insertedIDs, chunkIDs...)
+			}
+			inserted := lo.Keyify(insertedIDs)
+			for _, task := range tasks {
+				if _, ok := inserted[task.ID]; !ok {
+					tasksErr.Add(task.ID, entity.ErrDuplicateTask)
+				}
+			}
+			return insertedIDs, nil
+		})
+	})
+	if err := handleError(err); err != nil {
+		xlog.Error(ctx, "failed to add tasks", xfield.Error(err))
+		return err
+	}
+
+	return tasksErr.ErrOrNil()
+}
+
+func (s *Storage) insertTasks(ctx context.Context, tasks []*entity.Task) error {
+	ctx, span := xlog.WithOperationSpan(ctx, "storage.insertTasks")
+	defer span.End()
+
+	dbTasks := lo.Map(tasks, func(task *entity.Task, _ int) *model.GoqueTask {
+		return toDBModel(ctx, task)
+	})
+
+	query, args := table.GoqueTask.
+		INSERT(table.GoqueTask.AllColumns).
+		MODELS(dbTasks).
+		Sql()
+
+	_, err := s.db.Executor(ctx).ExecContext(ctx, query, args...)
+	return err
+}
+
+// insertTasksSkipDuplicates inserts the tasks that clash with no existing task by ID or by type and
+// external ID and returns the IDs of the inserted tasks.
+//
+// MySQL has no RETURNING, so the duplicates are looked up by a locking read first, which also keeps
+// concurrent transactions from inserting them meanwhile, and the rest is inserted by a single statement.
+// Only when the tasks clash with each other the inserted ones are read back.
+func (s *Storage) insertTasksSkipDuplicates(ctx context.Context, tasks []*entity.Task) ([]uuid.UUID, error) {
+	ctx, span := xlog.WithOperationSpan(ctx, "storage.insertTasksSkipDuplicates",
+		xfield.Int("tasks_count", len(tasks)),
+	)
+	defer span.End()
+
+	existing, err := s.lockExistingTasks(ctx, tasks)
+	if err != nil {
+		return nil, err
+	}
+	newTasks := lo.Reject(tasks, func(task *entity.Task, _ int) bool {
+		return lo.ContainsBy(existing, func(dbTask *model.GoqueTask) bool {
+			return dbTask.ID == task.ID.String() || (dbTask.Type == task.Type && dbTask.ExternalID == task.ExternalID)
+		})
+	})
+	if len(newTasks) == 0 {
+		return nil, nil
+	}
+
+	// The no-op update skips the clashing rows like INSERT IGNORE, which would also swallow data errors.
+	query, args := table.GoqueTask.
+		INSERT(table.GoqueTask.AllColumns).
+		MODELS(lo.Map(newTasks, func(task *entity.Task, _ int) *model.GoqueTask {
+			return toDBModel(ctx, task)
+		})).
+		ON_DUPLICATE_KEY_UPDATE(table.GoqueTask.ID.SET(table.GoqueTask.ID)).
+		Sql()
+
+	res, err := s.db.Executor(ctx).ExecContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+
+	newIDs := lo.Map(newTasks, func(task *entity.Task, _ int) uuid.UUID {
+		return task.ID
+	})
+	if affected == int64(len(newTasks)) {
+		return newIDs, nil
+	}
+
+	query, args = table.GoqueTask.
+		SELECT(table.GoqueTask.ID).
+		WHERE(table.GoqueTask.ID.IN(lo.Map(newIDs, func(taskID uuid.UUID, _ int) mysql.Expression {
+			return mysql.String(taskID.String())
+		})...)).
+		Sql()
+
+	insertedIDs := make([]uuid.UUID, 0, affected)
+	if err := s.db.Executor(ctx).SelectContext(ctx, &insertedIDs, query, args...); err != nil {
+		return nil, err
+	}
+
+	return insertedIDs, nil
+}
+
+// lockExistingTasks selects for update the existing tasks with the IDs or the types and external IDs of the tasks.
+// The lock covers the gaps of the missing ones as well, so the tasks can be inserted without clashing.
+func (s *Storage) lockExistingTasks(ctx context.Context, tasks []*entity.Task) ([]*model.GoqueTask, error) {
+	conditions := []mysql.BoolExpression{
+		table.GoqueTask.ID.IN(lo.Map(tasks, func(task *entity.Task, _ int) mysql.Expression {
+			return mysql.String(task.ID.String())
+		})...),
+	}
+	for _, taskType := range lo.Uniq(lo.Map(tasks, func(task *entity.Task, _ int) entity.TaskType {
+		return task.Type
+	})) {
+		conditions = append(conditions, table.GoqueTask.Type.EQ(mysql.String(taskType)).
+			AND(table.GoqueTask.ExternalID.IN(lo.FilterMap(tasks, func(task *entity.Task, _ int) (mysql.Expression, bool) {
+				return mysql.String(task.ExternalID), task.Type == taskType
+			})...)),
+		)
+	}
+
+	query, args := table.GoqueTask.
+		SELECT(
+			table.GoqueTask.ID,
+			table.GoqueTask.Type,
+			table.GoqueTask.ExternalID,
+		).
+		WHERE(mysql.OR(conditions...)).
+		FOR(mysql.UPDATE()).
+		Sql()
+
+	existing := make([]*model.GoqueTask, 0)
+	if err := s.db.Executor(ctx).SelectContext(ctx, &existing, query, args...); err != nil {
+		return nil, err
+	}
+
+	return existing, nil
+}
+
+func (s *Storage) insertTaskIfNotExists(ctx context.Context, task *entity.Task) (bool, error) {
+	ctx, span := xlog.WithOperationSpan(ctx, "storage.insertTaskIfNotExists",
+		xfield.String("task_id", task.ID.String()),
+	)
+	defer span.End()
+
+	// The no-op update keeps the existing row and reports 0 affected rows,
+	// unlike INSERT IGNORE, which would also swallow data errors.
+	query, args := table.GoqueTask.
+		INSERT(table.GoqueTask.AllColumns).
+		MODEL(toDBModel(ctx, task)).
+		ON_DUPLICATE_KEY_UPDATE(table.GoqueTask.ID.SET(table.GoqueTask.ID)).
+		Sql()
+
+	res, err := s.db.Executor(ctx).ExecContext(ctx, query, args...)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/samber/lo"
 
+	"github.com/ruko1202/goque/internal/storages/dbutils"
 	"github.com/ruko1202/goque/internal/utils/goquectx"
 
 	"github.com/ruko1202/goque/internal/entity"
@@ -16,17 +17,29 @@ import (
 func toDBModel(ctx context.Context, task *entity.Task) *model.GoqueTask {
 	metadata := task.Metadata.Merge(goquectx.Values(ctx))
 	return &model.GoqueTask{
-		ID:            task.ID.String(),
-		Type:          task.Type,
-		ExternalID:    task.ExternalID,
-		Payload:       task.Payload,
-		Status:        task.Status,
-		Attempts:      task.Attempts,
-		Errors:        task.Errors,
-		Metadata:      lo.ToPtr(metadata.ToJSON(ctx)),
-		CreatedAt:     task.CreatedAt,
-		UpdatedAt:     task.UpdatedAt,
-		NextAttemptAt: task.NextAttemptAt,
+		ID:             task.ID.String(),
+		Type:           task.Type,
+		ExternalID:     task.ExternalID,
+		Payload:        task.Payload,
+		Priority:       task.Priority,
+		Status:         task.Status,
+		Attempts:       task.Attempts,
+		Errors:         task.Errors,
+		Metadata:       lo.ToPtr(metadata.ToJSON(ctx)),
+		CreatedAt:      task.CreatedAt,
+		UpdatedAt:      task.UpdatedAt,
+		NextAttemptAt:  task.NextAttemptAt,
+		LockedBy:       task.LockedBy,
+		LeaseExpiresAt: task.LeaseExpiresAt,
+		Version:        task.Version,
+		TimeoutMs:      dbutils.DurationToMs(task.Timeout),
+		RetryDelayMs:   dbutils.DurationToMs(task.RetryDelay),
+		MaxAttempts:    task.MaxAttempts,
+		ConcurrencyKey: task.ConcurrencyKey,
+		Result:         task.Result,
+		Checkpoint:     task.Checkpoint,
+		Progress:       task.Progress,
+		BatchID:        dbutils.UUIDToString(task.BatchID),
 	}
 }
 
@@ -35,18 +48,34 @@ func fromDBModel(ctx context.Context, task *model.GoqueTask) (*entity.Task, erro
 	if err != nil {
 		return nil, fmt.Errorf("parse task id: %w", err)
 	}
+	batchID, err := dbutils.UUIDFromString(task.BatchID)
+	if err != nil {
+		return nil, fmt.Errorf("parse batch id: %w", err)
+	}
 	return &entity.Task{
-		ID:            id,
-		Type:          task.Type,
-		ExternalID:    task.ExternalID,
-		Payload:       task.Payload,
-		Status:        task.Status,
-		Attempts:      task.Attempts,
-		Errors:        task.Errors,
-		Metadata:      entity.NewMetadataFromJSON(ctx, task.Metadata),
-		CreatedAt:     task.CreatedAt,
-		UpdatedAt:     task.UpdatedAt,
-		NextAttemptAt: task.NextAttemptAt,
+		ID:             id,
+		Type:           task.Type,
+		ExternalID:     task.ExternalID,
+		Payload:        task.Payload,
+		Priority:       task.Priority,
+		Status:         task.Status,
+		Attempts:       task.Attempts,
+		Errors:         task.Errors,
+		Metadata:       entity.NewMetadataFromJSON(ctx, task.Metadata),
+		CreatedAt:      task.CreatedAt,
+		UpdatedAt:      task.UpdatedAt,
+		NextAttemptAt:  task.NextAttemptAt,
+		LockedBy:       task.LockedBy,
+		LeaseExpiresAt: task.LeaseExpiresAt,
+		Version:        task.Version,
+		Timeout:        dbutils.DurationFromMs(task.TimeoutMs),
+		RetryDelay:     dbutils.DurationFromMs(task.RetryDelayMs),
+		MaxAttempts:    task.MaxAttempts,
+		ConcurrencyKey: task.ConcurrencyKey,
+		Result:         task.Result,
+		Checkpoint:     task.Checkpoint,
+		Progress:       task.Progress,
+		BatchID:        batchID,
 	}, nil
 }
 
@@ -61,3 +90,32 @@ func fromDBModels(ctx context.Context, dbTasks []*model.GoqueTask) ([]*entity.Ta
 	}
 	return tasks, nil
 }
+
+func batchToDBModel(batch *entity.Batch) *model.GoqueTaskBatch {
+	return &model.GoqueTaskBatch{
+		ID:               batch.ID.String(),
+		Total:            batch.Total,
+		CompletionTaskID: dbutils.UUIDToString(batch.CompletionTaskID),
+		CreatedAt:        batch.CreatedAt,
+		CompletedAt:      batch.CompletedAt,
+	}
+}
+
+func batchFromDBModel(batch *model.GoqueTaskBatch) (*entity.Batch, error) {
+	id, err := uuid.Parse(batch.ID)
+	if err != nil {
+		return nil, fmt.Errorf("parse batch id: %w", err)
+	}
+	completionTaskID, err := dbutils.UUIDFromString(batch.CompletionTaskID)
+	if err != nil {
+		return nil, fmt.Errorf("parse completion task id: %w", err)
+	}
+
+	return &entity.Batch{
+		ID:               id,
+		Total:            batch.Total,
+		CompletionTaskID: completionTaskID,
+		CreatedAt:        batch.CreatedAt,
+		CompletedAt:      batch.CompletedAt,
+	}, nil
+}
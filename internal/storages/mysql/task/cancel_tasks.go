@@ -0,0 +1,88 @@
+package mysqltask
+
+import (
+	"context"
+
+	"github.com/go-jet/jet/v2/mysql"
+	"github.com/ruko1202/xlog"
+	"github.com/ruko1202/xlog/xfield"
+	"github.com/samber/lo"
+
+	"github.com/ruko1202/goque/internal/entity"
+	"github.com/ruko1202/goque/internal/pkg/generated/mysql/goque/table"
+	"github.com/ruko1202/goque/internal/storages/dbentity"
+	"github.com/ruko1202/goque/internal/storages/dbtx"
+	"github.com/ruko1202/goque/internal/utils/xtime"
+)
+
+// CancelTasks moves non-terminal tasks matching the filter to status canceled
+// with a single UPDATE and returns the number of canceled tasks.
+// The tasks waiting for the canceled ones are canceled too and not counted.
+// The batches left without unfinished tasks are completed.
+func (s *Storage) CancelTasks(ctx context.Context, filter *dbentity.GetTasksFilter) (int64, error) {
+	ctx, span := xlog.WithOperationSpan(ctx, "storage.CancelTasks",
+		xfield.String("db.type", "mysql"),
+		xfield.Any("filter", filter),
+	)
+	defer span.End()
+
+	whereExpr, err := filter.BindMysqlBulkWhereExpr()
+	if err != nil {
+		xlog.Error(ctx, "failed to bind filter", xfield.Error(err))
+		return 0, err
+	}
+
+	notTerminalExpr := table.GoqueTask.Status.NOT_IN(lo.Map(entity.TerminalStatuses(), func(status string, _ int) mysql.Expression {
+		return mysql.String(status)
+	})...)
+	stmt := table.GoqueTask.
+		UPDATE(
+			table.GoqueTask.Status,
+			table.GoqueTask.UpdatedAt,
+			table.GoqueTask.Version,
+		).
+		SET(
+			mysql.String(entity.TaskStatusCanceled),
+			mysql.TimestampT(xtime.Now()),
+			table.GoqueTask.Version.ADD(mysql.Int(1)),
+		).
+		WHERE(whereExpr.AND(notTerminalExpr))
+
+	query, args := stmt.Sql()
+
+	var canceled int64
+	err = dbtx.EnsureTx(ctx, s.db.GetDB(), func(ctx context.Context) error {
+		// the batches of the tasks to cancel, locked so the UPDATE matches the same tasks
+		batchQuery, batchArgs := table.GoqueTask.
+			SELECT(table.GoqueTask.BatchID).
+			DISTINCT().
+			WHERE(whereExpr.AND(table.GoqueTask.BatchID.IS_NOT_NULL()).AND(notTerminalExpr)).
+			FOR(mysql.UPDATE()).
+			Sql()
+		batchIDs := make([]string, 0)
+		if err := s.db.Executor(ctx).SelectContext(ctx, &batchIDs, batchQuery, batchArgs...); err != nil {
+			return err
+		}
+
+		res, err := s.db.Executor(ctx).ExecContext(ctx, query, args...)
+		if err != nil {
+			return err
+		}
+		if canceled, err = res.RowsAffected(); err != nil {
+			return err
+		}
+
+		dependentBatchIDs, err := s.cancelDependentsOfCanceledTasks(ctx)
+		if err != nil {
+			return err
+		}
+
+		return s.completeBatches(ctx, append(batchIDs, dependentBatchIDs...))
+	})
+	if err != nil {
+		xlog.Error(ctx, "failed to cancel tasks", xfield.Error(err))
+		return 0, err
+	}
+
+	return canceled, nil
+}
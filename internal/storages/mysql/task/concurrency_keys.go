@@ -0,0 +1,97 @@
+package mysqltask
+
+import (
+	"context"
+
+	"github.com/go-jet/jet/v2/mysql"
+	"github.com/ruko1202/xlog"
+	"github.com/samber/lo"
+
+	"github.com/ruko1202/goque/internal/entity"
+	"github.com/ruko1202/goque/internal/pkg/generated/mysql/goque/model"
+	"github.com/ruko1202/goque/internal/pkg/generated/mysql/goque/table"
+	"github.com/ruko1202/goque/internal/storages/dbentity"
+)
+
+// concurrencyKeysLockName is the goque_lock row locked to serialize the fetches limited by concurrency keys,
+// the counterpart of the PostgreSQL transaction advisory lock.
+// Without it two fetchers would both see a key under the limit and both claim its tasks.
+const concurrencyKeysLockName = "concurrency_keys"
+
+// lockConcurrencyKeys serializes the fetches limited by concurrency keys until the transaction ends.
+// Only the fetches wait for each other, the tasks themselves stay unlocked.
+func (s *Storage) lockConcurrencyKeys(ctx context.Context) error {
+	ctx, span := xlog.WithOperationSpan(ctx, "storage.lockConcurrencyKeys")
+	defer span.End()
+
+	// The upsert locks the row exclusively and creates it if missing, unlike SELECT FOR UPDATE,
+	// which takes only a gap lock on a missing row that doesn't conflict with the other fetches.
+	query, args := table.GoqueLock.
+		INSERT(table.GoqueLock.Name).
+		VALUES(concurrencyKeysLockName).
+		ON_DUPLICATE_KEY_UPDATE(table.GoqueLock.Name.SET(table.GoqueLock.Name)).
+		Sql()
+
+	_, err := s.db.Executor(ctx).ExecContext(ctx, query, args...)
+	return err
+}
+
+// concurrencyKeyUnderLimitExpr matches tasks without a concurrency key or whose key
+// has less than limit tasks of any type in flight.
+func concurrencyKeyUnderLimitExpr(limit int64) mysql.BoolExpression {
+	inFlight := table.GoqueTask.AS("in_flight")
+
+	return mysql.OR(
+		table.GoqueTask.ConcurrencyKey.IS_NULL(),
+		mysql.IntExp(
+			mysql.SELECT(mysql.COUNT(mysql.STAR)).
+				FROM(inFlight).
+				WHERE(mysql.AND(
+					inFlight.ConcurrencyKey.EQ(table.GoqueTask.ConcurrencyKey),
+					inFlightExpr(inFlight),
+				)),
+		).LT(mysql.Int(limit)),
+	)
+}
+
+// inFlightExpr matches fetched tasks not finished yet.
+func inFlightExpr(tbl *table.GoqueTaskTable) mysql.BoolExpression {
+	return tbl.Status.IN(
+		mysql.String(entity.TaskStatusPending),
+		mysql.String(entity.TaskStatusProcessing),
+	)
+}
+
+// limitTasksPerConcurrencyKey drops the fetched tasks that would take their key over the limit.
+func (s *Storage) limitTasksPerConcurrencyKey(ctx context.Context, tasks []*model.GoqueTask, limit int64) ([]*model.GoqueTask, error) {
+	ctx, span := xlog.WithOperationSpan(ctx, "storage.limitTasksPerConcurrencyKey")
+	defer span.End()
+
+	concurrencyKey := func(task *model.GoqueTask) *string { return task.ConcurrencyKey }
+	keys := dbentity.ConcurrencyKeys(tasks, concurrencyKey)
+	if len(keys) == 0 {
+		return tasks, nil
+	}
+
+	stmt := table.GoqueTask.
+		SELECT(
+			table.GoqueTask.ConcurrencyKey,
+			mysql.COUNT(mysql.STAR).AS("count"),
+		).
+		WHERE(mysql.AND(
+			table.GoqueTask.ConcurrencyKey.IN(lo.Map(keys, func(key string, _ int) mysql.Expression {
+				return mysql.String(key)
+			})...),
+			inFlightExpr(table.GoqueTask),
+		)).
+		GROUP_BY(table.GoqueTask.ConcurrencyKey)
+
+	query, args := stmt.Sql()
+
+	rows := make([]*dbentity.ConcurrencyKeyCountRow, 0)
+	if err := s.db.Executor(ctx).SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, err
+	}
+
+	return dbentity.LimitTasksPerConcurrencyKey(tasks, concurrencyKey, rows, limit), nil
+}
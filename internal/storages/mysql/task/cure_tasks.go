@@ -15,11 +15,12 @@ import (
 	"github.com/ruko1202/goque/internal/entity"
 	"github.com/ruko1202/goque/internal/pkg/generated/mysql/goque/model"
 	"github.com/ruko1202/goque/internal/pkg/generated/mysql/goque/table"
-	"github.com/ruko1202/goque/internal/storages/dbentity"
 	"github.com/ruko1202/goque/internal/utils/xtime"
 )
 
 // CureTasks updates stuck tasks to error status for retry.
+// A task is stuck when its lease has expired, or, for a task fetched without a lease,
+// when it was not updated for updatedAtTimeAgo.
 //
 // MySQL has no UPDATE ... RETURNING, so we can't atomically update
 // rows and read them back in a single statement (PG storage does
@@ -54,11 +55,7 @@ func (s *Storage) CureTasks(
 		// same task_type will see a different (non-overlapping) slice
 		// of rows. Without this lock the SELECT/UPDATE pair has a race
 		// where two workers both see and both cure the same task.
-		tasks, err = s.getTasksByFilterForUpdate(ctx, &dbentity.GetTasksFilter{
-			TaskType:         lo.ToPtr(taskType),
-			Statuses:         statuses,
-			UpdatedAtTimeAgo: lo.ToPtr(updatedAtTimeAgo),
-		}, 1000)
+		tasks, err = s.getStuckTasksForUpdate(ctx, taskType, statuses, updatedAtTimeAgo, 1000)
 		if err != nil {
 			return err
 		}
@@ -73,6 +70,52 @@ func (s *Storage) CureTasks(
 	return fromDBModels(ctx, tasks)
 }
 
+func (s *Storage) getStuckTasksForUpdate(
+	ctx context.Context,
+	taskType entity.TaskType,
+	statuses []entity.TaskStatus,
+	updatedAtTimeAgo time.Duration,
+	limit int64,
+) ([]*model.GoqueTask, error) {
+	ctx, span := xlog.WithOperationSpan(ctx, "storage.getStuckTasksForUpdate")
+	defer span.End()
+
+	now := xtime.Now()
+	stmt := table.GoqueTask.
+		SELECT(table.GoqueTask.AllColumns).
+		WHERE(
+			mysql.AND(
+				table.GoqueTask.Type.EQ(mysql.String(taskType)),
+				table.GoqueTask.Status.IN(lo.Map(statuses, func(item entity.TaskStatus, _ int) mysql.Expression {
+					return mysql.String(item)
+				})...),
+				mysql.OR(
+					table.GoqueTask.LeaseExpiresAt.LT_EQ(mysql.TimestampT(now)),
+					mysql.AND(
+						table.GoqueTask.LeaseExpiresAt.IS_NULL(),
+						table.GoqueTask.UpdatedAt.LT_EQ(mysql.TimestampT(now.Add(-updatedAtTimeAgo.Abs()))),
+					),
+				),
+			),
+		).
+		ORDER_BY(table.GoqueTask.CreatedAt.ASC()).
+		LIMIT(limit).
+		// SKIP LOCKED: other workers' concurrent CureTasks scans
+		// silently skip these rows instead of blocking — turns the
+		// race into a "next tick will get them" non-issue.
+		FOR(mysql.UPDATE().SKIP_LOCKED())
+
+	query, args := stmt.Sql()
+
+	tasks := make([]*model.GoqueTask, 0)
+	err := s.db.Executor(ctx).SelectContext(ctx, &tasks, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
 func (s *Storage) cureTask(ctx context.Context, tasks []*model.GoqueTask, comment string) error {
 	ctx, span := xlog.WithOperationSpan(ctx, "storage.cureTask")
 	defer span.End()
@@ -85,6 +128,7 @@ func (s *Storage) cureTask(ctx context.Context, tasks []*model.GoqueTask, commen
 			table.GoqueTask.Status,
 			table.GoqueTask.Errors,
 			table.GoqueTask.UpdatedAt,
+			table.GoqueTask.Version,
 		).
 		SET(
 			mysql.String(entity.TaskStatusError),
@@ -98,6 +142,7 @@ func (s *Storage) cureTask(ctx context.Context, tasks []*model.GoqueTask, commen
 				),
 			),
 			mysql.TimestampT(xtime.Now()),
+			table.GoqueTask.Version.ADD(mysql.Int(1)),
 		).
 		WHERE(
 			table.GoqueTask.ID.IN(lo.Map(tasks, func(item *model.GoqueTask, _ int) mysql.Expression {
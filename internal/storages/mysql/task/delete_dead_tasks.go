@@ -0,0 +1,72 @@
+package mysqltask
+
+import (
+	"context"
+
+	"github.com/go-jet/jet/v2/mysql"
+	"github.com/ruko1202/xlog"
+	"github.com/ruko1202/xlog/xfield"
+	"github.com/samber/lo"
+
+	"github.com/ruko1202/goque/internal/entity"
+	"github.com/ruko1202/goque/internal/pkg/generated/mysql/goque/model"
+	"github.com/ruko1202/goque/internal/pkg/generated/mysql/goque/table"
+	"github.com/ruko1202/goque/internal/storages/dbentity"
+	"github.com/ruko1202/goque/internal/storages/dbtx"
+)
+
+// DeleteDeadTasks purges dead-lettered tasks matching the filter and returns them.
+func (s *Storage) DeleteDeadTasks(ctx context.Context, filter *dbentity.DeadTasksFilter) ([]*entity.Task, error) {
+	ctx, span := xlog.WithOperationSpan(ctx, "storage.DeleteDeadTasks",
+		xfield.String("db.type", "mysql"),
+		xfield.Any("filter", filter),
+	)
+	defer span.End()
+
+	dbTasks := make([]*model.GoqueTaskDead, 0)
+	err := dbtx.EnsureTx(ctx, s.db.GetDB(), func(ctx context.Context) error {
+		var err error
+		dbTasks, err = s.deleteDeadTasks(ctx, filter)
+		return err
+	})
+	if err != nil {
+		xlog.Error(ctx, "failed to delete dead tasks", xfield.Error(err))
+		return nil, err
+	}
+
+	return fromDeadDBModels(ctx, dbTasks)
+}
+
+// deleteDeadTasks must be called within a transaction: MySQL has no RETURNING,
+// so the rows are locked and selected before being deleted.
+func (s *Storage) deleteDeadTasks(ctx context.Context, filter *dbentity.DeadTasksFilter) ([]*model.GoqueTaskDead, error) {
+	ctx, span := xlog.WithOperationSpan(ctx, "storage.deleteDeadTasks")
+	defer span.End()
+
+	query, args := table.GoqueTaskDead.
+		SELECT(table.GoqueTaskDead.AllColumns).
+		WHERE(filter.BindMysqlWhereExpr()).
+		FOR(mysql.UPDATE()).
+		Sql()
+
+	dbTasks := make([]*model.GoqueTaskDead, 0)
+	if err := s.db.Executor(ctx).SelectContext(ctx, &dbTasks, query, args...); err != nil {
+		return nil, err
+	}
+	if len(dbTasks) == 0 {
+		return dbTasks, nil
+	}
+
+	query, args = table.GoqueTaskDead.DELETE().
+		WHERE(
+			table.GoqueTaskDead.ID.IN(lo.Map(dbTasks, func(task *model.GoqueTaskDead, _ int) mysql.Expression {
+				return mysql.String(task.ID)
+			})...),
+		).
+		Sql()
+	if _, err := s.db.Executor(ctx).ExecContext(ctx, query, args...); err != nil {
+		return nil, err
+	}
+
+	return dbTasks, nil
+}
@@ -0,0 +1,70 @@
+package mysqltask
+
+import (
+	"context"
+
+	"github.com/go-jet/jet/v2/mysql"
+	"github.com/google/uuid"
+	"github.com/ruko1202/xlog"
+	"github.com/ruko1202/xlog/xfield"
+
+	"github.com/ruko1202/goque/internal/entity"
+	"github.com/ruko1202/goque/internal/pkg/generated/mysql/goque/model"
+	"github.com/ruko1202/goque/internal/pkg/generated/mysql/goque/table"
+	"github.com/ruko1202/goque/internal/storages/dbentity"
+)
+
+// GetBatch retrieves the batch by its ID along with the counts of its done and failed tasks.
+// The tasks deleted from the queue are not counted.
+func (s *Storage) GetBatch(ctx context.Context, id uuid.UUID) (*entity.Batch, error) {
+	ctx, span := xlog.WithOperationSpan(ctx, "storage.GetBatch",
+		xfield.String("db.type", "mysql"),
+		xfield.String("batch_id", id.String()),
+	)
+	defer span.End()
+
+	query, args := table.GoqueTaskBatch.
+		SELECT(table.GoqueTaskBatch.AllColumns).
+		WHERE(table.GoqueTaskBatch.ID.EQ(mysql.String(id.String()))).
+		Sql()
+
+	dbBatch := new(model.GoqueTaskBatch)
+	if err := s.db.Executor(ctx).GetContext(ctx, dbBatch, query, args...); err != nil {
+		xlog.Error(ctx, "failed to get batch", xfield.Error(err))
+		return nil, err
+	}
+
+	query, args = table.GoqueTask.
+		SELECT(
+			table.GoqueTask.Status,
+			mysql.COUNT(mysql.STAR).AS("count"),
+		).
+		WHERE(table.GoqueTask.BatchID.EQ(mysql.String(id.String()))).
+		GROUP_BY(table.GoqueTask.Status).
+		Sql()
+
+	rows := make([]*dbentity.BatchTasksRow, 0)
+	if err := s.db.Executor(ctx).SelectContext(ctx, &rows, query, args...); err != nil {
+		xlog.Error(ctx, "failed to count batch tasks", xfield.Error(err))
+		return nil, err
+	}
+
+	query, args = table.GoqueTaskDead.
+		SELECT(mysql.COUNT(mysql.STAR).AS("count")).
+		WHERE(table.GoqueTaskDead.BatchID.EQ(mysql.String(id.String()))).
+		Sql()
+
+	var deadCount int64
+	if err := s.db.Executor(ctx).GetContext(ctx, &deadCount, query, args...); err != nil {
+		xlog.Error(ctx, "failed to count batch dead tasks", xfield.Error(err))
+		return nil, err
+	}
+
+	batch, err := batchFromDBModel(dbBatch)
+	if err != nil {
+		return nil, err
+	}
+	dbentity.CountBatchTasks(batch, rows, deadCount)
+
+	return batch, nil
+}
@@ -15,23 +15,45 @@ import (
 	"github.com/ruko1202/goque/internal/utils/xtime"
 )
 
-// GetTasksForProcessing retrieves and locks tasks ready for processing, updating their status to pending.
-func (s *Storage) GetTasksForProcessing(ctx context.Context, taskType entity.TaskType, limit int64) ([]*entity.Task, error) {
+// GetTasksForProcessing retrieves and locks tasks ready for processing, updating their status to pending
+// and stamping them with the lease. A positive concurrencyLimitPerKey skips the tasks whose concurrency key
+// already has that many tasks of any type in flight.
+func (s *Storage) GetTasksForProcessing(
+	ctx context.Context,
+	taskType entity.TaskType,
+	limit int64,
+	lease entity.TaskLease,
+	concurrencyLimitPerKey int64,
+) ([]*entity.Task, error) {
 	ctx, span := xlog.WithOperationSpan(ctx, "storage.GetTasksForProcessing",
 		xfield.String("db.type", "mysql"),
 		xfield.String("task_type", taskType),
+		xfield.String("lease_owner", lease.Owner),
 	)
 	defer span.End()
 
 	var tasks []*model.GoqueTask
 	err := dbtx.WithinTx(ctx, s.db.GetDB(), func(ctx context.Context) error {
 		var err error
-		tasks, err = s.getTasksForProcessing(ctx, taskType, limit)
+		if concurrencyLimitPerKey > 0 {
+			if err = s.lockConcurrencyKeys(ctx); err != nil {
+				return err
+			}
+		}
+
+		tasks, err = s.getTasksForProcessing(ctx, taskType, limit, concurrencyLimitPerKey)
 		if err != nil {
 			return err
 		}
 
-		return s.batchUpdateTasksStatus(ctx, tasks, entity.TaskStatusPending)
+		if concurrencyLimitPerKey > 0 {
+			tasks, err = s.limitTasksPerConcurrencyKey(ctx, tasks, concurrencyLimitPerKey)
+			if err != nil {
+				return err
+			}
+		}
+
+		return s.leaseTasks(ctx, tasks, lease)
 	})
 	if err != nil {
 		xlog.Error(ctx, "failed to get task for processing", xfield.Error(err))
@@ -41,24 +63,29 @@ func (s *Storage) GetTasksForProcessing(ctx context.Context, taskType entity.Tas
 	return fromDBModels(ctx, tasks)
 }
 
-func (s *Storage) getTasksForProcessing(ctx context.Context, taskType entity.TaskType, limit int64) ([]*model.GoqueTask, error) {
+func (s *Storage) getTasksForProcessing(
+	ctx context.Context,
+	taskType entity.TaskType,
+	limit int64,
+	concurrencyLimitPerKey int64,
+) ([]*model.GoqueTask, error) {
 	ctx, span := xlog.WithOperationSpan(ctx, "storage.getTasksForProcessing")
 	defer span.End()
 
+	whereExpr := mysql.AND(
+		table.GoqueTask.Type.EQ(mysql.String(taskType)),
+		readyForProcessingExpr(),
+	)
+	if concurrencyLimitPerKey > 0 {
+		whereExpr = whereExpr.AND(concurrencyKeyUnderLimitExpr(concurrencyLimitPerKey))
+	}
+
 	stmt := table.GoqueTask.
 		SELECT(table.GoqueTask.AllColumns).
-		WHERE(
-			mysql.AND(
-				table.GoqueTask.Type.EQ(mysql.String(taskType)),
-				table.GoqueTask.Status.IN(
-					mysql.String(entity.TaskStatusNew),
-					mysql.String(entity.TaskStatusError),
-				),
-				table.GoqueTask.NextAttemptAt.LT_EQ(mysql.TimestampT(xtime.Now())),
-			),
-		).
+		WHERE(whereExpr).
 		FOR(mysql.UPDATE()).
 		ORDER_BY(
+			table.GoqueTask.Priority.DESC(),
 			table.GoqueTask.NextAttemptAt.ASC(),
 		).
 		LIMIT(limit)
@@ -73,3 +100,15 @@ func (s *Storage) getTasksForProcessing(ctx context.Context, taskType entity.Tas
 
 	return tasks, nil
 }
+
+// readyForProcessingExpr matches tasks the fetcher may pick up right now.
+// A scheduled task is new as well and becomes due once its next_attempt_at has passed.
+func readyForProcessingExpr() mysql.BoolExpression {
+	return mysql.AND(
+		table.GoqueTask.Status.IN(
+			mysql.String(entity.TaskStatusNew),
+			mysql.String(entity.TaskStatusError),
+		),
+		table.GoqueTask.NextAttemptAt.LT_EQ(mysql.TimestampT(xtime.Now())),
+	)
+}
@@ -0,0 +1,73 @@
+package mysqltask
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-jet/jet/v2/mysql"
+	"github.com/ruko1202/xlog"
+	"github.com/ruko1202/xlog/xfield"
+	"github.com/samber/lo"
+
+	"github.com/ruko1202/goque/internal/entity"
+	"github.com/ruko1202/goque/internal/pkg/generated/mysql/goque/model"
+	"github.com/ruko1202/goque/internal/pkg/generated/mysql/goque/table"
+	"github.com/ruko1202/goque/internal/storages/dbtx"
+	"github.com/ruko1202/goque/internal/utils/xtime"
+)
+
+// MoveTaskToDead moves the task with its current state to the dead letters table.
+// A task changed in the meantime, e.g. canceled by a user, is kept and entity.ErrTaskStateConflict is returned.
+// The tasks waiting for the task are canceled and its batch is completed if it has no unfinished tasks left.
+func (s *Storage) MoveTaskToDead(ctx context.Context, task *entity.Task) error {
+	ctx, span := xlog.WithOperationSpan(ctx, "storage.MoveTaskToDead",
+		xfield.String("db.type", "mysql"),
+		xfield.String("task_id", task.ID.String()),
+		xfield.String("task_type", task.Type),
+	)
+	defer span.End()
+
+	task.UpdatedAt = lo.ToPtr(xtime.Now())
+	dbTask := (*model.GoqueTaskDead)(toDBModel(ctx, task))
+
+	err := dbtx.EnsureTx(ctx, s.db.GetDB(), func(ctx context.Context) error {
+		query, args := table.GoqueTask.
+			DELETE().
+			WHERE(
+				table.GoqueTask.ID.EQ(mysql.String(dbTask.ID)).
+					AND(table.GoqueTask.Version.EQ(mysql.Int(task.Version))),
+			).
+			Sql()
+		res, err := s.db.Executor(ctx).ExecContext(ctx, query, args...)
+		if err != nil {
+			return err
+		}
+		deleted, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if deleted == 0 {
+			// changed concurrently or already deleted, nothing to move
+			return s.checkTaskConflict(ctx, task.ID)
+		}
+
+		query, args = table.GoqueTaskDead.
+			INSERT(table.GoqueTaskDead.AllColumns).
+			MODEL(dbTask).
+			Sql()
+		if _, err := s.db.Executor(ctx).ExecContext(ctx, query, args...); err != nil {
+			return err
+		}
+
+		return s.resolveFinishedTask(ctx, task.ID, task)
+	})
+	if errors.Is(err, entity.ErrTaskStateConflict) {
+		return err
+	}
+	if err := handleError(err); err != nil {
+		xlog.Error(ctx, "failed to move task to dead", xfield.Error(err))
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,118 @@
+package mysqltask
+
+import (
+	"context"
+	"math"
+
+	"github.com/go-jet/jet/v2/mysql"
+	"github.com/ruko1202/xlog"
+	"github.com/ruko1202/xlog/xfield"
+
+	"github.com/ruko1202/goque/internal/storages/dbtx"
+
+	"github.com/ruko1202/goque/internal/entity"
+	"github.com/ruko1202/goque/internal/pkg/generated/mysql/goque/model"
+	"github.com/ruko1202/goque/internal/pkg/generated/mysql/goque/table"
+	"github.com/ruko1202/goque/internal/utils/xtime"
+)
+
+// TakeRateLimitTokens takes up to maxTokens whole tokens from the rate limit bucket, refilled since the last take.
+// A missing bucket is created full. Returns the number of tokens taken.
+func (s *Storage) TakeRateLimitTokens(ctx context.Context, name string, limit entity.RateLimit, maxTokens int64) (int64, error) {
+	ctx, span := xlog.WithOperationSpan(ctx, "storage.TakeRateLimitTokens",
+		xfield.String("db.type", "mysql"),
+		xfield.String("rate_limit", name),
+	)
+	defer span.End()
+
+	var taken int64
+	err := s.updateRateLimit(ctx, name, limit, func(tokens float64) float64 {
+		taken = max(0, min(maxTokens, int64(math.Floor(tokens))))
+		return tokens - float64(taken)
+	})
+	if err != nil {
+		xlog.Error(ctx, "failed to take rate limit tokens", xfield.Error(err))
+		return 0, err
+	}
+
+	return taken, nil
+}
+
+// ReturnRateLimitTokens puts unused tokens back to the rate limit bucket, up to its capacity.
+func (s *Storage) ReturnRateLimitTokens(ctx context.Context, name string, limit entity.RateLimit, tokens int64) error {
+	ctx, span := xlog.WithOperationSpan(ctx, "storage.ReturnRateLimitTokens",
+		xfield.String("db.type", "mysql"),
+		xfield.String("rate_limit", name),
+	)
+	defer span.End()
+
+	if tokens <= 0 {
+		return nil
+	}
+
+	err := s.updateRateLimit(ctx, name, limit, func(bucketTokens float64) float64 {
+		return min(bucketTokens+float64(tokens), limit.Capacity())
+	})
+	if err != nil {
+		xlog.Error(ctx, "failed to return rate limit tokens", xfield.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// updateRateLimit locks the bucket, refills it and saves the tokens left by update.
+// The row lock serializes the buckets updates of all processors.
+func (s *Storage) updateRateLimit(ctx context.Context, name string, limit entity.RateLimit, update func(tokens float64) float64) error {
+	return dbtx.WithinTx(ctx, s.db.GetDB(), func(ctx context.Context) error {
+		bucket, err := s.lockRateLimit(ctx, name, limit)
+		if err != nil {
+			return err
+		}
+
+		now := xtime.Now()
+		bucket.Tokens = update(limit.Refill(bucket.Tokens, now.Sub(bucket.UpdatedAt)))
+		bucket.UpdatedAt = now
+
+		query, args := table.GoqueRateLimit.
+			UPDATE(table.GoqueRateLimit.Tokens, table.GoqueRateLimit.UpdatedAt).
+			MODEL(bucket).
+			WHERE(table.GoqueRateLimit.Name.EQ(mysql.String(name))).
+			Sql()
+
+		_, err = s.db.Executor(ctx).ExecContext(ctx, query, args...)
+		return err
+	})
+}
+
+func (s *Storage) lockRateLimit(ctx context.Context, name string, limit entity.RateLimit) (*model.GoqueRateLimit, error) {
+	ctx, span := xlog.WithOperationSpan(ctx, "storage.lockRateLimit")
+	defer span.End()
+
+	query, args := table.GoqueRateLimit.
+		INSERT(table.GoqueRateLimit.AllColumns).
+		MODEL(model.GoqueRateLimit{
+			Name:      name,
+			Tokens:    limit.Capacity(),
+			UpdatedAt: xtime.Now(),
+		}).
+		ON_DUPLICATE_KEY_UPDATE(table.GoqueRateLimit.Name.SET(table.GoqueRateLimit.Name)).
+		Sql()
+
+	if _, err := s.db.Executor(ctx).ExecContext(ctx, query, args...); err != nil {
+		return nil, err
+	}
+
+	query, args = table.GoqueRateLimit.
+		SELECT(table.GoqueRateLimit.AllColumns).
+		WHERE(table.GoqueRateLimit.Name.EQ(mysql.String(name))).
+		FOR(mysql.UPDATE()).
+		Sql()
+
+	bucket := &model.GoqueRateLimit{}
+	if err := s.db.Executor(ctx).GetContext(ctx, bucket, query, args...); err != nil {
+		return nil, err
+	}
+
+	return bucket, nil
+}
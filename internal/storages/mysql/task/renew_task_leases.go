@@ -0,0 +1,56 @@
+package mysqltask
+
+import (
+	"context"
+
+	"github.com/go-jet/jet/v2/mysql"
+	"github.com/google/uuid"
+	"github.com/ruko1202/xlog"
+	"github.com/ruko1202/xlog/xfield"
+	"github.com/samber/lo"
+
+	"github.com/ruko1202/goque/internal/entity"
+	"github.com/ruko1202/goque/internal/pkg/generated/mysql/goque/table"
+	"github.com/ruko1202/goque/internal/utils/xtime"
+)
+
+// RenewTaskLeases extends the lease of the tasks still owned by the lease owner.
+// Tasks that changed the owner or left statuses pending and processing are skipped.
+func (s *Storage) RenewTaskLeases(ctx context.Context, taskIDs []uuid.UUID, lease entity.TaskLease) error {
+	ctx, span := xlog.WithOperationSpan(ctx, "storage.RenewTaskLeases",
+		xfield.String("db.type", "mysql"),
+		xfield.String("lease_owner", lease.Owner),
+		xfield.Int("tasks", len(taskIDs)),
+	)
+	defer span.End()
+
+	if len(taskIDs) == 0 {
+		return nil
+	}
+
+	stmt := table.GoqueTask.
+		UPDATE(table.GoqueTask.LeaseExpiresAt).
+		SET(mysql.TimestampT(xtime.Now().Add(lease.Duration))).
+		WHERE(
+			mysql.AND(
+				table.GoqueTask.ID.IN(lo.Map(taskIDs, func(item uuid.UUID, _ int) mysql.Expression {
+					return mysql.String(item.String())
+				})...),
+				table.GoqueTask.LockedBy.EQ(mysql.String(lease.Owner)),
+				table.GoqueTask.Status.IN(
+					mysql.String(entity.TaskStatusPending),
+					mysql.String(entity.TaskStatusProcessing),
+				),
+			),
+		)
+
+	query, args := stmt.Sql()
+
+	_, err := s.db.Executor(ctx).ExecContext(ctx, query, args...)
+	if err != nil {
+		xlog.Error(ctx, "failed to renew task leases", xfield.Error(err))
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package mysqltask
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-jet/jet/v2/mysql"
+	"github.com/ruko1202/xlog"
+	"github.com/ruko1202/xlog/xfield"
+
+	"github.com/ruko1202/goque/internal/entity"
+	"github.com/ruko1202/goque/internal/pkg/generated/mysql/goque/table"
+	"github.com/ruko1202/goque/internal/storages/dbentity"
+	"github.com/ruko1202/goque/internal/utils/xtime"
+)
+
+// RetryTasks resets the attempts of tasks matching the filter and sets them back to status new
+// to be fetched right away, with a single UPDATE, clearing the result of done tasks and resetting the progress.
+// Tasks in flight (pending, processing) are skipped.
+// Returns the number of retried tasks.
+func (s *Storage) RetryTasks(ctx context.Context, filter *dbentity.GetTasksFilter) (int64, error) {
+	ctx, span := xlog.WithOperationSpan(ctx, "storage.RetryTasks",
+		xfield.String("db.type", "mysql"),
+		xfield.Any("filter", filter),
+	)
+	defer span.End()
+
+	whereExpr, err := filter.BindMysqlBulkWhereExpr()
+	if err != nil {
+		xlog.Error(ctx, "failed to bind filter", xfield.Error(err))
+		return 0, err
+	}
+
+	now := xtime.Now()
+	stmt := table.GoqueTask.
+		UPDATE(
+			table.GoqueTask.Status,
+			table.GoqueTask.Attempts,
+			table.GoqueTask.Errors,
+			table.GoqueTask.UpdatedAt,
+			table.GoqueTask.NextAttemptAt,
+			table.GoqueTask.Version,
+			table.GoqueTask.Result,
+			table.GoqueTask.Progress,
+		).
+		SET(
+			mysql.String(entity.TaskStatusNew),
+			mysql.Int32(0),
+			mysql.StringExp(mysql.COALESCE(table.GoqueTask.Errors, mysql.String(""))).
+				CONCAT(mysql.String(fmt.Sprintf("retry: %s\n", now.Format(time.RFC3339)))),
+			mysql.TimestampT(now),
+			mysql.TimestampT(now),
+			table.GoqueTask.Version.ADD(mysql.Int(1)),
+			mysql.NULL,
+			mysql.Int32(0),
+		).
+		WHERE(
+			whereExpr.AND(
+				table.GoqueTask.Status.NOT_IN(
+					mysql.String(entity.TaskStatusPending),
+					mysql.String(entity.TaskStatusProcessing),
+				),
+			),
+		)
+
+	query, args := stmt.Sql()
+
+	res, err := s.db.Executor(ctx).ExecContext(ctx, query, args...)
+	if err != nil {
+		xlog.Error(ctx, "failed to retry tasks", xfield.Error(err))
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
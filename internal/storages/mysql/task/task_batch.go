@@ -0,0 +1,147 @@
+package mysqltask
+
+import (
+	"context"
+	"slices"
+
+	"github.com/go-jet/jet/v2/mysql"
+	"github.com/google/uuid"
+	"github.com/ruko1202/xlog"
+	"github.com/ruko1202/xlog/xfield"
+	"github.com/samber/lo"
+
+	"github.com/ruko1202/goque/internal/entity"
+	"github.com/ruko1202/goque/internal/pkg/generated/mysql/goque/model"
+	"github.com/ruko1202/goque/internal/pkg/generated/mysql/goque/table"
+	"github.com/ruko1202/goque/internal/utils/xtime"
+)
+
+// resolveFinishedTask resolves the dependents of the task that reached a terminal status
+// and completes the batches of the task and of its canceled dependents.
+func (s *Storage) resolveFinishedTask(ctx context.Context, taskID uuid.UUID, task *entity.Task) error {
+	if !task.IsInTerminalState() {
+		return nil
+	}
+
+	batchIDs, err := s.resolveDependents(ctx, taskID, task.Status)
+	if err != nil {
+		return err
+	}
+	if task.BatchID != nil {
+		batchIDs = append(batchIDs, task.BatchID.String())
+	}
+
+	return s.completeBatches(ctx, batchIDs)
+}
+
+// completeBatches completes the batches left without unfinished tasks and releases their completion tasks.
+//
+// The batches are locked first and their unfinished tasks are looked up with a locking read, which sees
+// the latest committed state whatever the transaction read before, e.g. in a caller's tx. The read waits
+// for the transactions finishing other tasks of the batch concurrently, so those skip the batches locked
+// by others instead of waiting for them, which would deadlock. Either the read sees the task finished
+// by another transaction, or that transaction waits for the read and checks the batch after it,
+// so a batch is completed exactly once.
+func (s *Storage) completeBatches(ctx context.Context, batchIDs []string) error {
+	batchIDs = lo.Uniq(batchIDs)
+	if len(batchIDs) == 0 {
+		return nil
+	}
+
+	ctx, span := xlog.WithOperationSpan(ctx, "storage.completeBatches",
+		xfield.Int("batches_count", len(batchIDs)),
+	)
+	defer span.End()
+
+	query, args := table.GoqueTaskBatch.
+		SELECT(table.GoqueTaskBatch.ID, table.GoqueTaskBatch.CompletionTaskID).
+		WHERE(
+			table.GoqueTaskBatch.ID.IN(lo.Map(batchIDs, func(batchID string, _ int) mysql.Expression {
+				return mysql.String(batchID)
+			})...).
+				AND(table.GoqueTaskBatch.CompletedAt.IS_NULL()),
+		).
+		ORDER_BY(table.GoqueTaskBatch.ID).
+		FOR(mysql.UPDATE().SKIP_LOCKED()).
+		Sql()
+
+	batches := make([]*model.GoqueTaskBatch, 0, len(batchIDs))
+	if err := s.db.Executor(ctx).SelectContext(ctx, &batches, query, args...); err != nil {
+		return err
+	}
+	if len(batches) == 0 {
+		return nil
+	}
+
+	openIDs := lo.Map(batches, func(batch *model.GoqueTaskBatch, _ int) mysql.Expression {
+		return mysql.String(batch.ID)
+	})
+	query, args = table.GoqueTask.
+		SELECT(table.GoqueTask.BatchID).
+		DISTINCT().
+		WHERE(
+			table.GoqueTask.BatchID.IN(openIDs...).
+				AND(table.GoqueTask.Status.NOT_IN(lo.Map(entity.TerminalStatuses(), func(status string, _ int) mysql.Expression {
+					return mysql.String(status)
+				})...)),
+		).
+		LOCK_IN_SHARE_MODE().
+		Sql()
+
+	unfinishedIDs := make([]string, 0)
+	if err := s.db.Executor(ctx).SelectContext(ctx, &unfinishedIDs, query, args...); err != nil {
+		return err
+	}
+
+	completed := lo.Reject(batches, func(batch *model.GoqueTaskBatch, _ int) bool {
+		return slices.Contains(unfinishedIDs, batch.ID)
+	})
+	if len(completed) == 0 {
+		return nil
+	}
+
+	query, args = table.GoqueTaskBatch.
+		UPDATE(table.GoqueTaskBatch.CompletedAt).
+		SET(mysql.TimestampT(xtime.Now())).
+		WHERE(table.GoqueTaskBatch.ID.IN(lo.Map(completed, func(batch *model.GoqueTaskBatch, _ int) mysql.Expression {
+			return mysql.String(batch.ID)
+		})...)).
+		Sql()
+	if _, err := s.db.Executor(ctx).ExecContext(ctx, query, args...); err != nil {
+		return err
+	}
+
+	return s.releaseCompletionTasks(ctx, lo.FilterMap(completed, func(batch *model.GoqueTaskBatch, _ int) (string, bool) {
+		return lo.FromPtr(batch.CompletionTaskID), batch.CompletionTaskID != nil
+	}))
+}
+
+// releaseCompletionTasks makes the completion tasks of the completed batches ready to run.
+// A completion task canceled in the meantime stays canceled.
+func (s *Storage) releaseCompletionTasks(ctx context.Context, taskIDs []string) error {
+	if len(taskIDs) == 0 {
+		return nil
+	}
+
+	query, args := table.GoqueTask.
+		UPDATE(
+			table.GoqueTask.Status,
+			table.GoqueTask.UpdatedAt,
+			table.GoqueTask.Version,
+		).
+		SET(
+			mysql.String(entity.TaskStatusNew),
+			mysql.TimestampT(xtime.Now()),
+			table.GoqueTask.Version.ADD(mysql.Int(1)),
+		).
+		WHERE(
+			table.GoqueTask.ID.IN(lo.Map(taskIDs, func(taskID string, _ int) mysql.Expression {
+				return mysql.String(taskID)
+			})...).
+				AND(table.GoqueTask.Status.EQ(mysql.String(entity.TaskStatusWaiting))),
+		).
+		Sql()
+
+	_, err := s.db.Executor(ctx).ExecContext(ctx, query, args...)
+	return err
+}
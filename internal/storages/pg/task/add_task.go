@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/go-jet/jet/v2/postgres"
+	"github.com/google/uuid"
 	"github.com/jackc/pgerrcode"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/ruko1202/xlog"
@@ -14,9 +16,11 @@ import (
 	"github.com/ruko1202/goque/internal/entity"
 	"github.com/ruko1202/goque/internal/pkg/generated/postgres/public/table"
 	"github.com/ruko1202/goque/internal/storages/dbutils"
+	"github.com/ruko1202/goque/internal/utils/xtime"
 )
 
-// AddTask inserts a new task into the database.
+// AddTask inserts a new task into the database. A task with dependencies is saved along with them,
+// see dbentity.BuildTaskDependencies.
 func (s *Storage) AddTask(ctx context.Context, task *entity.Task) error {
 	ctx, span := xlog.WithOperationSpan(ctx, "storage.AddTask",
 		xfield.String("task_id", task.ID.String()),
@@ -29,15 +33,24 @@ func (s *Storage) AddTask(ctx context.Context, task *entity.Task) error {
 	if !dbutils.IsValidJSON(task.Payload) {
 		return entity.ErrInvalidPayloadFormat
 	}
-	dbTask := toDBModel(ctx, task)
 
-	stmt := table.GoqueTask.
-		INSERT(table.GoqueTask.AllColumns).
-		MODEL(dbTask)
+	err := s.withTaskDependencies(ctx, []*entity.Task{task}, func(ctx context.Context) ([]uuid.UUID, error) {
+		stmt := table.GoqueTask.
+			INSERT(table.GoqueTask.AllColumns).
+			MODEL(toDBModel(ctx, task))
+		if task.Status == entity.TaskStatusNew && !task.NextAttemptAt.After(xtime.Now()) {
+			// Wake up listening processors in the same round trip.
+			// Inside a transaction the notification is delivered on commit.
+			stmt = stmt.RETURNING(
+				postgres.Func("pg_notify", postgres.String(notifyChannel(task.Type)), postgres.String("")),
+			)
+		}
 
-	query, args := stmt.Sql()
+		query, args := stmt.Sql()
 
-	_, err := s.db.Executor(ctx).ExecContext(ctx, query, args...)
+		_, err := s.db.Executor(ctx).ExecContext(ctx, query, args...)
+		return []uuid.UUID{task.ID}, err
+	})
 	if err := handleError(err); err != nil {
 		xlog.Error(ctx, "failed to add task", xfield.Error(err))
 		return err
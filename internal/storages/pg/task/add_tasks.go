@@ -0,0 +1,227 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-jet/jet/v2/postgres"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/ruko1202/xlog"
+	"github.com/ruko1202/xlog/xfield"
+	"github.com/samber/lo"
+	semconv "go.opentelemetry.io/otel/semconv/v1.40.0"
+
+	"github.com/ruko1202/goque/internal/entity"
+	"github.com/ruko1202/goque/internal/pkg/generated/postgres/public/model"
+	"github.com/ruko1202/goque/internal/pkg/generated/postgres/public/table"
+	"github.com/ruko1202/goque/internal/storages/dbtx"
+	"github.com/ruko1202/goque/internal/storages/dbutils"
+	"github.com/ruko1202/goque/internal/utils/xtime"
+)
+
+const (
+	// addTasksChunkSize bounds the number of rows in a single INSERT statement.
+	addTasksChunkSize = 500
+	// copyMinTasks is the batch size starting from which COPY is used instead of INSERT.
+	copyMinTasks = 1000
+)
+
+var errCopyNotSupported = errors.New("copy is not supported by the driver")
+
+// AddTasks inserts tasks into the database atomically.
+//
+// Payloads are validated first; if any is invalid nothing is inserted and
+// *entity.TasksError lists the invalid tasks.
+//
+// Without skipDuplicates a duplicate fails the whole batch with ErrDuplicateTask.
+// With skipDuplicates duplicates are skipped (ON CONFLICT DO NOTHING), the rest is
+// inserted and *entity.TasksError lists the skipped tasks with ErrDuplicateTask.
+//
+// Tasks with dependencies are saved along with them, see dbentity.BuildTaskDependencies.
+//
+// Large batches are written with COPY when the pgx driver is used, duplicates
+// are not skipped, no task has dependencies and ctx carries no caller's tx
+// (COPY can't join a *sqlx.Tx).
+func (s *Storage) AddTasks(ctx context.Context, tasks []*entity.Task, skipDuplicates bool) error {
+	ctx, span := xlog.WithOperationSpan(ctx, "storage.AddTasks",
+		xfield.Int("tasks_count", len(tasks)),
+	)
+	span.SetAttributes(semconv.DBSystemNamePostgreSQL)
+	defer span.End()
+
+	if err := dbutils.ValidateTasksPayload(tasks); err != nil {
+		return err
+	}
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	hasDependencies := lo.SomeBy(tasks, func(task *entity.Task) bool { return len(task.DependsOn) > 0 })
+	_, inTx := dbtx.TxFromContext(ctx)
+	if !skipDuplicates && !inTx && !hasDependencies && len(tasks) >= copyMinTasks {
+		dbTasks := lo.Map(tasks, func(task *entity.Task, _ int) *model.GoqueTask {
+			return toDBModel(ctx, task)
+		})
+		err := s.copyTasks(ctx, dbTasks, tasks)
+		if !errors.Is(err, errCopyNotSupported) {
+			if err := handleError(err); err != nil {
+				xlog.Error(ctx, "failed to copy tasks", xfield.Error(err))
+				return err
+			}
+			return nil
+		}
+		xlog.Debug(ctx, "fallback to insert", xfield.Error(err))
+	}
+
+	insertedIDs := make([]uuid.UUID, 0, len(tasks))
+	err := dbtx.EnsureTx(ctx, s.db.GetDB(), func(ctx context.Context) error {
+		return s.withTaskDependencies(ctx, tasks, func(ctx context.Context) ([]uuid.UUID, error) {
+			dbTasks := lo.Map(tasks, func(task *entity.Task, _ int) *model.GoqueTask {
+				return toDBModel(ctx, task)
+			})
+			for _, chunk := range lo.Chunk(dbTasks, addTasksChunkSize) {
+				ids, err := s.insertTasks(ctx, chunk, skipDuplicates)
+				if err != nil {
+					return nil, err
+				}
+				insertedIDs = append(insertedIDs, ids...)
+			}
+
+			return insertedIDs, notifyTasks(ctx, s.db.Executor(ctx).ExecContext, tasks)
+		})
+	})
+	if err := handleError(err); err != nil {
+		xlog.Error(ctx, "failed to add tasks", xfield.Error(err))
+		return err
+	}
+
+	return skippedTasksError(tasks, insertedIDs)
+}
+
+func (s *Storage) insertTasks(ctx context.Context, tasks []*model.GoqueTask, skipDuplicates bool) ([]uuid.UUID, error) {
+	ctx, span := xlog.WithOperationSpan(ctx, "storage.insertTasks")
+	defer span.End()
+
+	stmt := table.GoqueTask.
+		INSERT(table.GoqueTask.AllColumns).
+		MODELS(tasks)
+	if skipDuplicates {
+		stmt = stmt.ON_CONFLICT().DO_NOTHING()
+	}
+	stmt = stmt.RETURNING(table.GoqueTask.ID)
+
+	query, args := stmt.Sql()
+
+	ids := make([]uuid.UUID, 0, len(tasks))
+	if err := s.db.Executor(ctx).SelectContext(ctx, &ids, query, args...); err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+func (s *Storage) copyTasks(ctx context.Context, dbTasks []*model.GoqueTask, tasks []*entity.Task) error {
+	ctx, span := xlog.WithOperationSpan(ctx, "storage.copyTasks")
+	defer span.End()
+
+	conn, err := s.db.GetDB().Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	return conn.Raw(func(driverConn any) error {
+		stdConn, ok := driverConn.(*stdlib.Conn)
+		if !ok {
+			return fmt.Errorf("%w: %T", errCopyNotSupported, driverConn)
+		}
+
+		columns, rows := copyRows(dbTasks)
+		return pgx.BeginFunc(ctx, stdConn.Conn(), func(tx pgx.Tx) error {
+			_, err := tx.CopyFrom(ctx,
+				pgx.Identifier{table.GoqueTask.SchemaName(), table.GoqueTask.TableName()},
+				columns,
+				pgx.CopyFromRows(rows),
+			)
+			if err != nil {
+				return err
+			}
+
+			return notifyTasks(ctx, tx.Exec, tasks)
+		})
+	})
+}
+
+// notifyTasks wakes up processors listening for the types of the tasks that are ready to run.
+// Identical notifications within a transaction are delivered once.
+func notifyTasks[T any](
+	ctx context.Context,
+	exec func(ctx context.Context, query string, args ...any) (T, error),
+	tasks []*entity.Task,
+) error {
+	now := xtime.Now()
+	taskTypes := lo.FilterMap(tasks, func(task *entity.Task, _ int) (entity.TaskType, bool) {
+		return task.Type, task.Status == entity.TaskStatusNew && !task.NextAttemptAt.After(now)
+	})
+
+	return notifyTaskTypes(ctx, exec, taskTypes)
+}
+
+// notifyTaskTypes wakes up processors listening for the task types, once per type.
+func notifyTaskTypes[T any](
+	ctx context.Context,
+	exec func(ctx context.Context, query string, args ...any) (T, error),
+	taskTypes []entity.TaskType,
+) error {
+	for _, taskType := range lo.Uniq(taskTypes) {
+		query, args := postgres.SELECT(
+			postgres.Func("pg_notify", postgres.String(notifyChannel(taskType)), postgres.String("")),
+		).Sql()
+		if _, err := exec(ctx, query, args...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// copyRows lays out the tasks as COPY rows in the table.GoqueTask.AllColumns order.
+func copyRows(tasks []*model.GoqueTask) ([]string, [][]any) {
+	modelType := reflect.TypeFor[model.GoqueTask]()
+	fieldByColumn := make(map[string]int, modelType.NumField())
+	for i := range modelType.NumField() {
+		column := strings.TrimPrefix(modelType.Field(i).Tag.Get("db"), table.GoqueTask.TableName()+".")
+		fieldByColumn[column] = i
+	}
+
+	columns := lo.Map(table.GoqueTask.AllColumns, func(column postgres.Column, _ int) string {
+		return column.Name()
+	})
+
+	rows := lo.Map(tasks, func(task *model.GoqueTask, _ int) []any {
+		value := reflect.ValueOf(task).Elem()
+		return lo.Map(columns, func(column string, _ int) any {
+			return value.Field(fieldByColumn[column]).Interface()
+		})
+	})
+
+	return columns, rows
+}
+
+func skippedTasksError(tasks []*entity.Task, insertedIDs []uuid.UUID) error {
+	inserted := lo.Keyify(insertedIDs)
+
+	tasksErr := entity.NewTasksError()
+	for _, task := range tasks {
+		if _, ok := inserted[task.ID]; !ok {
+			tasksErr.Add(task.ID, entity.ErrDuplicateTask)
+		}
+	}
+
+	return tasksErr.ErrOrNil()
+}
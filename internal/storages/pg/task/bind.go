@@ -7,39 +7,64 @@ import (
 
 	"github.com/ruko1202/goque/internal/entity"
 	"github.com/ruko1202/goque/internal/pkg/generated/postgres/public/model"
+	"github.com/ruko1202/goque/internal/storages/dbutils"
 	"github.com/ruko1202/goque/internal/utils/goquectx"
 )
 
 func toDBModel(ctx context.Context, task *entity.Task) *model.GoqueTask {
 	metadata := task.Metadata.Merge(goquectx.Values(ctx))
 	return &model.GoqueTask{
-		ID:            task.ID,
-		Type:          task.Type,
-		ExternalID:    task.ExternalID,
-		Payload:       task.Payload,
-		Status:        task.Status,
-		Attempts:      task.Attempts,
-		Errors:        task.Errors,
-		Metadata:      lo.ToPtr(metadata.ToJSON(ctx)),
-		CreatedAt:     task.CreatedAt,
-		UpdatedAt:     task.UpdatedAt,
-		NextAttemptAt: task.NextAttemptAt,
+		ID:             task.ID,
+		Type:           task.Type,
+		ExternalID:     task.ExternalID,
+		Payload:        task.Payload,
+		Priority:       task.Priority,
+		Status:         task.Status,
+		Attempts:       task.Attempts,
+		Errors:         task.Errors,
+		Metadata:       lo.ToPtr(metadata.ToJSON(ctx)),
+		CreatedAt:      task.CreatedAt,
+		UpdatedAt:      task.UpdatedAt,
+		NextAttemptAt:  task.NextAttemptAt,
+		LockedBy:       task.LockedBy,
+		LeaseExpiresAt: task.LeaseExpiresAt,
+		Version:        task.Version,
+		TimeoutMs:      dbutils.DurationToMs(task.Timeout),
+		RetryDelayMs:   dbutils.DurationToMs(task.RetryDelay),
+		MaxAttempts:    task.MaxAttempts,
+		ConcurrencyKey: task.ConcurrencyKey,
+		Result:         task.Result,
+		Checkpoint:     task.Checkpoint,
+		Progress:       task.Progress,
+		BatchID:        task.BatchID,
 	}
 }
 
 func fromDBModel(ctx context.Context, task *model.GoqueTask) *entity.Task {
 	return &entity.Task{
-		ID:            task.ID,
-		Type:          task.Type,
-		ExternalID:    task.ExternalID,
-		Payload:       task.Payload,
-		Status:        task.Status,
-		Attempts:      task.Attempts,
-		Errors:        task.Errors,
-		Metadata:      entity.NewMetadataFromJSON(ctx, task.Metadata),
-		CreatedAt:     task.CreatedAt,
-		UpdatedAt:     task.UpdatedAt,
-		NextAttemptAt: task.NextAttemptAt,
+		ID:             task.ID,
+		Type:           task.Type,
+		ExternalID:     task.ExternalID,
+		Payload:        task.Payload,
+		Priority:       task.Priority,
+		Status:         task.Status,
+		Attempts:       task.Attempts,
+		Errors:         task.Errors,
+		Metadata:       entity.NewMetadataFromJSON(ctx, task.Metadata),
+		CreatedAt:      task.CreatedAt,
+		UpdatedAt:      task.UpdatedAt,
+		NextAttemptAt:  task.NextAttemptAt,
+		LockedBy:       task.LockedBy,
+		LeaseExpiresAt: task.LeaseExpiresAt,
+		Version:        task.Version,
+		Timeout:        dbutils.DurationFromMs(task.TimeoutMs),
+		RetryDelay:     dbutils.DurationFromMs(task.RetryDelayMs),
+		MaxAttempts:    task.MaxAttempts,
+		ConcurrencyKey: task.ConcurrencyKey,
+		Result:         task.Result,
+		Checkpoint:     task.Checkpoint,
+		Progress:       task.Progress,
+		BatchID:        task.BatchID,
 	}
 }
 
@@ -48,3 +73,23 @@ func fromDBModels(ctx context.Context, tasks []*model.GoqueTask) []*entity.Task
 		return fromDBModel(ctx, item)
 	})
 }
+
+func batchToDBModel(batch *entity.Batch) *model.GoqueTaskBatch {
+	return &model.GoqueTaskBatch{
+		ID:               batch.ID,
+		Total:            batch.Total,
+		CompletionTaskID: batch.CompletionTaskID,
+		CreatedAt:        batch.CreatedAt,
+		CompletedAt:      batch.CompletedAt,
+	}
+}
+
+func batchFromDBModel(batch *model.GoqueTaskBatch) *entity.Batch {
+	return &entity.Batch{
+		ID:               batch.ID,
+		Total:            batch.Total,
+		CompletionTaskID: batch.CompletionTaskID,
+		CreatedAt:        batch.CreatedAt,
+		CompletedAt:      batch.CompletedAt,
+	}
+}
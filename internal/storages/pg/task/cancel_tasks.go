@@ -0,0 +1,88 @@
+package task
+
+import (
+	"context"
+
+	"github.com/go-jet/jet/v2/postgres"
+	"github.com/google/uuid"
+	"github.com/ruko1202/xlog"
+	"github.com/ruko1202/xlog/xfield"
+	"github.com/samber/lo"
+	semconv "go.opentelemetry.io/otel/semconv/v1.40.0"
+
+	"github.com/ruko1202/goque/internal/entity"
+	"github.com/ruko1202/goque/internal/pkg/generated/postgres/public/model"
+	"github.com/ruko1202/goque/internal/pkg/generated/postgres/public/table"
+	"github.com/ruko1202/goque/internal/storages/dbentity"
+	"github.com/ruko1202/goque/internal/storages/dbtx"
+	"github.com/ruko1202/goque/internal/utils/xtime"
+)
+
+// CancelTasks moves non-terminal tasks matching the filter to status canceled
+// with a single UPDATE and returns the number of canceled tasks.
+// The tasks waiting for the canceled ones are canceled too and not counted.
+// The batches left without unfinished tasks are completed.
+func (s *Storage) CancelTasks(ctx context.Context, filter *dbentity.GetTasksFilter) (int64, error) {
+	ctx, span := xlog.WithOperationSpan(ctx, "storage.CancelTasks",
+		xfield.Any("filter", filter),
+	)
+	span.SetAttributes(semconv.DBSystemNamePostgreSQL)
+	defer span.End()
+
+	whereExpr, err := filter.BindPgBulkWhereExpr()
+	if err != nil {
+		xlog.Error(ctx, "failed to bind filter", xfield.Error(err))
+		return 0, err
+	}
+
+	stmt := table.GoqueTask.
+		UPDATE(
+			table.GoqueTask.Status,
+			table.GoqueTask.UpdatedAt,
+			table.GoqueTask.Version,
+		).
+		SET(
+			postgres.String(entity.TaskStatusCanceled),
+			postgres.TimestampzT(xtime.Now()),
+			table.GoqueTask.Version.ADD(postgres.Int(1)),
+		).
+		WHERE(
+			whereExpr.AND(
+				table.GoqueTask.Status.NOT_IN(lo.Map(entity.TerminalStatuses(), func(status string, _ int) postgres.Expression {
+					return postgres.String(status)
+				})...),
+			),
+		).
+		RETURNING(table.GoqueTask.ID, table.GoqueTask.BatchID)
+
+	query, args := stmt.Sql()
+
+	canceledTasks := make([]*model.GoqueTask, 0)
+	err = dbtx.EnsureTx(ctx, s.db.GetDB(), func(ctx context.Context) error {
+		if err := s.db.Executor(ctx).SelectContext(ctx, &canceledTasks, query, args...); err != nil {
+			return err
+		}
+		// wake up the waiters of the canceled tasks, see ListenFinishedTasks
+		err := s.notifyTasksFinished(ctx, lo.Map(canceledTasks, func(task *model.GoqueTask, _ int) uuid.UUID {
+			return task.ID
+		}))
+		if err != nil {
+			return err
+		}
+
+		batchIDs, err := s.cancelDependentsOfCanceledTasks(ctx)
+		if err != nil {
+			return err
+		}
+
+		return s.completeBatches(ctx, append(batchIDs, lo.FilterMap(canceledTasks, func(task *model.GoqueTask, _ int) (uuid.UUID, bool) {
+			return lo.FromPtr(task.BatchID), task.BatchID != nil
+		})...))
+	})
+	if err != nil {
+		xlog.Error(ctx, "failed to cancel tasks", xfield.Error(err))
+		return 0, err
+	}
+
+	return int64(len(canceledTasks)), nil
+}
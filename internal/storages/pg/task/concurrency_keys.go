@@ -0,0 +1,91 @@
+package task
+
+import (
+	"context"
+
+	"github.com/go-jet/jet/v2/postgres"
+	"github.com/ruko1202/xlog"
+	"github.com/samber/lo"
+
+	"github.com/ruko1202/goque/internal/entity"
+	"github.com/ruko1202/goque/internal/pkg/generated/postgres/public/model"
+	"github.com/ruko1202/goque/internal/pkg/generated/postgres/public/table"
+	"github.com/ruko1202/goque/internal/storages/dbentity"
+)
+
+// concurrencyKeysLockID is the transaction advisory lock serializing fetches limited by concurrency keys.
+// Without it two fetchers would both see a key under the limit and both claim its tasks.
+const concurrencyKeysLockID = 0x676f717565 // "goque"
+
+// lockConcurrencyKeys serializes the fetches limited by concurrency keys until the transaction ends.
+func (s *Storage) lockConcurrencyKeys(ctx context.Context) error {
+	ctx, span := xlog.WithOperationSpan(ctx, "storage.lockConcurrencyKeys")
+	defer span.End()
+
+	query, args := postgres.SELECT(
+		postgres.Func("pg_advisory_xact_lock", postgres.Int64(concurrencyKeysLockID)),
+	).Sql()
+
+	_, err := s.db.Executor(ctx).ExecContext(ctx, query, args...)
+	return err
+}
+
+// concurrencyKeyUnderLimitExpr matches tasks without a concurrency key or whose key
+// has less than limit tasks of any type in flight.
+func concurrencyKeyUnderLimitExpr(limit int64) postgres.BoolExpression {
+	inFlight := table.GoqueTask.AS("in_flight")
+
+	return postgres.OR(
+		table.GoqueTask.ConcurrencyKey.IS_NULL(),
+		postgres.IntExp(
+			postgres.SELECT(postgres.COUNT(postgres.STAR)).
+				FROM(inFlight).
+				WHERE(postgres.AND(
+					inFlight.ConcurrencyKey.EQ(table.GoqueTask.ConcurrencyKey),
+					inFlightExpr(inFlight),
+				)),
+		).LT(postgres.Int(limit)),
+	)
+}
+
+// inFlightExpr matches fetched tasks not finished yet.
+func inFlightExpr(tbl *table.GoqueTaskTable) postgres.BoolExpression {
+	return tbl.Status.IN(
+		postgres.String(entity.TaskStatusPending),
+		postgres.String(entity.TaskStatusProcessing),
+	)
+}
+
+// limitTasksPerConcurrencyKey drops the fetched tasks that would take their key over the limit.
+func (s *Storage) limitTasksPerConcurrencyKey(ctx context.Context, tasks []*model.GoqueTask, limit int64) ([]*model.GoqueTask, error) {
+	ctx, span := xlog.WithOperationSpan(ctx, "storage.limitTasksPerConcurrencyKey")
+	defer span.End()
+
+	concurrencyKey := func(task *model.GoqueTask) *string { return task.ConcurrencyKey }
+	keys := dbentity.ConcurrencyKeys(tasks, concurrencyKey)
+	if len(keys) == 0 {
+		return tasks, nil
+	}
+
+	stmt := table.GoqueTask.
+		SELECT(
+			table.GoqueTask.ConcurrencyKey,
+			postgres.COUNT(postgres.STAR).AS("count"),
+		).
+		WHERE(postgres.AND(
+			table.GoqueTask.ConcurrencyKey.IN(lo.Map(keys, func(key string, _ int) postgres.Expression {
+				return postgres.String(key)
+			})...),
+			inFlightExpr(table.GoqueTask),
+		)).
+		GROUP_BY(table.GoqueTask.ConcurrencyKey)
+
+	query, args := stmt.Sql()
+
+	rows := make([]*dbentity.ConcurrencyKeyCountRow, 0)
+	if err := s.db.Executor(ctx).SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, err
+	}
+
+	return dbentity.LimitTasksPerConcurrencyKey(tasks, concurrencyKey, rows, limit), nil
+}
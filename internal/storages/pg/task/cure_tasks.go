@@ -18,6 +18,8 @@ import (
 )
 
 // CureTasks updates stuck tasks to error status for retry.
+// A task is stuck when its lease has expired, or, for a task fetched without a lease,
+// when it was not updated for updatedAtTimeAgo.
 func (s *Storage) CureTasks(
 	ctx context.Context,
 	taskType entity.TaskType,
@@ -37,6 +39,7 @@ func (s *Storage) CureTasks(
 			table.GoqueTask.Status,
 			table.GoqueTask.Errors,
 			table.GoqueTask.UpdatedAt,
+			table.GoqueTask.Version,
 		).
 		SET(
 			postgres.String(entity.TaskStatusError),
@@ -48,18 +51,10 @@ func (s *Storage) CureTasks(
 					CONCAT(postgres.String(fmt.Sprintf(": %s\n", comment))),
 			),
 			postgres.TimestampzT(xtime.Now()),
+			table.GoqueTask.Version.ADD(postgres.Int(1)),
 		).
-		WHERE(
-			postgres.AND(
-				table.GoqueTask.Type.EQ(postgres.String(taskType)),
-				table.GoqueTask.Status.IN(lo.Map(statuses, func(item entity.TaskStatus, _ int) postgres.Expression {
-					return postgres.String(item)
-				})...),
-				table.GoqueTask.UpdatedAt.LT_EQ(
-					postgres.TimestampzT(xtime.Now().Add(-updatedAtTimeAgo.Abs())),
-				),
-			),
-		).RETURNING(table.GoqueTask.AllColumns)
+		WHERE(stuckTasksExpr(taskType, statuses, updatedAtTimeAgo)).
+		RETURNING(table.GoqueTask.AllColumns)
 
 	query, args := stmt.Sql()
 
@@ -72,3 +67,21 @@ func (s *Storage) CureTasks(
 
 	return fromDBModels(ctx, dbTasks), nil
 }
+
+// stuckTasksExpr matches tasks nobody works on anymore.
+func stuckTasksExpr(taskType entity.TaskType, statuses []entity.TaskStatus, updatedAtTimeAgo time.Duration) postgres.BoolExpression {
+	now := xtime.Now()
+	return postgres.AND(
+		table.GoqueTask.Type.EQ(postgres.String(taskType)),
+		table.GoqueTask.Status.IN(lo.Map(statuses, func(item entity.TaskStatus, _ int) postgres.Expression {
+			return postgres.String(item)
+		})...),
+		postgres.OR(
+			table.GoqueTask.LeaseExpiresAt.LT_EQ(postgres.TimestampzT(now)),
+			postgres.AND(
+				table.GoqueTask.LeaseExpiresAt.IS_NULL(),
+				table.GoqueTask.UpdatedAt.LT_EQ(postgres.TimestampzT(now.Add(-updatedAtTimeAgo.Abs()))),
+			),
+		),
+	)
+}
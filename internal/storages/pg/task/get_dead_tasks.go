@@ -0,0 +1,47 @@
+package task
+
+import (
+	"context"
+
+	"github.com/ruko1202/xlog"
+	"github.com/ruko1202/xlog/xfield"
+	"github.com/samber/lo"
+	semconv "go.opentelemetry.io/otel/semconv/v1.40.0"
+
+	"github.com/ruko1202/goque/internal/entity"
+	"github.com/ruko1202/goque/internal/pkg/generated/postgres/public/model"
+	"github.com/ruko1202/goque/internal/pkg/generated/postgres/public/table"
+	"github.com/ruko1202/goque/internal/storages/dbentity"
+)
+
+// GetDeadTasks retrieves dead-lettered tasks matching the filter, the most recently moved first.
+func (s *Storage) GetDeadTasks(ctx context.Context, filter *dbentity.DeadTasksFilter, limit int64) ([]*entity.Task, error) {
+	ctx, span := xlog.WithOperationSpan(ctx, "storage.GetDeadTasks",
+		xfield.Any("filter", filter),
+	)
+	span.SetAttributes(semconv.DBSystemNamePostgreSQL)
+	defer span.End()
+
+	stmt := table.GoqueTaskDead.
+		SELECT(table.GoqueTaskDead.AllColumns).
+		WHERE(filter.BindPgWhereExpr()).
+		ORDER_BY(table.GoqueTaskDead.UpdatedAt.DESC(), table.GoqueTaskDead.ID.DESC()).
+		LIMIT(limit)
+
+	query, args := stmt.Sql()
+
+	dbTasks := make([]*model.GoqueTaskDead, 0)
+	err := s.db.Executor(ctx).SelectContext(ctx, &dbTasks, query, args...)
+	if err != nil {
+		xlog.Error(ctx, "failed to get dead tasks", xfield.Error(err))
+		return nil, err
+	}
+
+	return fromDeadDBModels(ctx, dbTasks), nil
+}
+
+func fromDeadDBModels(ctx context.Context, tasks []*model.GoqueTaskDead) []*entity.Task {
+	return lo.Map(tasks, func(item *model.GoqueTaskDead, _ int) *entity.Task {
+		return fromDBModel(ctx, (*model.GoqueTask)(item))
+	})
+}
@@ -41,6 +41,7 @@ func (s *Storage) getTasksByFilter(ctx context.Context, filter *dbentity.GetTask
 	stmt := table.GoqueTask.
 		SELECT(table.GoqueTask.AllColumns).
 		WHERE(whereExpr).
+		ORDER_BY(filter.BindPgOrderBy()...).
 		LIMIT(limit)
 
 	query, args := stmt.Sql()
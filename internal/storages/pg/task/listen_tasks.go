@@ -0,0 +1,170 @@
+package task
+
+import (
+	"context"
+	"crypto/sha1" //nolint:gosec // used for channel name shortening, not for security
+	"database/sql/driver"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/go-jet/jet/v2/postgres"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/ruko1202/xlog"
+	"github.com/ruko1202/xlog/xfield"
+	semconv "go.opentelemetry.io/otel/semconv/v1.40.0"
+
+	"github.com/ruko1202/goque/internal/entity"
+)
+
+const (
+	notifyChannelPrefix = "goque_task_"
+	// finishedTasksChannel carries the IDs of the tasks reaching a terminal status.
+	finishedTasksChannel = "goque_finished_task"
+	// finishedTasksBufferSize is the number of finished task notifications kept until they are read.
+	finishedTasksBufferSize = 256
+	// PostgreSQL truncates identifiers longer than NAMEDATALEN-1 bytes.
+	maxNotifyChannelLen = 63
+)
+
+// ErrListenNotSupported is returned when the database driver can't hold a LISTEN connection.
+var ErrListenNotSupported = errors.New("listen is not supported by the driver")
+
+// ListenTasks subscribes to notifications about new tasks of the given type.
+//
+// The subscription holds a dedicated connection from the pool. Notifications are
+// coalesced: a single pending signal in the returned channel means "there is something
+// to fetch". The channel is closed when ctx is done or the connection is lost,
+// the connection is discarded from the pool in both cases.
+//
+// Only the pgx driver is supported, ErrListenNotSupported is returned for others.
+func (s *Storage) ListenTasks(ctx context.Context, taskType entity.TaskType) (<-chan struct{}, error) {
+	ctx, span := xlog.WithOperationSpan(ctx, "storage.ListenTasks",
+		xfield.String("task_type", taskType),
+	)
+	span.SetAttributes(semconv.DBSystemNamePostgreSQL)
+	defer span.End()
+
+	return listen(ctx, s, notifyChannel(taskType), 1, func(string) (struct{}, bool) {
+		return struct{}{}, true
+	})
+}
+
+// ListenFinishedTasks subscribes to notifications about tasks reaching a terminal status
+// and returns their IDs.
+//
+// The subscription holds a dedicated connection from the pool, the notifications
+// not read in time are dropped. The channel is closed when ctx is done or the connection is lost.
+//
+// Only the pgx driver is supported, ErrListenNotSupported is returned for others.
+func (s *Storage) ListenFinishedTasks(ctx context.Context) (<-chan uuid.UUID, error) {
+	ctx, span := xlog.WithOperationSpan(ctx, "storage.ListenFinishedTasks")
+	span.SetAttributes(semconv.DBSystemNamePostgreSQL)
+	defer span.End()
+
+	return listen(ctx, s, finishedTasksChannel, finishedTasksBufferSize, func(payload string) (uuid.UUID, bool) {
+		taskID, err := uuid.Parse(payload)
+		return taskID, err == nil
+	})
+}
+
+// listen runs LISTEN on a dedicated connection and sends the parsed notification payloads
+// to the returned channel until ctx is done or the connection is lost.
+// A payload is dropped when the channel is full.
+func listen[T any](ctx context.Context, s *Storage, channel string, size int, parse func(payload string) (T, bool)) (<-chan T, error) {
+	conn, err := s.db.GetDB().Conn(ctx)
+	if err != nil {
+		xlog.Error(ctx, "failed to get listener connection", xfield.Error(err))
+		return nil, err
+	}
+
+	notifications := make(chan T, size)
+	listening := make(chan error, 1)
+	stopped := make(chan error, 1)
+
+	go func() {
+		defer close(notifications)
+		defer conn.Close()
+
+		err := conn.Raw(func(driverConn any) error {
+			stdConn, ok := driverConn.(*stdlib.Conn)
+			if !ok {
+				err := fmt.Errorf("%w: %T", ErrListenNotSupported, driverConn)
+				listening <- err
+				return err
+			}
+			pgxConn := stdConn.Conn()
+
+			if _, err := pgxConn.Exec(ctx, "LISTEN "+pgx.Identifier{channel}.Sanitize()); err != nil {
+				listening <- err
+				return fmt.Errorf("%w: %w", driver.ErrBadConn, err)
+			}
+			listening <- nil
+
+			for {
+				notification, err := pgxConn.WaitForNotification(ctx)
+				if err != nil {
+					// the connection is still subscribed to the channel,
+					// so it must not go back to the pool
+					return fmt.Errorf("%w: %w", driver.ErrBadConn, err)
+				}
+
+				value, ok := parse(notification.Payload)
+				if !ok {
+					continue
+				}
+				select {
+				case notifications <- value:
+				default:
+				}
+			}
+		})
+		stopped <- err
+		if ctx.Err() == nil {
+			xlog.Warn(ctx, "listener stopped", xfield.String("channel", channel), xfield.Error(err))
+		}
+	}()
+
+	select {
+	case err = <-listening:
+	case err = <-stopped:
+		// Raw failed before running the callback, e.g. on a closed connection
+	}
+	if err != nil {
+		xlog.Error(ctx, "failed to listen", xfield.String("channel", channel), xfield.Error(err))
+		return nil, err
+	}
+
+	return notifications, nil
+}
+
+// notifyTaskFinished wakes up the waiters of the task if it has reached a terminal status.
+// Inside a transaction the notification is delivered on commit. A failed notification is
+// only logged, the waiters poll the task anyway.
+func (s *Storage) notifyTaskFinished(ctx context.Context, taskID uuid.UUID, status entity.TaskStatus) {
+	if !slices.Contains(entity.TerminalStatuses(), status) {
+		return
+	}
+
+	query, args := postgres.SELECT(
+		postgres.Func("pg_notify", postgres.String(finishedTasksChannel), postgres.String(taskID.String())),
+	).Sql()
+	if _, err := s.db.Executor(ctx).ExecContext(ctx, query, args...); err != nil {
+		xlog.Warn(ctx, "failed to notify task finished", xfield.Error(err))
+	}
+}
+
+// notifyChannel returns the LISTEN/NOTIFY channel name for the task type.
+// Too long names are replaced by a hash to fit the identifier length limit.
+func notifyChannel(taskType entity.TaskType) string {
+	channel := notifyChannelPrefix + taskType
+	if len(channel) <= maxNotifyChannelLen {
+		return channel
+	}
+
+	sum := sha1.Sum([]byte(taskType)) //nolint:gosec // see import comment
+	return notifyChannelPrefix + hex.EncodeToString(sum[:])
+}
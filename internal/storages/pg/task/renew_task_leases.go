@@ -0,0 +1,57 @@
+package task
+
+import (
+	"context"
+
+	"github.com/go-jet/jet/v2/postgres"
+	"github.com/google/uuid"
+	"github.com/ruko1202/xlog"
+	"github.com/ruko1202/xlog/xfield"
+	"github.com/samber/lo"
+	semconv "go.opentelemetry.io/otel/semconv/v1.40.0"
+
+	"github.com/ruko1202/goque/internal/entity"
+	"github.com/ruko1202/goque/internal/pkg/generated/postgres/public/table"
+	"github.com/ruko1202/goque/internal/utils/xtime"
+)
+
+// RenewTaskLeases extends the lease of the tasks still owned by the lease owner.
+// Tasks that changed the owner or left statuses pending and processing are skipped.
+func (s *Storage) RenewTaskLeases(ctx context.Context, taskIDs []uuid.UUID, lease entity.TaskLease) error {
+	ctx, span := xlog.WithOperationSpan(ctx, "storage.RenewTaskLeases",
+		xfield.String("lease_owner", lease.Owner),
+		xfield.Int("tasks", len(taskIDs)),
+	)
+	span.SetAttributes(semconv.DBSystemNamePostgreSQL)
+	defer span.End()
+
+	if len(taskIDs) == 0 {
+		return nil
+	}
+
+	stmt := table.GoqueTask.
+		UPDATE(table.GoqueTask.LeaseExpiresAt).
+		SET(postgres.TimestampzT(xtime.Now().Add(lease.Duration))).
+		WHERE(
+			postgres.AND(
+				table.GoqueTask.ID.IN(lo.Map(taskIDs, func(item uuid.UUID, _ int) postgres.Expression {
+					return postgres.UUID(item)
+				})...),
+				table.GoqueTask.LockedBy.EQ(postgres.String(lease.Owner)),
+				table.GoqueTask.Status.IN(
+					postgres.String(entity.TaskStatusPending),
+					postgres.String(entity.TaskStatusProcessing),
+				),
+			),
+		)
+
+	query, args := stmt.Sql()
+
+	_, err := s.db.Executor(ctx).ExecContext(ctx, query, args...)
+	if err != nil {
+		xlog.Error(ctx, "failed to renew task leases", xfield.Error(err))
+		return err
+	}
+
+	return nil
+}
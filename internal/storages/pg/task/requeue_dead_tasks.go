@@ -0,0 +1,68 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ruko1202/xlog"
+	"github.com/ruko1202/xlog/xfield"
+	"github.com/samber/lo"
+	semconv "go.opentelemetry.io/otel/semconv/v1.40.0"
+
+	"github.com/ruko1202/goque/internal/entity"
+	"github.com/ruko1202/goque/internal/pkg/generated/postgres/public/model"
+	"github.com/ruko1202/goque/internal/storages/dbentity"
+	"github.com/ruko1202/goque/internal/storages/dbtx"
+	"github.com/ruko1202/goque/internal/utils/xtime"
+)
+
+// RequeueDeadTasks moves dead-lettered tasks matching the filter back to the queue
+// with status new and fresh attempts, and returns them.
+// Fails with ErrDuplicateTask if the queue already has a task with the same type and external ID.
+func (s *Storage) RequeueDeadTasks(ctx context.Context, filter *dbentity.DeadTasksFilter) ([]*entity.Task, error) {
+	ctx, span := xlog.WithOperationSpan(ctx, "storage.RequeueDeadTasks",
+		xfield.Any("filter", filter),
+	)
+	span.SetAttributes(semconv.DBSystemNamePostgreSQL)
+	defer span.End()
+
+	tasks := make([]*entity.Task, 0)
+	err := dbtx.EnsureTx(ctx, s.db.GetDB(), func(ctx context.Context) error {
+		dbDeadTasks, err := s.deleteDeadTasks(ctx, filter)
+		if err != nil {
+			return err
+		}
+
+		now := xtime.Now()
+		tasks = fromDeadDBModels(ctx, dbDeadTasks)
+		for _, task := range tasks {
+			task.Attempts = 0
+			task.Status = entity.TaskStatusNew
+			task.UpdatedAt = lo.ToPtr(now)
+			task.NextAttemptAt = now
+			task.Version++
+
+			taskErr := lo.FromPtr(task.Errors)
+			taskErr += fmt.Sprintf("requeued from dead letters: %s\n", now.Format(time.RFC3339))
+			task.Errors = &taskErr
+		}
+
+		dbTasks := lo.Map(tasks, func(task *entity.Task, _ int) *model.GoqueTask {
+			return toDBModel(ctx, task)
+		})
+		for _, chunk := range lo.Chunk(dbTasks, addTasksChunkSize) {
+			if _, err := s.insertTasks(ctx, chunk, false); err != nil {
+				return err
+			}
+		}
+
+		return notifyTasks(ctx, s.db.Executor(ctx).ExecContext, tasks)
+	})
+	if err := handleError(err); err != nil {
+		xlog.Error(ctx, "failed to requeue dead tasks", xfield.Error(err))
+		return nil, err
+	}
+
+	return tasks, nil
+}
@@ -0,0 +1,85 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-jet/jet/v2/postgres"
+	"github.com/ruko1202/xlog"
+	"github.com/ruko1202/xlog/xfield"
+	semconv "go.opentelemetry.io/otel/semconv/v1.40.0"
+
+	"github.com/ruko1202/goque/internal/entity"
+	"github.com/ruko1202/goque/internal/pkg/generated/postgres/public/table"
+	"github.com/ruko1202/goque/internal/storages/dbentity"
+	"github.com/ruko1202/goque/internal/storages/dbtx"
+	"github.com/ruko1202/goque/internal/utils/xtime"
+)
+
+// RetryTasks resets the attempts of tasks matching the filter and sets them back to status new
+// to be fetched right away, with a single UPDATE, clearing the result of done tasks and resetting the progress.
+// Tasks in flight (pending, processing) are skipped.
+// Returns the number of retried tasks.
+func (s *Storage) RetryTasks(ctx context.Context, filter *dbentity.GetTasksFilter) (int64, error) {
+	ctx, span := xlog.WithOperationSpan(ctx, "storage.RetryTasks",
+		xfield.Any("filter", filter),
+	)
+	span.SetAttributes(semconv.DBSystemNamePostgreSQL)
+	defer span.End()
+
+	whereExpr, err := filter.BindPgBulkWhereExpr()
+	if err != nil {
+		xlog.Error(ctx, "failed to bind filter", xfield.Error(err))
+		return 0, err
+	}
+
+	now := xtime.Now()
+	stmt := table.GoqueTask.
+		UPDATE(
+			table.GoqueTask.Status,
+			table.GoqueTask.Attempts,
+			table.GoqueTask.Errors,
+			table.GoqueTask.UpdatedAt,
+			table.GoqueTask.NextAttemptAt,
+			table.GoqueTask.Version,
+			table.GoqueTask.Result,
+			table.GoqueTask.Progress,
+		).
+		SET(
+			postgres.String(entity.TaskStatusNew),
+			postgres.Int32(0),
+			postgres.StringExp(postgres.COALESCE(table.GoqueTask.Errors, postgres.String(""))).
+				CONCAT(postgres.String(fmt.Sprintf("retry: %s\n", now.Format(time.RFC3339)))),
+			postgres.TimestampzT(now),
+			postgres.TimestampzT(now),
+			table.GoqueTask.Version.ADD(postgres.Int(1)),
+			postgres.NULL,
+			postgres.Int32(0),
+		).
+		WHERE(
+			whereExpr.AND(
+				table.GoqueTask.Status.NOT_IN(
+					postgres.String(entity.TaskStatusPending),
+					postgres.String(entity.TaskStatusProcessing),
+				),
+			),
+		).
+		RETURNING(table.GoqueTask.Type)
+
+	query, args := stmt.Sql()
+
+	taskTypes := make([]entity.TaskType, 0)
+	err = dbtx.EnsureTx(ctx, s.db.GetDB(), func(ctx context.Context) error {
+		if err := s.db.Executor(ctx).SelectContext(ctx, &taskTypes, query, args...); err != nil {
+			return err
+		}
+		return notifyTaskTypes(ctx, s.db.Executor(ctx).ExecContext, taskTypes)
+	})
+	if err != nil {
+		xlog.Error(ctx, "failed to retry tasks", xfield.Error(err))
+		return 0, err
+	}
+
+	return int64(len(taskTypes)), nil
+}
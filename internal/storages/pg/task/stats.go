@@ -0,0 +1,60 @@
+package task
+
+import (
+	"context"
+
+	"github.com/go-jet/jet/v2/postgres"
+	"github.com/ruko1202/xlog"
+	"github.com/ruko1202/xlog/xfield"
+	semconv "go.opentelemetry.io/otel/semconv/v1.40.0"
+
+	"github.com/ruko1202/goque/internal/entity"
+	"github.com/ruko1202/goque/internal/pkg/generated/postgres/public/table"
+	"github.com/ruko1202/goque/internal/storages/dbentity"
+)
+
+// Stats returns per-type task counts by status along with the queue lag,
+// the oldest processing task and the average progress of the processing tasks.
+// A nil filter takes all tasks into account.
+func (s *Storage) Stats(ctx context.Context, filter *dbentity.GetTasksFilter) ([]*entity.TaskStats, error) {
+	ctx, span := xlog.WithOperationSpan(ctx, "storage.Stats",
+		xfield.Any("filter", filter),
+	)
+	span.SetAttributes(semconv.DBSystemNamePostgreSQL)
+	defer span.End()
+
+	if filter == nil {
+		filter = &dbentity.GetTasksFilter{}
+	}
+
+	whereExpr, err := filter.BindPgWhereExpr()
+	if err != nil {
+		xlog.Error(ctx, "failed to bind filter", xfield.Error(err))
+		return nil, err
+	}
+
+	stmt := table.GoqueTask.
+		SELECT(
+			table.GoqueTask.Type,
+			table.GoqueTask.Status,
+			postgres.COUNT(postgres.STAR).AS("count"),
+			postgres.MIN(
+				postgres.CASE().WHEN(readyForProcessingExpr()).THEN(table.GoqueTask.NextAttemptAt),
+			).AS("oldest_runnable_at"),
+			postgres.MIN(table.GoqueTask.UpdatedAt).AS("oldest_updated_at"),
+			postgres.SUM(table.GoqueTask.Progress).AS("progress_sum"),
+		).
+		WHERE(whereExpr).
+		GROUP_BY(table.GoqueTask.Type, table.GoqueTask.Status)
+
+	query, args := stmt.Sql()
+
+	rows := make([]*dbentity.TaskStatsRow, 0)
+	err = s.db.Executor(ctx).SelectContext(ctx, &rows, query, args...)
+	if err != nil {
+		xlog.Error(ctx, "failed to get stats", xfield.Error(err))
+		return nil, err
+	}
+
+	return dbentity.BuildTaskStats(rows), nil
+}
@@ -9,7 +9,10 @@ import (
 	"github.com/ruko1202/goque/internal/storages"
 )
 
-var _ storages.Task = (*Storage)(nil)
+var (
+	_ storages.Task         = (*Storage)(nil)
+	_ storages.TaskListener = (*Storage)(nil)
+)
 
 // Storage handles database operations for tasks.
 type Storage struct {
@@ -0,0 +1,165 @@
+package task
+
+import (
+	"context"
+	"slices"
+
+	"github.com/go-jet/jet/v2/postgres"
+	"github.com/google/uuid"
+	"github.com/ruko1202/xlog"
+	"github.com/ruko1202/xlog/xfield"
+	"github.com/samber/lo"
+
+	"github.com/ruko1202/goque/internal/entity"
+	"github.com/ruko1202/goque/internal/pkg/generated/postgres/public/model"
+	"github.com/ruko1202/goque/internal/pkg/generated/postgres/public/table"
+	"github.com/ruko1202/goque/internal/utils/xtime"
+)
+
+// resolveFinishedTask resolves the dependents of the task that reached a terminal status
+// and completes the batches of the task and of its canceled dependents.
+func (s *Storage) resolveFinishedTask(ctx context.Context, taskID uuid.UUID, task *entity.Task) error {
+	if !task.IsInTerminalState() {
+		return nil
+	}
+
+	batchIDs, err := s.resolveDependents(ctx, taskID, task.Status)
+	if err != nil {
+		return err
+	}
+	if task.BatchID != nil {
+		batchIDs = append(batchIDs, *task.BatchID)
+	}
+
+	return s.completeBatches(ctx, batchIDs)
+}
+
+// completeBatches completes the batches left without unfinished tasks and releases their completion tasks.
+// The batches are locked first, so of the transactions finishing the last tasks of a batch concurrently
+// the one locking it last sees the tasks finished by the others and completes the batch exactly once.
+func (s *Storage) completeBatches(ctx context.Context, batchIDs []uuid.UUID) error {
+	batchIDs = lo.Uniq(batchIDs)
+	if len(batchIDs) == 0 {
+		return nil
+	}
+
+	ctx, span := xlog.WithOperationSpan(ctx, "storage.completeBatches",
+		xfield.Int("batches_count", len(batchIDs)),
+	)
+	defer span.End()
+
+	query, args := table.GoqueTaskBatch.
+		SELECT(table.GoqueTaskBatch.ID, table.GoqueTaskBatch.CompletionTaskID).
+		WHERE(
+			table.GoqueTaskBatch.ID.IN(lo.Map(batchIDs, func(batchID uuid.UUID, _ int) postgres.Expression {
+				return postgres.UUID(batchID)
+			})...).
+				AND(table.GoqueTaskBatch.CompletedAt.IS_NULL()),
+		).
+		ORDER_BY(table.GoqueTaskBatch.ID).
+		FOR(postgres.UPDATE()).
+		Sql()
+
+	batches := make([]*model.GoqueTaskBatch, 0, len(batchIDs))
+	if err := s.db.Executor(ctx).SelectContext(ctx, &batches, query, args...); err != nil {
+		return err
+	}
+	if len(batches) == 0 {
+		return nil
+	}
+
+	openIDs := lo.Map(batches, func(batch *model.GoqueTaskBatch, _ int) postgres.Expression {
+		return postgres.UUID(batch.ID)
+	})
+	query, args = table.GoqueTask.
+		SELECT(table.GoqueTask.BatchID).
+		DISTINCT().
+		WHERE(
+			table.GoqueTask.BatchID.IN(openIDs...).
+				AND(table.GoqueTask.Status.NOT_IN(lo.Map(entity.TerminalStatuses(), func(status string, _ int) postgres.Expression {
+					return postgres.String(status)
+				})...)),
+		).
+		Sql()
+
+	unfinishedIDs := make([]uuid.UUID, 0)
+	if err := s.db.Executor(ctx).SelectContext(ctx, &unfinishedIDs, query, args...); err != nil {
+		return err
+	}
+
+	completed := lo.Reject(batches, func(batch *model.GoqueTaskBatch, _ int) bool {
+		return slices.Contains(unfinishedIDs, batch.ID)
+	})
+	if len(completed) == 0 {
+		return nil
+	}
+
+	query, args = table.GoqueTaskBatch.
+		UPDATE(table.GoqueTaskBatch.CompletedAt).
+		SET(postgres.TimestampzT(xtime.Now())).
+		WHERE(table.GoqueTaskBatch.ID.IN(lo.Map(completed, func(batch *model.GoqueTaskBatch, _ int) postgres.Expression {
+			return postgres.UUID(batch.ID)
+		})...)).
+		Sql()
+	if _, err := s.db.Executor(ctx).ExecContext(ctx, query, args...); err != nil {
+		return err
+	}
+
+	return s.releaseCompletionTasks(ctx, lo.FilterMap(completed, func(batch *model.GoqueTaskBatch, _ int) (uuid.UUID, bool) {
+		return lo.FromPtr(batch.CompletionTaskID), batch.CompletionTaskID != nil
+	}))
+}
+
+// releaseCompletionTasks makes the completion tasks of the completed batches ready to run.
+// A completion task canceled in the meantime stays canceled.
+func (s *Storage) releaseCompletionTasks(ctx context.Context, taskIDs []uuid.UUID) error {
+	if len(taskIDs) == 0 {
+		return nil
+	}
+
+	query, args := table.GoqueTask.
+		UPDATE(
+			table.GoqueTask.Status,
+			table.GoqueTask.UpdatedAt,
+			table.GoqueTask.Version,
+		).
+		SET(
+			postgres.String(entity.TaskStatusNew),
+			postgres.TimestampzT(xtime.Now()),
+			table.GoqueTask.Version.ADD(postgres.Int(1)),
+		).
+		WHERE(
+			table.GoqueTask.ID.IN(lo.Map(taskIDs, func(taskID uuid.UUID, _ int) postgres.Expression {
+				return postgres.UUID(taskID)
+			})...).
+				AND(table.GoqueTask.Status.EQ(postgres.String(entity.TaskStatusWaiting))),
+		).
+		RETURNING(table.GoqueTask.Type).
+		Sql()
+
+	taskTypes := make([]entity.TaskType, 0)
+	if err := s.db.Executor(ctx).SelectContext(ctx, &taskTypes, query, args...); err != nil {
+		return err
+	}
+
+	return notifyTaskTypes(ctx, s.db.Executor(ctx).ExecContext, taskTypes)
+}
+
+// notifyTasksFinished wakes up the waiters of the tasks that reached a terminal status, see ListenFinishedTasks.
+func (s *Storage) notifyTasksFinished(ctx context.Context, taskIDs []uuid.UUID) error {
+	for _, chunk := range lo.Chunk(taskIDs, addTasksChunkSize) {
+		query, args := table.GoqueTask.
+			SELECT(
+				postgres.Func("pg_notify", postgres.String(finishedTasksChannel), postgres.CAST(table.GoqueTask.ID).AS_TEXT()),
+			).
+			WHERE(table.GoqueTask.ID.IN(lo.Map(chunk, func(taskID uuid.UUID, _ int) postgres.Expression {
+				return postgres.UUID(taskID)
+			})...)).
+			Sql()
+		if _, err := s.db.Executor(ctx).ExecContext(ctx, query, args...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,298 @@
+package task
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-jet/jet/v2/postgres"
+	"github.com/google/uuid"
+	"github.com/ruko1202/xlog"
+	"github.com/ruko1202/xlog/xfield"
+	"github.com/samber/lo"
+
+	"github.com/ruko1202/goque/internal/entity"
+	"github.com/ruko1202/goque/internal/pkg/generated/postgres/public/model"
+	"github.com/ruko1202/goque/internal/pkg/generated/postgres/public/table"
+	"github.com/ruko1202/goque/internal/storages/dbentity"
+	"github.com/ruko1202/goque/internal/storages/dbtx"
+	"github.com/ruko1202/goque/internal/utils/xtime"
+)
+
+// withTaskDependencies runs insert, which returns the IDs of the inserted tasks, and saves the dependencies
+// of the inserted tasks in the same transaction. Tasks without dependencies are just inserted.
+func (s *Storage) withTaskDependencies(ctx context.Context, tasks []*entity.Task, insert func(ctx context.Context) ([]uuid.UUID, error)) error {
+	if !lo.SomeBy(tasks, func(task *entity.Task) bool { return len(task.DependsOn) > 0 }) {
+		_, err := insert(ctx)
+		return err
+	}
+
+	return dbtx.EnsureTx(ctx, s.db.GetDB(), func(ctx context.Context) error {
+		dependencies, err := s.prepareTaskDependencies(ctx, tasks)
+		if err != nil {
+			return err
+		}
+
+		insertedIDs, err := insert(ctx)
+		if err != nil {
+			return err
+		}
+
+		dependencies, err = dbentity.InsertedTaskDependencies(dependencies, tasks, insertedIDs)
+		if err != nil {
+			return err
+		}
+
+		return s.addTaskDependencies(ctx, dependencies)
+	})
+}
+
+// prepareTaskDependencies checks the dependencies of the tasks about to be added and sets the task statuses,
+// see dbentity.BuildTaskDependencies. The tasks depended on are locked until the transaction ends,
+// so none of them finishes unnoticed by the added tasks.
+func (s *Storage) prepareTaskDependencies(ctx context.Context, tasks []*entity.Task) ([]dbentity.TaskDependency, error) {
+	ctx, span := xlog.WithOperationSpan(ctx, "storage.prepareTaskDependencies")
+	defer span.End()
+
+	statuses := make(map[uuid.UUID]entity.TaskStatus)
+	for _, chunk := range lo.Chunk(dbentity.ExternalTaskDependencies(tasks), addTasksChunkSize) {
+		query, args := table.GoqueTask.
+			SELECT(table.GoqueTask.ID, table.GoqueTask.Status).
+			WHERE(table.GoqueTask.ID.IN(lo.Map(chunk, func(taskID uuid.UUID, _ int) postgres.Expression {
+				return postgres.UUID(taskID)
+			})...)).
+			FOR(postgres.SHARE()).
+			Sql()
+
+		dbTasks := make([]*model.GoqueTask, 0, len(chunk))
+		if err := s.db.Executor(ctx).SelectContext(ctx, &dbTasks, query, args...); err != nil {
+			return nil, err
+		}
+		for _, dbTask := range dbTasks {
+			statuses[dbTask.ID] = dbTask.Status
+		}
+	}
+
+	return dbentity.BuildTaskDependencies(tasks, statuses)
+}
+
+func (s *Storage) addTaskDependencies(ctx context.Context, dependencies []dbentity.TaskDependency) error {
+	ctx, span := xlog.WithOperationSpan(ctx, "storage.addTaskDependencies",
+		xfield.Int("dependencies_count", len(dependencies)),
+	)
+	defer span.End()
+
+	for _, chunk := range lo.Chunk(dependencies, addTasksChunkSize) {
+		query, args := table.GoqueTaskDependency.
+			INSERT(table.GoqueTaskDependency.AllColumns).
+			MODELS(lo.Map(chunk, func(dependency dbentity.TaskDependency, _ int) model.GoqueTaskDependency {
+				return model.GoqueTaskDependency{
+					TaskID:          dependency.TaskID,
+					DependsOnTaskID: dependency.DependsOnTaskID,
+				}
+			})).
+			Sql()
+
+		if _, err := s.db.Executor(ctx).ExecContext(ctx, query, args...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// taskDependent is a dependency of a waiting task along with the batch of the task.
+type taskDependent struct {
+	model.GoqueTaskDependency
+	BatchID *uuid.UUID `db:"goque_task.batch_id"`
+}
+
+// resolveDependents releases or cancels the tasks waiting for the task that reached a terminal status
+// and returns the batches of the canceled tasks.
+func (s *Storage) resolveDependents(ctx context.Context, taskID uuid.UUID, status entity.TaskStatus) ([]uuid.UUID, error) {
+	switch status {
+	case entity.TaskStatusDone:
+		return nil, s.releaseDependents(ctx, taskID)
+	case entity.TaskStatusCanceled, entity.TaskStatusAttemptsLeft:
+		return s.cancelDependents(ctx, []uuid.UUID{taskID}, status)
+	default:
+		return nil, nil
+	}
+}
+
+// releaseDependents makes the tasks waiting only for the done task ready to run and drops its dependencies.
+func (s *Storage) releaseDependents(ctx context.Context, taskID uuid.UUID) error {
+	ctx, span := xlog.WithOperationSpan(ctx, "storage.releaseDependents",
+		xfield.String("task_id", taskID.String()),
+	)
+	defer span.End()
+
+	otherDependency := table.GoqueTaskDependency.AS("other_dependency")
+	query, args := table.GoqueTask.
+		UPDATE(
+			table.GoqueTask.Status,
+			table.GoqueTask.UpdatedAt,
+			table.GoqueTask.Version,
+		).
+		SET(
+			postgres.String(entity.TaskStatusNew),
+			postgres.TimestampzT(xtime.Now()),
+			table.GoqueTask.Version.ADD(postgres.Int(1)),
+		).
+		WHERE(postgres.AND(
+			table.GoqueTask.Status.EQ(postgres.String(entity.TaskStatusWaiting)),
+			table.GoqueTask.ID.IN(
+				table.GoqueTaskDependency.
+					SELECT(table.GoqueTaskDependency.TaskID).
+					WHERE(table.GoqueTaskDependency.DependsOnTaskID.EQ(postgres.UUID(taskID))),
+			),
+			postgres.NOT(postgres.EXISTS(
+				otherDependency.
+					SELECT(postgres.Int(1)).
+					WHERE(
+						otherDependency.TaskID.EQ(table.GoqueTask.ID).
+							AND(otherDependency.DependsOnTaskID.NOT_EQ(postgres.UUID(taskID))),
+					),
+			)),
+		)).
+		RETURNING(table.GoqueTask.Type).
+		Sql()
+
+	taskTypes := make([]entity.TaskType, 0)
+	if err := s.db.Executor(ctx).SelectContext(ctx, &taskTypes, query, args...); err != nil {
+		return err
+	}
+	if err := notifyTaskTypes(ctx, s.db.Executor(ctx).ExecContext, taskTypes); err != nil {
+		return err
+	}
+
+	return s.deleteTaskDependencies(ctx, table.GoqueTaskDependency.DependsOnTaskID.EQ(postgres.UUID(taskID)))
+}
+
+// cancelDependents cancels the tasks waiting for the tasks that were canceled or ran out of attempts,
+// then the tasks waiting for those and so on, drops their dependencies and returns the batches of the canceled tasks.
+func (s *Storage) cancelDependents(ctx context.Context, taskIDs []uuid.UUID, status entity.TaskStatus) ([]uuid.UUID, error) {
+	ctx, span := xlog.WithOperationSpan(ctx, "storage.cancelDependents",
+		xfield.Int("tasks_count", len(taskIDs)),
+	)
+	defer span.End()
+
+	batchIDs := make([]uuid.UUID, 0)
+	for len(taskIDs) > 0 {
+		ids := lo.Map(taskIDs, func(taskID uuid.UUID, _ int) postgres.Expression {
+			return postgres.UUID(taskID)
+		})
+
+		query, args := table.GoqueTaskDependency.
+			INNER_JOIN(table.GoqueTask, table.GoqueTask.ID.EQ(table.GoqueTaskDependency.TaskID)).
+			SELECT(table.GoqueTaskDependency.AllColumns, table.GoqueTask.BatchID).
+			WHERE(
+				table.GoqueTaskDependency.DependsOnTaskID.IN(ids...).
+					AND(table.GoqueTask.Status.EQ(postgres.String(entity.TaskStatusWaiting))),
+			).
+			FOR(postgres.UPDATE()).
+			Sql()
+
+		dependencies := make([]*taskDependent, 0)
+		if err := s.db.Executor(ctx).SelectContext(ctx, &dependencies, query, args...); err != nil {
+			return nil, err
+		}
+
+		// a dependent is canceled once, because of the first of its failed dependencies
+		dependencies = lo.UniqBy(dependencies, func(dependency *taskDependent) uuid.UUID {
+			return dependency.TaskID
+		})
+		dependents := lo.GroupBy(dependencies, func(dependency *taskDependent) uuid.UUID {
+			return dependency.DependsOnTaskID
+		})
+		for dependsOnID, group := range dependents {
+			err := s.cancelWaitingTasks(ctx, lo.Map(group, func(dependency *taskDependent, _ int) uuid.UUID {
+				return dependency.TaskID
+			}), fmt.Errorf("%w: task %s is %s", entity.ErrTaskDependencyFailed, dependsOnID, status))
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		canceledIDs := lo.Map(dependencies, func(dependency *taskDependent, _ int) uuid.UUID {
+			return dependency.TaskID
+		})
+		whereExpr := table.GoqueTaskDependency.DependsOnTaskID.IN(ids...)
+		if len(canceledIDs) > 0 {
+			whereExpr = whereExpr.OR(table.GoqueTaskDependency.TaskID.IN(lo.Map(canceledIDs, func(taskID uuid.UUID, _ int) postgres.Expression {
+				return postgres.UUID(taskID)
+			})...))
+		}
+		if err := s.deleteTaskDependencies(ctx, whereExpr); err != nil {
+			return nil, err
+		}
+
+		batchIDs = append(batchIDs, lo.FilterMap(dependencies, func(dependency *taskDependent, _ int) (uuid.UUID, bool) {
+			return lo.FromPtr(dependency.BatchID), dependency.BatchID != nil
+		})...)
+		taskIDs, status = canceledIDs, entity.TaskStatusCanceled
+	}
+
+	return lo.Uniq(batchIDs), nil
+}
+
+// cancelDependentsOfCanceledTasks cascades the cancellation of the tasks canceled in bulk to the tasks waiting for them
+// and returns the batches of the canceled tasks.
+// Only the dependencies on unfinished tasks are kept, so the dependencies on canceled tasks are the new ones.
+func (s *Storage) cancelDependentsOfCanceledTasks(ctx context.Context) ([]uuid.UUID, error) {
+	query, args := table.GoqueTaskDependency.
+		INNER_JOIN(table.GoqueTask, table.GoqueTask.ID.EQ(table.GoqueTaskDependency.DependsOnTaskID)).
+		SELECT(table.GoqueTaskDependency.DependsOnTaskID).
+		DISTINCT().
+		WHERE(table.GoqueTask.Status.EQ(postgres.String(entity.TaskStatusCanceled))).
+		Sql()
+
+	taskIDs := make([]uuid.UUID, 0)
+	if err := s.db.Executor(ctx).SelectContext(ctx, &taskIDs, query, args...); err != nil {
+		return nil, err
+	}
+
+	return s.cancelDependents(ctx, taskIDs, entity.TaskStatusCanceled)
+}
+
+func (s *Storage) cancelWaitingTasks(ctx context.Context, taskIDs []uuid.UUID, reason error) error {
+	canceledTask := &entity.Task{}
+	canceledTask.AddError(reason)
+
+	query, args := table.GoqueTask.
+		UPDATE(
+			table.GoqueTask.Status,
+			table.GoqueTask.Errors,
+			table.GoqueTask.UpdatedAt,
+			table.GoqueTask.Version,
+		).
+		SET(
+			postgres.String(entity.TaskStatusCanceled),
+			postgres.String(lo.FromPtr(canceledTask.Errors)),
+			postgres.TimestampzT(xtime.Now()),
+			table.GoqueTask.Version.ADD(postgres.Int(1)),
+		).
+		WHERE(
+			table.GoqueTask.ID.IN(lo.Map(taskIDs, func(taskID uuid.UUID, _ int) postgres.Expression {
+				return postgres.UUID(taskID)
+			})...).
+				AND(table.GoqueTask.Status.EQ(postgres.String(entity.TaskStatusWaiting))),
+		).
+		// wake up the waiters of the canceled tasks, see ListenFinishedTasks
+		RETURNING(
+			postgres.Func("pg_notify", postgres.String(finishedTasksChannel), postgres.CAST(table.GoqueTask.ID).AS_TEXT()),
+		).
+		Sql()
+
+	_, err := s.db.Executor(ctx).ExecContext(ctx, query, args...)
+	return err
+}
+
+func (s *Storage) deleteTaskDependencies(ctx context.Context, whereExpr postgres.BoolExpression) error {
+	query, args := table.GoqueTaskDependency.
+		DELETE().
+		WHERE(whereExpr).
+		Sql()
+
+	_, err := s.db.Executor(ctx).ExecContext(ctx, query, args...)
+	return err
+}
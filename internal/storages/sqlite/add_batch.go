@@ -0,0 +1,47 @@
+package sqlite
+
+import (
+	"context"
+	"slices"
+
+	"github.com/ruko1202/xlog"
+	"github.com/ruko1202/xlog/xfield"
+
+	"github.com/ruko1202/goque/internal/entity"
+	"github.com/ruko1202/goque/internal/pkg/generated/sqlite3/table"
+	"github.com/ruko1202/goque/internal/storages/dbtx"
+)
+
+// AddBatch inserts the batch along with its tasks and the completion task, if any, atomically.
+// The tasks are added as AddTasks does without skipping duplicates.
+func (s *Storage) AddBatch(ctx context.Context, batch *entity.Batch, tasks []*entity.Task, onComplete *entity.Task) error {
+	ctx, span := xlog.WithOperationSpan(ctx, "storage.AddBatch",
+		xfield.String("db.type", "sqlite"),
+		xfield.String("batch_id", batch.ID.String()),
+		xfield.Int("tasks_count", len(tasks)),
+	)
+	defer span.End()
+
+	batchTasks := slices.Clone(tasks)
+	if onComplete != nil {
+		batchTasks = append(batchTasks, onComplete)
+	}
+
+	err := dbtx.EnsureTx(ctx, s.db.GetDB(), func(ctx context.Context) error {
+		query, args := table.GoqueTaskBatch.
+			INSERT(table.GoqueTaskBatch.AllColumns).
+			MODEL(batchToDBModel(batch)).
+			Sql()
+		if _, err := s.db.Executor(ctx).ExecContext(ctx, query, args...); err != nil {
+			return err
+		}
+
+		return s.AddTasks(ctx, batchTasks, false)
+	})
+	if err != nil {
+		xlog.Error(ctx, "failed to add batch", xfield.Error(err))
+		return err
+	}
+
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"strings"
 
+	"github.com/google/uuid"
 	"github.com/ruko1202/xlog"
 	"github.com/ruko1202/xlog/xfield"
 
@@ -12,7 +13,8 @@ import (
 	"github.com/ruko1202/goque/internal/storages/dbutils"
 )
 
-// AddTask inserts a new task into the database.
+// AddTask inserts a new task into the database. A task with dependencies is saved along with them,
+// see dbentity.BuildTaskDependencies.
 func (s *Storage) AddTask(ctx context.Context, task *entity.Task) error {
 	ctx, span := xlog.WithOperationSpan(ctx, "storage.AddTask",
 		xfield.String("db.type", "sqlite"),
@@ -26,15 +28,16 @@ func (s *Storage) AddTask(ctx context.Context, task *entity.Task) error {
 		return entity.ErrInvalidPayloadFormat
 	}
 
-	dbTask := toDBModel(ctx, task)
+	err := s.withTaskDependencies(ctx, []*entity.Task{task}, func(ctx context.Context) ([]uuid.UUID, error) {
+		stmt := table.GoqueTask.
+			INSERT(table.GoqueTask.AllColumns).
+			MODEL(toDBModel(ctx, task))
 
-	stmt := table.GoqueTask.
-		INSERT(table.GoqueTask.AllColumns).
-		MODEL(dbTask)
+		query, args := stmt.Sql()
 
-	query, args := stmt.Sql()
-
-	_, err := s.db.Executor(ctx).ExecContext(ctx, query, args...)
+		_, err := s.db.Executor(ctx).ExecContext(ctx, query, args...)
+		return []uuid.UUID{task.ID}, err
+	})
 	if err := handleError(err); err != nil {
 		xlog.Error(ctx, "failed to add task", xfield.Error(err))
 		return err
@@ -0,0 +1,74 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/ruko1202/xlog"
+	"github.com/ruko1202/xlog/xfield"
+	"github.com/samber/lo"
+
+	"github.com/ruko1202/goque/internal/entity"
+	"github.com/ruko1202/goque/internal/pkg/generated/sqlite3/table"
+	"github.com/ruko1202/goque/internal/storages/dbentity"
+	"github.com/ruko1202/goque/internal/storages/dbutils"
+)
+
+// AddTaskOrGet inserts the task unless a task with the same type and external ID exists.
+// Returns the inserted task and true, or the already existing task and false.
+func (s *Storage) AddTaskOrGet(ctx context.Context, task *entity.Task) (*entity.Task, bool, error) {
+	ctx, span := xlog.WithOperationSpan(ctx, "storage.AddTaskOrGet",
+		xfield.String("db.type", "sqlite"),
+		xfield.String("task_id", task.ID.String()),
+		xfield.String("task_type", task.Type),
+		xfield.String("external_id", task.ExternalID),
+	)
+	defer span.End()
+
+	// Validate JSON payload before insertion
+	if !dbutils.IsValidJSON(task.Payload) {
+		return nil, false, entity.ErrInvalidPayloadFormat
+	}
+
+	ids := make([]string, 0, 1)
+	err := s.withTaskDependencies(ctx, []*entity.Task{task}, func(ctx context.Context) ([]uuid.UUID, error) {
+		query, args := table.GoqueTask.
+			INSERT(table.GoqueTask.AllColumns).
+			MODEL(toDBModel(ctx, task)).
+			ON_CONFLICT(table.GoqueTask.Type, table.GoqueTask.ExternalID).
+			DO_NOTHING().
+			RETURNING(table.GoqueTask.ID).
+			Sql()
+
+		if err := s.db.Executor(ctx).SelectContext(ctx, &ids, query, args...); err != nil || len(ids) == 0 {
+			return nil, err
+		}
+		return []uuid.UUID{task.ID}, nil
+	})
+	if err := handleError(err); err != nil {
+		xlog.Error(ctx, "failed to add task", xfield.Error(err))
+		return nil, false, err
+	}
+	if len(ids) > 0 {
+		return task, true, nil
+	}
+
+	existing, err := s.getTasksByFilter(ctx, &dbentity.GetTasksFilter{
+		TaskType:   lo.ToPtr(task.Type),
+		ExternalID: lo.ToPtr(task.ExternalID),
+	}, 1)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(existing) == 0 {
+		return nil, false, fmt.Errorf("%w: existing task is not found", entity.ErrDuplicateTask)
+	}
+
+	existingTask, err := fromDBModel(ctx, existing[0])
+	if err != nil {
+		return nil, false, err
+	}
+
+	return existingTask, false, nil
+}
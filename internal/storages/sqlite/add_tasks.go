@@ -0,0 +1,103 @@
+package sqlite
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/ruko1202/xlog"
+	"github.com/ruko1202/xlog/xfield"
+	"github.com/samber/lo"
+
+	"github.com/ruko1202/goque/internal/entity"
+	"github.com/ruko1202/goque/internal/pkg/generated/sqlite3/model"
+	"github.com/ruko1202/goque/internal/pkg/generated/sqlite3/table"
+	"github.com/ruko1202/goque/internal/storages/dbtx"
+	"github.com/ruko1202/goque/internal/storages/dbutils"
+)
+
+// addTasksChunkSize bounds the number of rows in a single INSERT statement,
+// keeping the bound parameters below SQLITE_MAX_VARIABLE_NUMBER.
+const addTasksChunkSize = 500
+
+// AddTasks inserts tasks into the database atomically.
+//
+// Payloads are validated first; if any is invalid nothing is inserted and
+// *entity.TasksError lists the invalid tasks.
+//
+// Without skipDuplicates a duplicate fails the whole batch with ErrDuplicateTask.
+// With skipDuplicates duplicates are skipped (ON CONFLICT DO NOTHING), the rest is
+// inserted and *entity.TasksError lists the skipped tasks with ErrDuplicateTask.
+//
+// Tasks with dependencies are saved along with them, see dbentity.BuildTaskDependencies.
+func (s *Storage) AddTasks(ctx context.Context, tasks []*entity.Task, skipDuplicates bool) error {
+	ctx, span := xlog.WithOperationSpan(ctx, "storage.AddTasks",
+		xfield.String("db.type", "sqlite"),
+		xfield.Int("tasks_count", len(tasks)),
+	)
+	defer span.End()
+
+	if err := dbutils.ValidateTasksPayload(tasks); err != nil {
+		return err
+	}
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	insertedIDs := make([]string, 0, len(tasks))
+	err := dbtx.EnsureTx(ctx, s.db.GetDB(), func(ctx context.Context) error {
+		return s.withTaskDependencies(ctx, tasks, func(ctx context.Context) ([]uuid.UUID, error) {
+			dbTasks := lo.Map(tasks, func(task *entity.Task, _ int) *model.GoqueTask {
+				return toDBModel(ctx, task)
+			})
+			for _, chunk := range lo.Chunk(dbTasks, addTasksChunkSize) {
+				ids, err := s.insertTasks(ctx, chunk, skipDuplicates)
+				if err != nil {
+					return nil, err
+				}
+				insertedIDs = append(insertedIDs, ids...)
+			}
+
+			inserted := lo.Keyify(insertedIDs)
+			return lo.FilterMap(tasks, func(task *entity.Task, _ int) (uuid.UUID, bool) {
+				_, ok := inserted[task.ID.String()]
+				return task.ID, ok
+			}), nil
+		})
+	})
+	if err := handleError(err); err != nil {
+		xlog.Error(ctx, "failed to add tasks", xfield.Error(err))
+		return err
+	}
+
+	inserted := lo.Keyify(insertedIDs)
+	tasksErr := entity.NewTasksError()
+	for _, task := range tasks {
+		if _, ok := inserted[task.ID.String()]; !ok {
+			tasksErr.Add(task.ID, entity.ErrDuplicateTask)
+		}
+	}
+
+	return tasksErr.ErrOrNil()
+}
+
+func (s *Storage) insertTasks(ctx context.Context, tasks []*model.GoqueTask, skipDuplicates bool) ([]string, error) {
+	ctx, span := xlog.WithOperationSpan(ctx, "storage.insertTasks")
+	defer span.End()
+
+	stmt := table.GoqueTask.
+		INSERT(table.GoqueTask.AllColumns).
+		MODELS(tasks)
+	if skipDuplicates {
+		stmt = stmt.ON_CONFLICT().DO_NOTHING()
+	}
+	stmt = stmt.RETURNING(table.GoqueTask.ID)
+
+	query, args := stmt.Sql()
+
+	ids := make([]string, 0, len(tasks))
+	if err := s.db.Executor(ctx).SelectContext(ctx, &ids, query, args...); err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/ruko1202/xlog/xfield"
 	"github.com/samber/lo"
 
+	"github.com/ruko1202/goque/internal/storages/dbutils"
 	"github.com/ruko1202/goque/internal/utils/goquectx"
 
 	"github.com/ruko1202/goque/internal/entity"
@@ -26,18 +27,34 @@ func toDBModel(ctx context.Context, task *entity.Task) *model.GoqueTask {
 	if task.UpdatedAt != nil {
 		updatedAt = lo.ToPtr(timeToString(lo.FromPtr(task.UpdatedAt)))
 	}
+	var leaseExpiresAt *string
+	if task.LeaseExpiresAt != nil {
+		leaseExpiresAt = lo.ToPtr(timeToString(lo.FromPtr(task.LeaseExpiresAt)))
+	}
 	return &model.GoqueTask{
-		ID:            lo.ToPtr(task.ID.String()),
-		Type:          task.Type,
-		ExternalID:    task.ExternalID,
-		Payload:       task.Payload,
-		Status:        task.Status,
-		Attempts:      task.Attempts,
-		Errors:        task.Errors,
-		Metadata:      lo.ToPtr(metadata.ToJSON(ctx)),
-		CreatedAt:     timeToString(task.CreatedAt),
-		UpdatedAt:     updatedAt,
-		NextAttemptAt: timeToString(task.NextAttemptAt),
+		ID:             lo.ToPtr(task.ID.String()),
+		Type:           task.Type,
+		ExternalID:     task.ExternalID,
+		Payload:        task.Payload,
+		Priority:       task.Priority,
+		Status:         task.Status,
+		Attempts:       task.Attempts,
+		Errors:         task.Errors,
+		Metadata:       lo.ToPtr(metadata.ToJSON(ctx)),
+		CreatedAt:      timeToString(task.CreatedAt),
+		UpdatedAt:      updatedAt,
+		NextAttemptAt:  timeToString(task.NextAttemptAt),
+		LockedBy:       task.LockedBy,
+		LeaseExpiresAt: leaseExpiresAt,
+		Version:        task.Version,
+		TimeoutMs:      dbutils.DurationToMs(task.Timeout),
+		RetryDelayMs:   dbutils.DurationToMs(task.RetryDelay),
+		MaxAttempts:    task.MaxAttempts,
+		ConcurrencyKey: task.ConcurrencyKey,
+		Result:         task.Result,
+		Checkpoint:     task.Checkpoint,
+		Progress:       task.Progress,
+		BatchID:        dbutils.UUIDToString(task.BatchID),
 	}
 }
 
@@ -46,23 +63,43 @@ func fromDBModel(ctx context.Context, task *model.GoqueTask) (*entity.Task, erro
 	if err != nil {
 		return nil, fmt.Errorf("parse task id: %w", err)
 	}
+	batchID, err := dbutils.UUIDFromString(task.BatchID)
+	if err != nil {
+		return nil, fmt.Errorf("parse batch id: %w", err)
+	}
 	var updatedAt *time.Time
 	if task.UpdatedAt != nil {
 		updatedAt = lo.ToPtr(timeFromString(lo.FromPtr(task.UpdatedAt)))
 	}
+	var leaseExpiresAt *time.Time
+	if task.LeaseExpiresAt != nil {
+		leaseExpiresAt = lo.ToPtr(timeFromString(lo.FromPtr(task.LeaseExpiresAt)))
+	}
 
 	return &entity.Task{
-		ID:            id,
-		Type:          task.Type,
-		ExternalID:    task.ExternalID,
-		Payload:       task.Payload,
-		Status:        task.Status,
-		Attempts:      task.Attempts,
-		Errors:        task.Errors,
-		Metadata:      entity.NewMetadataFromJSON(ctx, task.Metadata),
-		CreatedAt:     timeFromString(task.CreatedAt),
-		UpdatedAt:     updatedAt,
-		NextAttemptAt: timeFromString(task.NextAttemptAt),
+		ID:             id,
+		Type:           task.Type,
+		ExternalID:     task.ExternalID,
+		Payload:        task.Payload,
+		Priority:       task.Priority,
+		Status:         task.Status,
+		Attempts:       task.Attempts,
+		Errors:         task.Errors,
+		Metadata:       entity.NewMetadataFromJSON(ctx, task.Metadata),
+		CreatedAt:      timeFromString(task.CreatedAt),
+		UpdatedAt:      updatedAt,
+		NextAttemptAt:  timeFromString(task.NextAttemptAt),
+		LockedBy:       task.LockedBy,
+		LeaseExpiresAt: leaseExpiresAt,
+		Version:        task.Version,
+		Timeout:        dbutils.DurationFromMs(task.TimeoutMs),
+		RetryDelay:     dbutils.DurationFromMs(task.RetryDelayMs),
+		MaxAttempts:    task.MaxAttempts,
+		ConcurrencyKey: task.ConcurrencyKey,
+		Result:         task.Result,
+		Checkpoint:     task.Checkpoint,
+		Progress:       task.Progress,
+		BatchID:        batchID,
 	}, nil
 }
 
@@ -102,3 +139,40 @@ func timeFromString(value string) time.Time {
 
 	return t
 }
+
+func batchToDBModel(batch *entity.Batch) *model.GoqueTaskBatch {
+	var completedAt *string
+	if batch.CompletedAt != nil {
+		completedAt = lo.ToPtr(timeToString(lo.FromPtr(batch.CompletedAt)))
+	}
+	return &model.GoqueTaskBatch{
+		ID:               lo.ToPtr(batch.ID.String()),
+		Total:            batch.Total,
+		CompletionTaskID: dbutils.UUIDToString(batch.CompletionTaskID),
+		CreatedAt:        timeToString(batch.CreatedAt),
+		CompletedAt:      completedAt,
+	}
+}
+
+func batchFromDBModel(batch *model.GoqueTaskBatch) (*entity.Batch, error) {
+	id, err := uuid.Parse(lo.FromPtr(batch.ID))
+	if err != nil {
+		return nil, fmt.Errorf("parse batch id: %w", err)
+	}
+	completionTaskID, err := dbutils.UUIDFromString(batch.CompletionTaskID)
+	if err != nil {
+		return nil, fmt.Errorf("parse completion task id: %w", err)
+	}
+	var completedAt *time.Time
+	if batch.CompletedAt != nil {
+		completedAt = lo.ToPtr(timeFromString(lo.FromPtr(batch.CompletedAt)))
+	}
+
+	return &entity.Batch{
+		ID:               id,
+		Total:            batch.Total,
+		CompletionTaskID: completionTaskID,
+		CreatedAt:        timeFromString(batch.CreatedAt),
+		CompletedAt:      completedAt,
+	}, nil
+}
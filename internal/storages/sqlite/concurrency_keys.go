@@ -0,0 +1,74 @@
+package sqlite
+
+import (
+	"context"
+
+	"github.com/go-jet/jet/v2/sqlite"
+	"github.com/ruko1202/xlog"
+	"github.com/samber/lo"
+
+	"github.com/ruko1202/goque/internal/entity"
+	"github.com/ruko1202/goque/internal/pkg/generated/sqlite3/model"
+	"github.com/ruko1202/goque/internal/pkg/generated/sqlite3/table"
+	"github.com/ruko1202/goque/internal/storages/dbentity"
+)
+
+// concurrencyKeyUnderLimitExpr matches tasks without a concurrency key or whose key
+// has less than limit tasks of any type in flight.
+func concurrencyKeyUnderLimitExpr(limit int64) sqlite.BoolExpression {
+	inFlight := table.GoqueTask.AS("in_flight")
+
+	return sqlite.OR(
+		table.GoqueTask.ConcurrencyKey.IS_NULL(),
+		sqlite.IntExp(
+			sqlite.SELECT(sqlite.COUNT(sqlite.STAR)).
+				FROM(inFlight).
+				WHERE(sqlite.AND(
+					inFlight.ConcurrencyKey.EQ(table.GoqueTask.ConcurrencyKey),
+					inFlightExpr(inFlight),
+				)),
+		).LT(sqlite.Int(limit)),
+	)
+}
+
+// inFlightExpr matches fetched tasks not finished yet.
+func inFlightExpr(tbl *table.GoqueTaskTable) sqlite.BoolExpression {
+	return tbl.Status.IN(
+		sqlite.String(entity.TaskStatusPending),
+		sqlite.String(entity.TaskStatusProcessing),
+	)
+}
+
+// limitTasksPerConcurrencyKey drops the fetched tasks that would take their key over the limit.
+func (s *Storage) limitTasksPerConcurrencyKey(ctx context.Context, tasks []*model.GoqueTask, limit int64) ([]*model.GoqueTask, error) {
+	ctx, span := xlog.WithOperationSpan(ctx, "storage.limitTasksPerConcurrencyKey")
+	defer span.End()
+
+	concurrencyKey := func(task *model.GoqueTask) *string { return task.ConcurrencyKey }
+	keys := dbentity.ConcurrencyKeys(tasks, concurrencyKey)
+	if len(keys) == 0 {
+		return tasks, nil
+	}
+
+	stmt := table.GoqueTask.
+		SELECT(
+			table.GoqueTask.ConcurrencyKey,
+			sqlite.COUNT(sqlite.STAR).AS("count"),
+		).
+		WHERE(sqlite.AND(
+			table.GoqueTask.ConcurrencyKey.IN(lo.Map(keys, func(key string, _ int) sqlite.Expression {
+				return sqlite.String(key)
+			})...),
+			inFlightExpr(table.GoqueTask),
+		)).
+		GROUP_BY(table.GoqueTask.ConcurrencyKey)
+
+	query, args := stmt.Sql()
+
+	rows := make([]*dbentity.ConcurrencyKeyCountRow, 0)
+	if err := s.db.Executor(ctx).SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, err
+	}
+
+	return dbentity.LimitTasksPerConcurrencyKey(tasks, concurrencyKey, rows, limit), nil
+}
@@ -15,11 +15,12 @@ import (
 	"github.com/ruko1202/goque/internal/entity"
 	"github.com/ruko1202/goque/internal/pkg/generated/sqlite3/model"
 	"github.com/ruko1202/goque/internal/pkg/generated/sqlite3/table"
-	"github.com/ruko1202/goque/internal/storages/dbentity"
 	"github.com/ruko1202/goque/internal/utils/xtime"
 )
 
 // CureTasks updates stuck tasks to error status for retry.
+// A task is stuck when its lease has expired, or, for a task fetched without a lease,
+// when it was not updated for updatedAtTimeAgo.
 func (s *Storage) CureTasks(
 	ctx context.Context,
 	taskType entity.TaskType,
@@ -37,11 +38,7 @@ func (s *Storage) CureTasks(
 	tasks := make([]*model.GoqueTask, 0)
 	err := dbtx.WithinTx(ctx, s.db.GetDB(), func(ctx context.Context) error {
 		var err error
-		tasks, err = s.getTasksByFilter(ctx, &dbentity.GetTasksFilter{
-			TaskType:         lo.ToPtr(taskType),
-			Statuses:         statuses,
-			UpdatedAtTimeAgo: lo.ToPtr(updatedAtTimeAgo),
-		}, 1000)
+		tasks, err = s.getStuckTasks(ctx, taskType, statuses, updatedAtTimeAgo, 1000)
 		if err != nil {
 			return err
 		}
@@ -56,6 +53,48 @@ func (s *Storage) CureTasks(
 	return fromDBModels(ctx, tasks)
 }
 
+func (s *Storage) getStuckTasks(
+	ctx context.Context,
+	taskType entity.TaskType,
+	statuses []entity.TaskStatus,
+	updatedAtTimeAgo time.Duration,
+	limit int64,
+) ([]*model.GoqueTask, error) {
+	ctx, span := xlog.WithOperationSpan(ctx, "storage.getStuckTasks")
+	defer span.End()
+
+	now := xtime.Now()
+	stmt := table.GoqueTask.
+		SELECT(table.GoqueTask.AllColumns).
+		WHERE(
+			sqlite.AND(
+				table.GoqueTask.Type.EQ(sqlite.String(taskType)),
+				table.GoqueTask.Status.IN(lo.Map(statuses, func(item entity.TaskStatus, _ int) sqlite.Expression {
+					return sqlite.String(item)
+				})...),
+				sqlite.OR(
+					sqlite.DATETIME(table.GoqueTask.LeaseExpiresAt).LT_EQ(sqlite.DATETIME(now)),
+					sqlite.AND(
+						table.GoqueTask.LeaseExpiresAt.IS_NULL(),
+						sqlite.DATETIME(table.GoqueTask.UpdatedAt).LT_EQ(sqlite.DATETIME(now.Add(-updatedAtTimeAgo.Abs()))),
+					),
+				),
+			),
+		).
+		ORDER_BY(sqlite.DATETIME(table.GoqueTask.CreatedAt).ASC()).
+		LIMIT(limit)
+
+	query, args := stmt.Sql()
+
+	tasks := make([]*model.GoqueTask, 0)
+	err := s.db.Executor(ctx).SelectContext(ctx, &tasks, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
 func (s *Storage) cureTask(ctx context.Context, tasks []*model.GoqueTask, comment string) error {
 	ctx, span := xlog.WithOperationSpan(ctx, "storage.cureTask")
 	defer span.End()
@@ -70,6 +109,7 @@ func (s *Storage) cureTask(ctx context.Context, tasks []*model.GoqueTask, commen
 			table.GoqueTask.Status,
 			table.GoqueTask.Errors,
 			table.GoqueTask.UpdatedAt,
+			table.GoqueTask.Version,
 		).
 		SET(
 			sqlite.String(entity.TaskStatusError),
@@ -81,6 +121,7 @@ func (s *Storage) cureTask(ctx context.Context, tasks []*model.GoqueTask, commen
 				CONCAT(table.GoqueTask.Attempts).
 				CONCAT(sqlite.String(fmt.Sprintf(": %s\n", comment))),
 			sqlite.String(timeToString(xtime.Now())),
+			table.GoqueTask.Version.ADD(sqlite.Int(1)),
 		).
 		WHERE(
 			table.GoqueTask.ID.IN(lo.Map(tasks, func(item *model.GoqueTask, _ int) sqlite.Expression {
@@ -0,0 +1,48 @@
+package sqlite
+
+import (
+	"context"
+
+	"github.com/ruko1202/xlog"
+	"github.com/ruko1202/xlog/xfield"
+
+	"github.com/ruko1202/goque/internal/entity"
+	"github.com/ruko1202/goque/internal/pkg/generated/sqlite3/model"
+	"github.com/ruko1202/goque/internal/pkg/generated/sqlite3/table"
+	"github.com/ruko1202/goque/internal/storages/dbentity"
+)
+
+// DeleteDeadTasks purges dead-lettered tasks matching the filter and returns them.
+func (s *Storage) DeleteDeadTasks(ctx context.Context, filter *dbentity.DeadTasksFilter) ([]*entity.Task, error) {
+	ctx, span := xlog.WithOperationSpan(ctx, "storage.DeleteDeadTasks",
+		xfield.String("db.type", "sqlite"),
+		xfield.Any("filter", filter),
+	)
+	defer span.End()
+
+	dbTasks, err := s.deleteDeadTasks(ctx, filter)
+	if err != nil {
+		xlog.Error(ctx, "failed to delete dead tasks", xfield.Error(err))
+		return nil, err
+	}
+
+	return fromDeadDBModels(ctx, dbTasks)
+}
+
+func (s *Storage) deleteDeadTasks(ctx context.Context, filter *dbentity.DeadTasksFilter) ([]*model.GoqueTaskDead, error) {
+	ctx, span := xlog.WithOperationSpan(ctx, "storage.deleteDeadTasks")
+	defer span.End()
+
+	stmt := table.GoqueTaskDead.DELETE().
+		WHERE(filter.BindSqliteWhereExpr()).
+		RETURNING(table.GoqueTaskDead.AllColumns)
+
+	query, args := stmt.Sql()
+
+	dbTasks := make([]*model.GoqueTaskDead, 0)
+	if err := s.db.Executor(ctx).SelectContext(ctx, &dbTasks, query, args...); err != nil {
+		return nil, err
+	}
+
+	return dbTasks, nil
+}
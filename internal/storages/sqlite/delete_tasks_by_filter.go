@@ -0,0 +1,39 @@
+package sqlite
+
+import (
+	"context"
+
+	"github.com/ruko1202/xlog"
+	"github.com/ruko1202/xlog/xfield"
+
+	"github.com/ruko1202/goque/internal/pkg/generated/sqlite3/table"
+	"github.com/ruko1202/goque/internal/storages/dbentity"
+)
+
+// DeleteTasksByFilter removes tasks matching the filter with a single DELETE
+// and returns the number of deleted tasks.
+func (s *Storage) DeleteTasksByFilter(ctx context.Context, filter *dbentity.GetTasksFilter) (int64, error) {
+	ctx, span := xlog.WithOperationSpan(ctx, "storage.DeleteTasksByFilter",
+		xfield.String("db.type", "sqlite"),
+		xfield.Any("filter", filter),
+	)
+	defer span.End()
+
+	whereExpr, err := filter.BindSqliteBulkWhereExpr()
+	if err != nil {
+		xlog.Error(ctx, "failed to bind filter", xfield.Error(err))
+		return 0, err
+	}
+
+	query, args := table.GoqueTask.DELETE().
+		WHERE(whereExpr).
+		Sql()
+
+	res, err := s.db.Executor(ctx).ExecContext(ctx, query, args...)
+	if err != nil {
+		xlog.Error(ctx, "failed to delete tasks", xfield.Error(err))
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
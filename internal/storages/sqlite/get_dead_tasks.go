@@ -0,0 +1,53 @@
+package sqlite
+
+import (
+	"context"
+
+	"github.com/go-jet/jet/v2/sqlite"
+	"github.com/ruko1202/xlog"
+	"github.com/ruko1202/xlog/xfield"
+
+	"github.com/ruko1202/goque/internal/entity"
+	"github.com/ruko1202/goque/internal/pkg/generated/sqlite3/model"
+	"github.com/ruko1202/goque/internal/pkg/generated/sqlite3/table"
+	"github.com/ruko1202/goque/internal/storages/dbentity"
+)
+
+// GetDeadTasks retrieves dead-lettered tasks matching the filter, the most recently moved first.
+func (s *Storage) GetDeadTasks(ctx context.Context, filter *dbentity.DeadTasksFilter, limit int64) ([]*entity.Task, error) {
+	ctx, span := xlog.WithOperationSpan(ctx, "storage.GetDeadTasks",
+		xfield.String("db.type", "sqlite"),
+		xfield.Any("filter", filter),
+	)
+	defer span.End()
+
+	stmt := table.GoqueTaskDead.
+		SELECT(table.GoqueTaskDead.AllColumns).
+		WHERE(filter.BindSqliteWhereExpr()).
+		ORDER_BY(sqlite.DATETIME(table.GoqueTaskDead.UpdatedAt).DESC(), table.GoqueTaskDead.ID.DESC()).
+		LIMIT(limit)
+
+	query, args := stmt.Sql()
+
+	dbTasks := make([]*model.GoqueTaskDead, 0)
+	err := s.db.Executor(ctx).SelectContext(ctx, &dbTasks, query, args...)
+	if err != nil {
+		xlog.Error(ctx, "failed to get dead tasks", xfield.Error(err))
+		return nil, err
+	}
+
+	return fromDeadDBModels(ctx, dbTasks)
+}
+
+func fromDeadDBModels(ctx context.Context, dbTasks []*model.GoqueTaskDead) ([]*entity.Task, error) {
+	tasks := make([]*entity.Task, 0, len(dbTasks))
+	for _, dbTask := range dbTasks {
+		task, err := fromDBModel(ctx, (*model.GoqueTask)(dbTask))
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+
+	return tasks, nil
+}
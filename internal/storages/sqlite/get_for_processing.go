@@ -15,23 +15,39 @@ import (
 	"github.com/ruko1202/goque/internal/utils/xtime"
 )
 
-// GetTasksForProcessing retrieves and locks tasks ready for processing, updating their status to pending.
-func (s *Storage) GetTasksForProcessing(ctx context.Context, taskType entity.TaskType, limit int64) ([]*entity.Task, error) {
+// GetTasksForProcessing retrieves and locks tasks ready for processing, updating their status to pending
+// and stamping them with the lease. A positive concurrencyLimitPerKey skips the tasks whose concurrency key
+// already has that many tasks of any type in flight. SQLite serializes writers, so no extra lock is taken.
+func (s *Storage) GetTasksForProcessing(
+	ctx context.Context,
+	taskType entity.TaskType,
+	limit int64,
+	lease entity.TaskLease,
+	concurrencyLimitPerKey int64,
+) ([]*entity.Task, error) {
 	ctx, span := xlog.WithOperationSpan(ctx, "storage.GetTasksForProcessing",
 		xfield.String("db.type", "sqlite"),
 		xfield.String("task_type", taskType),
+		xfield.String("lease_owner", lease.Owner),
 	)
 	defer span.End()
 
 	var tasks []*model.GoqueTask
 	err := dbtx.WithinTx(ctx, s.db.GetDB(), func(ctx context.Context) error {
 		var err error
-		tasks, err = s.getTasksForProcessing(ctx, taskType, limit)
+		tasks, err = s.getTasksForProcessing(ctx, taskType, limit, concurrencyLimitPerKey)
 		if err != nil {
 			return err
 		}
 
-		return s.batchUpdateTasksStatus(ctx, tasks, entity.TaskStatusPending)
+		if concurrencyLimitPerKey > 0 {
+			tasks, err = s.limitTasksPerConcurrencyKey(ctx, tasks, concurrencyLimitPerKey)
+			if err != nil {
+				return err
+			}
+		}
+
+		return s.leaseTasks(ctx, tasks, lease)
 	})
 	if err != nil {
 		xlog.Error(ctx, "failed to get task for processing", xfield.Error(err))
@@ -41,26 +57,31 @@ func (s *Storage) GetTasksForProcessing(ctx context.Context, taskType entity.Tas
 	return fromDBModels(ctx, tasks)
 }
 
-func (s *Storage) getTasksForProcessing(ctx context.Context, taskType entity.TaskType, limit int64) ([]*model.GoqueTask, error) {
+func (s *Storage) getTasksForProcessing(
+	ctx context.Context,
+	taskType entity.TaskType,
+	limit int64,
+	concurrencyLimitPerKey int64,
+) ([]*model.GoqueTask, error) {
 	ctx, span := xlog.WithOperationSpan(ctx, "storage.getTasksForProcessing")
 	defer span.End()
 
 	// SQLite doesn't support FOR UPDATE SKIP LOCKED
 	// In WAL mode, the transaction provides row-level locking automatically
 	// The forUpdate parameter is kept for interface compatibility but not used
+	whereExpr := sqlite.AND(
+		table.GoqueTask.Type.EQ(sqlite.String(taskType)),
+		readyForProcessingExpr(),
+	)
+	if concurrencyLimitPerKey > 0 {
+		whereExpr = whereExpr.AND(concurrencyKeyUnderLimitExpr(concurrencyLimitPerKey))
+	}
+
 	stmt := table.GoqueTask.
 		SELECT(table.GoqueTask.AllColumns).
-		WHERE(
-			sqlite.AND(
-				table.GoqueTask.Type.EQ(sqlite.String(taskType)),
-				table.GoqueTask.Status.IN(
-					sqlite.String(entity.TaskStatusNew),
-					sqlite.String(entity.TaskStatusError),
-				),
-				table.GoqueTask.NextAttemptAt.LT_EQ(sqlite.String(timeToString(xtime.Now()))),
-			),
-		).
+		WHERE(whereExpr).
 		ORDER_BY(
+			table.GoqueTask.Priority.DESC(),
 			table.GoqueTask.NextAttemptAt.ASC(),
 		).
 		LIMIT(limit)
@@ -76,3 +97,15 @@ func (s *Storage) getTasksForProcessing(ctx context.Context, taskType entity.Tas
 
 	return tasks, nil
 }
+
+// readyForProcessingExpr matches tasks the fetcher may pick up right now.
+// A scheduled task is new as well and becomes due once its next_attempt_at has passed.
+func readyForProcessingExpr() sqlite.BoolExpression {
+	return sqlite.AND(
+		table.GoqueTask.Status.IN(
+			sqlite.String(entity.TaskStatusNew),
+			sqlite.String(entity.TaskStatusError),
+		),
+		table.GoqueTask.NextAttemptAt.LT_EQ(sqlite.String(timeToString(xtime.Now()))),
+	)
+}
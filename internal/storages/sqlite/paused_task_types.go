@@ -0,0 +1,112 @@
+package sqlite
+
+import (
+	"context"
+
+	"github.com/go-jet/jet/v2/sqlite"
+	"github.com/ruko1202/xlog"
+	"github.com/ruko1202/xlog/xfield"
+	"github.com/samber/lo"
+
+	"github.com/ruko1202/goque/internal/entity"
+	"github.com/ruko1202/goque/internal/pkg/generated/sqlite3/model"
+	"github.com/ruko1202/goque/internal/pkg/generated/sqlite3/table"
+	"github.com/ruko1202/goque/internal/utils/xtime"
+)
+
+// PauseTaskType pauses fetching the tasks of the type by all processors. Pausing a paused type is a no-op.
+func (s *Storage) PauseTaskType(ctx context.Context, taskType entity.TaskType) error {
+	ctx, span := xlog.WithOperationSpan(ctx, "storage.PauseTaskType",
+		xfield.String("db.type", "sqlite"),
+		xfield.String("task_type", taskType),
+	)
+	defer span.End()
+
+	query, args := table.GoquePausedTaskType.
+		INSERT(table.GoquePausedTaskType.AllColumns).
+		MODEL(model.GoquePausedTaskType{
+			Type:     lo.ToPtr(taskType),
+			PausedAt: timeToString(xtime.Now()),
+		}).
+		ON_CONFLICT(table.GoquePausedTaskType.Type).
+		DO_NOTHING().
+		Sql()
+
+	_, err := s.db.Executor(ctx).ExecContext(ctx, query, args...)
+	if err != nil {
+		xlog.Error(ctx, "failed to pause task type", xfield.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// ResumeTaskType resumes fetching the tasks of the type. Resuming a type that isn't paused is a no-op.
+func (s *Storage) ResumeTaskType(ctx context.Context, taskType entity.TaskType) error {
+	ctx, span := xlog.WithOperationSpan(ctx, "storage.ResumeTaskType",
+		xfield.String("db.type", "sqlite"),
+		xfield.String("task_type", taskType),
+	)
+	defer span.End()
+
+	query, args := table.GoquePausedTaskType.
+		DELETE().
+		WHERE(table.GoquePausedTaskType.Type.EQ(sqlite.String(taskType))).
+		Sql()
+
+	_, err := s.db.Executor(ctx).ExecContext(ctx, query, args...)
+	if err != nil {
+		xlog.Error(ctx, "failed to resume task type", xfield.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// IsTaskTypePaused reports whether the task type is paused.
+func (s *Storage) IsTaskTypePaused(ctx context.Context, taskType entity.TaskType) (bool, error) {
+	ctx, span := xlog.WithOperationSpan(ctx, "storage.IsTaskTypePaused",
+		xfield.String("db.type", "sqlite"),
+		xfield.String("task_type", taskType),
+	)
+	defer span.End()
+
+	pausedTypes, err := s.getPausedTaskTypes(ctx, table.GoquePausedTaskType.Type.EQ(sqlite.String(taskType)))
+	if err != nil {
+		xlog.Error(ctx, "failed to check paused task type", xfield.Error(err))
+		return false, err
+	}
+
+	return len(pausedTypes) > 0, nil
+}
+
+// GetPausedTaskTypes returns all paused task types sorted by name.
+func (s *Storage) GetPausedTaskTypes(ctx context.Context) ([]entity.TaskType, error) {
+	ctx, span := xlog.WithOperationSpan(ctx, "storage.GetPausedTaskTypes",
+		xfield.String("db.type", "sqlite"),
+	)
+	defer span.End()
+
+	pausedTypes, err := s.getPausedTaskTypes(ctx, sqlite.Bool(true))
+	if err != nil {
+		xlog.Error(ctx, "failed to get paused task types", xfield.Error(err))
+		return nil, err
+	}
+
+	return pausedTypes, nil
+}
+
+func (s *Storage) getPausedTaskTypes(ctx context.Context, whereExpr sqlite.BoolExpression) ([]entity.TaskType, error) {
+	query, args := table.GoquePausedTaskType.
+		SELECT(table.GoquePausedTaskType.Type).
+		WHERE(whereExpr).
+		ORDER_BY(table.GoquePausedTaskType.Type.ASC()).
+		Sql()
+
+	pausedTypes := make([]entity.TaskType, 0)
+	if err := s.db.Executor(ctx).SelectContext(ctx, &pausedTypes, query, args...); err != nil {
+		return nil, err
+	}
+
+	return pausedTypes, nil
+}
@@ -0,0 +1,56 @@
+package sqlite
+
+import (
+	"context"
+
+	"github.com/go-jet/jet/v2/sqlite"
+	"github.com/google/uuid"
+	"github.com/ruko1202/xlog"
+	"github.com/ruko1202/xlog/xfield"
+	"github.com/samber/lo"
+
+	"github.com/ruko1202/goque/internal/entity"
+	"github.com/ruko1202/goque/internal/pkg/generated/sqlite3/table"
+	"github.com/ruko1202/goque/internal/utils/xtime"
+)
+
+// RenewTaskLeases extends the lease of the tasks still owned by the lease owner.
+// Tasks that changed the owner or left statuses pending and processing are skipped.
+func (s *Storage) RenewTaskLeases(ctx context.Context, taskIDs []uuid.UUID, lease entity.TaskLease) error {
+	ctx, span := xlog.WithOperationSpan(ctx, "storage.RenewTaskLeases",
+		xfield.String("db.type", "sqlite"),
+		xfield.String("lease_owner", lease.Owner),
+		xfield.Int("tasks", len(taskIDs)),
+	)
+	defer span.End()
+
+	if len(taskIDs) == 0 {
+		return nil
+	}
+
+	stmt := table.GoqueTask.
+		UPDATE(table.GoqueTask.LeaseExpiresAt).
+		SET(sqlite.String(timeToString(xtime.Now().Add(lease.Duration)))).
+		WHERE(
+			sqlite.AND(
+				table.GoqueTask.ID.IN(lo.Map(taskIDs, func(item uuid.UUID, _ int) sqlite.Expression {
+					return sqlite.String(item.String())
+				})...),
+				table.GoqueTask.LockedBy.EQ(sqlite.String(lease.Owner)),
+				table.GoqueTask.Status.IN(
+					sqlite.String(entity.TaskStatusPending),
+					sqlite.String(entity.TaskStatusProcessing),
+				),
+			),
+		)
+
+	query, args := stmt.Sql()
+
+	_, err := s.db.Executor(ctx).ExecContext(ctx, query, args...)
+	if err != nil {
+		xlog.Error(ctx, "failed to renew task leases", xfield.Error(err))
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-jet/jet/v2/sqlite"
+	"github.com/ruko1202/xlog"
+	"github.com/ruko1202/xlog/xfield"
+
+	"github.com/ruko1202/goque/internal/entity"
+	"github.com/ruko1202/goque/internal/pkg/generated/sqlite3/table"
+	"github.com/ruko1202/goque/internal/storages/dbentity"
+	"github.com/ruko1202/goque/internal/utils/xtime"
+)
+
+// RetryTasks resets the attempts of tasks matching the filter and sets them back to status new
+// to be fetched right away, with a single UPDATE, clearing the result of done tasks and resetting the progress.
+// Tasks in flight (pending, processing) are skipped.
+// Returns the number of retried tasks.
+func (s *Storage) RetryTasks(ctx context.Context, filter *dbentity.GetTasksFilter) (int64, error) {
+	ctx, span := xlog.WithOperationSpan(ctx, "storage.RetryTasks",
+		xfield.String("db.type", "sqlite"),
+		xfield.Any("filter", filter),
+	)
+	defer span.End()
+
+	whereExpr, err := filter.BindSqliteBulkWhereExpr()
+	if err != nil {
+		xlog.Error(ctx, "failed to bind filter", xfield.Error(err))
+		return 0, err
+	}
+
+	now := xtime.Now()
+	stmt := table.GoqueTask.
+		UPDATE(
+			table.GoqueTask.Status,
+			table.GoqueTask.Attempts,
+			table.GoqueTask.Errors,
+			table.GoqueTask.UpdatedAt,
+			table.GoqueTask.NextAttemptAt,
+			table.GoqueTask.Version,
+			table.GoqueTask.Result,
+			table.GoqueTask.Progress,
+		).
+		SET(
+			sqlite.String(entity.TaskStatusNew),
+			sqlite.Int32(0),
+			sqlite.StringExp(sqlite.COALESCE(table.GoqueTask.Errors, sqlite.String(""))).
+				CONCAT(sqlite.String(fmt.Sprintf("retry: %s\n", now.Format(time.RFC3339)))),
+			sqlite.String(timeToString(now)),
+			sqlite.String(timeToString(now)),
+			table.GoqueTask.Version.ADD(sqlite.Int(1)),
+			sqlite.NULL,
+			sqlite.Int32(0),
+		).
+		WHERE(
+			whereExpr.AND(
+				table.GoqueTask.Status.NOT_IN(
+					sqlite.String(entity.TaskStatusPending),
+					sqlite.String(entity.TaskStatusProcessing),
+				),
+			),
+		)
+
+	query, args := stmt.Sql()
+
+	res, err := s.db.Executor(ctx).ExecContext(ctx, query, args...)
+	if err != nil {
+		xlog.Error(ctx, "failed to retry tasks", xfield.Error(err))
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
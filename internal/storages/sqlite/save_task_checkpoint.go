@@ -0,0 +1,57 @@
+package sqlite
+
+import (
+	"context"
+
+	"github.com/go-jet/jet/v2/sqlite"
+	"github.com/ruko1202/xlog"
+	"github.com/ruko1202/xlog/xfield"
+
+	"github.com/ruko1202/goque/internal/entity"
+	"github.com/ruko1202/goque/internal/pkg/generated/sqlite3/table"
+)
+
+// SaveTaskCheckpoint writes the checkpoint and the progress of the task and bumps its version.
+// Like UpdateTask it is a compare-and-set on the task version, but the other columns,
+// updated_at included, are kept.
+func (s *Storage) SaveTaskCheckpoint(ctx context.Context, task *entity.Task) error {
+	ctx, span := xlog.WithOperationSpan(ctx, "storage.SaveTaskCheckpoint",
+		xfield.String("db.type", "sqlite"),
+		xfield.String("task_id", task.ID.String()),
+	)
+	defer span.End()
+
+	stmt := table.GoqueTask.
+		UPDATE(
+			table.GoqueTask.Checkpoint,
+			table.GoqueTask.Progress,
+			table.GoqueTask.Version,
+		).
+		SET(
+			task.Checkpoint,
+			task.Progress,
+			task.Version+1,
+		).
+		WHERE(
+			table.GoqueTask.ID.EQ(sqlite.String(task.ID.String())).
+				AND(table.GoqueTask.Version.EQ(sqlite.Int(task.Version))),
+		)
+
+	query, args := stmt.Sql()
+
+	res, err := s.db.Executor(ctx).ExecContext(ctx, query, args...)
+	if err != nil {
+		xlog.Error(ctx, "failed to save task checkpoint", xfield.Error(err))
+		return err
+	}
+	updated, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if updated == 0 {
+		return s.checkTaskConflict(ctx, task.ID)
+	}
+	task.Version++
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package sqlite
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-jet/jet/v2/sqlite"
+	"github.com/ruko1202/xlog"
+	"github.com/ruko1202/xlog/xfield"
+	"github.com/samber/lo"
+
+	"github.com/ruko1202/goque/internal/entity"
+	"github.com/ruko1202/goque/internal/pkg/generated/sqlite3/table"
+	"github.com/ruko1202/goque/internal/storages/dbentity"
+)
+
+// taskStatsRow is dbentity.TaskStatsRow with times stored as text.
+type taskStatsRow struct {
+	TaskType         entity.TaskType   `db:"goque_task.type"`
+	Status           entity.TaskStatus `db:"goque_task.status"`
+	Count            int64             `db:"count"`
+	OldestRunnableAt *string           `db:"oldest_runnable_at"`
+	OldestUpdatedAt  *string           `db:"oldest_updated_at"`
+	ProgressSum      int64             `db:"progress_sum"`
+}
+
+// Stats returns per-type task counts by status along with the queue lag,
+// the oldest processing task and the average progress of the processing tasks.
+// A nil filter takes all tasks into account.
+func (s *Storage) Stats(ctx context.Context, filter *dbentity.GetTasksFilter) ([]*entity.TaskStats, error) {
+	ctx, span := xlog.WithOperationSpan(ctx, "storage.Stats",
+		xfield.String("db.type", "sqlite"),
+		xfield.Any("filter", filter),
+	)
+	defer span.End()
+
+	if filter == nil {
+		filter = &dbentity.GetTasksFilter{}
+	}
+
+	whereExpr, err := filter.BindSqliteWhereExpr()
+	if err != nil {
+		xlog.Error(ctx, "failed to bind filter", xfield.Error(err))
+		return nil, err
+	}
+
+	stmt := table.GoqueTask.
+		SELECT(
+			table.GoqueTask.Type,
+			table.GoqueTask.Status,
+			sqlite.COUNT(sqlite.STAR).AS("count"),
+			sqlite.MIN(
+				sqlite.CASE().WHEN(readyForProcessingExpr()).THEN(table.GoqueTask.NextAttemptAt),
+			).AS("oldest_runnable_at"),
+			sqlite.MIN(table.GoqueTask.UpdatedAt).AS("oldest_updated_at"),
+			sqlite.SUM(table.GoqueTask.Progress).AS("progress_sum"),
+		).
+		WHERE(whereExpr).
+		GROUP_BY(table.GoqueTask.Type, table.GoqueTask.Status)
+
+	query, args := stmt.Sql()
+
+	rows := make([]*taskStatsRow, 0)
+	err = s.db.Executor(ctx).SelectContext(ctx, &rows, query, args...)
+	if err != nil {
+		xlog.Error(ctx, "failed to get stats", xfield.Error(err))
+		return nil, err
+	}
+
+	return dbentity.BuildTaskStats(lo.Map(rows, func(row *taskStatsRow, _ int) *dbentity.TaskStatsRow {
+		return &dbentity.TaskStatsRow{
+			TaskType:         row.TaskType,
+			Status:           row.Status,
+			Count:            row.Count,
+			OldestRunnableAt: optionalTimeFromString(row.OldestRunnableAt),
+			OldestUpdatedAt:  optionalTimeFromString(row.OldestUpdatedAt),
+			ProgressSum:      row.ProgressSum,
+		}
+	})), nil
+}
+
+func optionalTimeFromString(value *string) *time.Time {
+	if value == nil {
+		return nil
+	}
+	return lo.ToPtr(timeFromString(*value))
+}
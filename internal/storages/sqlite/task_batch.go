@@ -0,0 +1,138 @@
+package sqlite
+
+import (
+	"context"
+	"slices"
+
+	"github.com/go-jet/jet/v2/sqlite"
+	"github.com/google/uuid"
+	"github.com/ruko1202/xlog"
+	"github.com/ruko1202/xlog/xfield"
+	"github.com/samber/lo"
+
+	"github.com/ruko1202/goque/internal/entity"
+	"github.com/ruko1202/goque/internal/pkg/generated/sqlite3/model"
+	"github.com/ruko1202/goque/internal/pkg/generated/sqlite3/table"
+	"github.com/ruko1202/goque/internal/utils/xtime"
+)
+
+// resolveFinishedTask resolves the dependents of the task that reached a terminal status
+// and completes the batches of the task and of its canceled dependents.
+func (s *Storage) resolveFinishedTask(ctx context.Context, taskID uuid.UUID, task *entity.Task) error {
+	if !task.IsInTerminalState() {
+		return nil
+	}
+
+	batchIDs, err := s.resolveDependents(ctx, taskID, task.Status)
+	if err != nil {
+		return err
+	}
+	if task.BatchID != nil {
+		batchIDs = append(batchIDs, task.BatchID.String())
+	}
+
+	return s.completeBatches(ctx, batchIDs)
+}
+
+// completeBatches completes the batches left without unfinished tasks and releases their completion tasks.
+// SQLite serializes the write transactions, so a batch is completed exactly once without locking.
+func (s *Storage) completeBatches(ctx context.Context, batchIDs []string) error {
+	batchIDs = lo.Uniq(batchIDs)
+	if len(batchIDs) == 0 {
+		return nil
+	}
+
+	ctx, span := xlog.WithOperationSpan(ctx, "storage.completeBatches",
+		xfield.Int("batches_count", len(batchIDs)),
+	)
+	defer span.End()
+
+	query, args := table.GoqueTaskBatch.
+		SELECT(table.GoqueTaskBatch.ID, table.GoqueTaskBatch.CompletionTaskID).
+		WHERE(
+			table.GoqueTaskBatch.ID.IN(lo.Map(batchIDs, func(batchID string, _ int) sqlite.Expression {
+				return sqlite.String(batchID)
+			})...).
+				AND(table.GoqueTaskBatch.CompletedAt.IS_NULL()),
+		).
+		Sql()
+
+	batches := make([]*model.GoqueTaskBatch, 0, len(batchIDs))
+	if err := s.db.Executor(ctx).SelectContext(ctx, &batches, query, args...); err != nil {
+		return err
+	}
+	if len(batches) == 0 {
+		return nil
+	}
+
+	openIDs := lo.Map(batches, func(batch *model.GoqueTaskBatch, _ int) sqlite.Expression {
+		return sqlite.String(lo.FromPtr(batch.ID))
+	})
+	query, args = table.GoqueTask.
+		SELECT(table.GoqueTask.BatchID).
+		DISTINCT().
+		WHERE(
+			table.GoqueTask.BatchID.IN(openIDs...).
+				AND(table.GoqueTask.Status.NOT_IN(lo.Map(entity.TerminalStatuses(), func(status string, _ int) sqlite.Expression {
+					return sqlite.String(status)
+				})...)),
+		).
+		Sql()
+
+	unfinishedIDs := make([]string, 0)
+	if err := s.db.Executor(ctx).SelectContext(ctx, &unfinishedIDs, query, args...); err != nil {
+		return err
+	}
+
+	completed := lo.Reject(batches, func(batch *model.GoqueTaskBatch, _ int) bool {
+		return slices.Contains(unfinishedIDs, lo.FromPtr(batch.ID))
+	})
+	if len(completed) == 0 {
+		return nil
+	}
+
+	query, args = table.GoqueTaskBatch.
+		UPDATE(table.GoqueTaskBatch.CompletedAt).
+		SET(sqlite.String(timeToString(xtime.Now()))).
+		WHERE(table.GoqueTaskBatch.ID.IN(lo.Map(completed, func(batch *model.GoqueTaskBatch, _ int) sqlite.Expression {
+			return sqlite.String(lo.FromPtr(batch.ID))
+		})...)).
+		Sql()
+	if _, err := s.db.Executor(ctx).ExecContext(ctx, query, args...); err != nil {
+		return err
+	}
+
+	return s.releaseCompletionTasks(ctx, lo.FilterMap(completed, func(batch *model.GoqueTaskBatch, _ int) (string, bool) {
+		return lo.FromPtr(batch.CompletionTaskID), batch.CompletionTaskID != nil
+	}))
+}
+
+// releaseCompletionTasks makes the completion tasks of the completed batches ready to run.
+// A completion task canceled in the meantime stays canceled.
+func (s *Storage) releaseCompletionTasks(ctx context.Context, taskIDs []string) error {
+	if len(taskIDs) == 0 {
+		return nil
+	}
+
+	query, args := table.GoqueTask.
+		UPDATE(
+			table.GoqueTask.Status,
+			table.GoqueTask.UpdatedAt,
+			table.GoqueTask.Version,
+		).
+		SET(
+			sqlite.String(entity.TaskStatusNew),
+			sqlite.String(timeToString(xtime.Now())),
+			table.GoqueTask.Version.ADD(sqlite.Int(1)),
+		).
+		WHERE(
+			table.GoqueTask.ID.IN(lo.Map(taskIDs, func(taskID string, _ int) sqlite.Expression {
+				return sqlite.String(taskID)
+			})...).
+				AND(table.GoqueTask.Status.EQ(sqlite.String(entity.TaskStatusWaiting))),
+		).
+		Sql()
+
+	_, err := s.db.Executor(ctx).ExecContext(ctx, query, args...)
+	return err
+}
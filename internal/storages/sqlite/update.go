@@ -2,6 +2,9 @@ package sqlite
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
 
 	"github.com/go-jet/jet/v2/sqlite"
 	"github.com/google/uuid"
@@ -12,10 +15,16 @@ import (
 	"github.com/ruko1202/goque/internal/entity"
 	"github.com/ruko1202/goque/internal/pkg/generated/sqlite3/model"
 	"github.com/ruko1202/goque/internal/pkg/generated/sqlite3/table"
+	"github.com/ruko1202/goque/internal/storages/dbtx"
 	"github.com/ruko1202/goque/internal/utils/xtime"
 )
 
 // UpdateTask updates an existing task in the database with the provided data.
+// The update is a compare-and-set on the task version: if the task has been changed since it was read,
+// nothing is written and entity.ErrTaskStateConflict is returned, wrapping entity.ErrTaskCancelRequested
+// when the task has been canceled. A task that no longer exists is not an error.
+// A task reaching a terminal status releases or cancels the tasks waiting for it, see entity.TaskStatusWaiting,
+// and completes its batch if it is the last unfinished task of the batch, see entity.Batch.
 func (s *Storage) UpdateTask(ctx context.Context, taskID uuid.UUID, task *entity.Task) error {
 	ctx, span := xlog.WithOperationSpan(ctx, "storage.UpdateTask",
 		xfield.String("db.type", "sqlite"),
@@ -24,10 +33,32 @@ func (s *Storage) UpdateTask(ctx context.Context, taskID uuid.UUID, task *entity
 	defer span.End()
 
 	task.UpdatedAt = lo.ToPtr(xtime.Now())
-	return s.updateTask(ctx, taskID.String(), toDBModel(ctx, task))
+	dbTask := toDBModel(ctx, task)
+	update := func(ctx context.Context) error {
+		if err := s.updateTaskVersion(ctx, taskID, dbTask); err != nil {
+			return err
+		}
+
+		return s.resolveFinishedTask(ctx, taskID, task)
+	}
+
+	var err error
+	if task.IsInTerminalState() {
+		// the tasks waiting for this one and the batch are resolved along with it
+		err = dbtx.EnsureTx(ctx, s.db.GetDB(), update)
+	} else {
+		err = update(ctx)
+	}
+	if err != nil {
+		return err
+	}
+	task.Version = dbTask.Version
+
+	return nil
 }
 
-// HardUpdateTask updates a task without automatically setting the updated_at timestamp.
+// HardUpdateTask updates a task without automatically setting the updated_at timestamp
+// and without checking the task version.
 func (s *Storage) HardUpdateTask(ctx context.Context, taskID uuid.UUID, task *entity.Task) error {
 	ctx, span := xlog.WithOperationSpan(ctx, "storage.HardUpdateTask",
 		xfield.String("db.type", "sqlite"),
@@ -35,11 +66,33 @@ func (s *Storage) HardUpdateTask(ctx context.Context, taskID uuid.UUID, task *en
 	)
 	defer span.End()
 
-	return s.updateTask(ctx, taskID.String(), toDBModel(ctx, task))
+	_, err := s.updateTaskWhere(ctx, toDBModel(ctx, task), table.GoqueTask.ID.EQ(sqlite.String(taskID.String())))
+	return err
 }
 
-func (s *Storage) updateTask(ctx context.Context, taskID string, task *model.GoqueTask) error {
-	ctx, span := xlog.WithOperationSpan(ctx, "storage.updateTask")
+// updateTaskVersion writes the task if its stored version is still task.Version and bumps the version.
+func (s *Storage) updateTaskVersion(ctx context.Context, taskID uuid.UUID, task *model.GoqueTask) error {
+	version := task.Version
+	task.Version++
+	updated, err := s.updateTaskWhere(ctx, task,
+		table.GoqueTask.ID.EQ(sqlite.String(taskID.String())).
+			AND(table.GoqueTask.Version.EQ(sqlite.Int(version))),
+	)
+	if err == nil && updated == 0 {
+		err = s.checkTaskConflict(ctx, taskID)
+	}
+	if err != nil {
+		task.Version = version
+		return err
+	}
+
+	return nil
+}
+
+// updateTaskWhere updates the mutable task columns of the rows matching whereExpr
+// and returns the number of affected rows.
+func (s *Storage) updateTaskWhere(ctx context.Context, task *model.GoqueTask, whereExpr sqlite.BoolExpression) (int64, error) {
+	ctx, span := xlog.WithOperationSpan(ctx, "storage.updateTaskWhere")
 	defer span.End()
 
 	stmt := table.GoqueTask.
@@ -49,6 +102,11 @@ func (s *Storage) updateTask(ctx context.Context, taskID string, task *model.Goq
 			table.GoqueTask.Errors,
 			table.GoqueTask.UpdatedAt,
 			table.GoqueTask.NextAttemptAt,
+			table.GoqueTask.Version,
+			table.GoqueTask.Result,
+			table.GoqueTask.Checkpoint,
+			table.GoqueTask.Progress,
+			table.GoqueTask.RetryDelayMs,
 		).
 		SET(
 			task.Status,
@@ -56,53 +114,88 @@ func (s *Storage) updateTask(ctx context.Context, taskID string, task *model.Goq
 			task.Errors,
 			task.UpdatedAt,
 			task.NextAttemptAt,
+			task.Version,
+			task.Result,
+			task.Checkpoint,
+			task.Progress,
+			task.RetryDelayMs,
 		).
-		WHERE(table.GoqueTask.ID.EQ(sqlite.String(taskID)))
+		WHERE(whereExpr)
 
 	query, args := stmt.Sql()
 
-	_, err := s.db.Executor(ctx).ExecContext(ctx, query, args...)
+	res, err := s.db.Executor(ctx).ExecContext(ctx, query, args...)
 	if err != nil {
 		xlog.Error(ctx, "failed to update task", xfield.Error(err))
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// checkTaskConflict tells why a compare-and-set write of the task matched no rows.
+// A task that no longer exists has nothing to protect and is not an error.
+func (s *Storage) checkTaskConflict(ctx context.Context, taskID uuid.UUID) error {
+	task, err := s.getTask(ctx, taskID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
 		return err
 	}
+	if task.Status == entity.TaskStatusCanceled {
+		return fmt.Errorf("%w: %w", entity.ErrTaskStateConflict, entity.ErrTaskCancelRequested)
+	}
 
-	return nil
+	return entity.ErrTaskStateConflict
 }
 
-func (s *Storage) batchUpdateTasksStatus(ctx context.Context, tasks []*model.GoqueTask, newStatus string) error {
-	ctx, span := xlog.WithOperationSpan(ctx, "storage.batchUpdateTasksStatus")
+// leaseTasks moves the fetched tasks to status pending owned by the lease owner.
+func (s *Storage) leaseTasks(ctx context.Context, tasks []*model.GoqueTask, lease entity.TaskLease) error {
+	ctx, span := xlog.WithOperationSpan(ctx, "storage.leaseTasks")
 	defer span.End()
 
 	if len(tasks) == 0 {
 		return nil
 	}
 
-	now := timeToString(xtime.Now())
+	now := xtime.Now()
+	expiresAt := now.Add(lease.Duration)
 	stmt := table.GoqueTask.
 		UPDATE(
 			table.GoqueTask.Status,
 			table.GoqueTask.UpdatedAt,
+			table.GoqueTask.LockedBy,
+			table.GoqueTask.LeaseExpiresAt,
+			table.GoqueTask.Version,
 		).
 		SET(
-			sqlite.String(newStatus),
-			sqlite.String(now),
+			sqlite.String(entity.TaskStatusPending),
+			sqlite.String(timeToString(now)),
+			sqlite.String(lease.Owner),
+			sqlite.String(timeToString(expiresAt)),
+			table.GoqueTask.Version.ADD(sqlite.Int(1)),
 		).
 		WHERE(table.GoqueTask.ID.IN(
-			lo.Map(tasks, func(task *model.GoqueTask, _ int) sqlite.Expression { return sqlite.String(lo.FromPtr(task.ID)) })...,
+			lo.Map(tasks, func(task *model.GoqueTask, _ int) sqlite.Expression {
+				return sqlite.String(lo.FromPtr(task.ID))
+			})...,
 		))
 
 	query, args := stmt.Sql()
 
 	_, err := s.db.Executor(ctx).ExecContext(ctx, query, args...)
 	if err != nil {
-		xlog.Error(ctx, "failed to update task", xfield.Error(err))
+		xlog.Error(ctx, "failed to lease tasks", xfield.Error(err))
 		return err
 	}
 
 	lo.ForEach(tasks, func(task *model.GoqueTask, _ int) {
-		task.UpdatedAt = lo.ToPtr(now)
-		task.Status = newStatus
+		task.Status = entity.TaskStatusPending
+		task.UpdatedAt = lo.ToPtr(timeToString(now))
+		task.LockedBy = lo.ToPtr(lease.Owner)
+		task.LeaseExpiresAt = lo.ToPtr(timeToString(expiresAt))
+		task.Version++
 	})
 
 	return nil
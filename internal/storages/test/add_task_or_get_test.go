@@ -0,0 +1,104 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/ruko1202/xlog"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/ruko1202/goque"
+	"github.com/ruko1202/goque/internal/entity"
+	"github.com/ruko1202/goque/internal/storages"
+	"github.com/ruko1202/goque/test/testutils"
+)
+
+func TestAddTaskOrGet(t *testing.T) {
+	testutils.RunMultiDBTests(t, taskStorages, testAddTaskOrGet)
+}
+
+//nolint:thelper
+func testAddTaskOrGet(t *testing.T, storage storages.AdvancedTaskStorage) {
+	t.Parallel()
+	ctx := context.Background()
+
+	t.Run("new task", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+		task := entity.NewTaskWithExternalID("test", testutils.ToJSON(t, testutils.TestPayload{Data: "payload"}), uuid.NewString())
+
+		stored, created, err := storage.AddTaskOrGet(ctx, task)
+		require.NoError(t, err)
+		require.True(t, created)
+		require.Same(t, task, stored)
+
+		dbTask, err := storage.GetTask(ctx, task.ID)
+		require.NoError(t, err)
+		testutils.EqualTask(t, task, dbTask)
+	})
+
+	t.Run("existing task", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+		existing := entity.NewTaskWithExternalID("test", testutils.ToJSON(t, testutils.TestPayload{Data: "existing"}), uuid.NewString())
+		require.NoError(t, storage.AddTask(ctx, existing))
+
+		task := entity.NewTaskWithExternalID(existing.Type, testutils.ToJSON(t, testutils.TestPayload{Data: "new"}), existing.ExternalID)
+
+		stored, created, err := storage.AddTaskOrGet(ctx, task)
+		require.NoError(t, err)
+		require.False(t, created)
+		testutils.EqualTask(t, existing, stored)
+
+		_, err = storage.GetTask(ctx, task.ID)
+		require.Error(t, err)
+	})
+
+	t.Run("same external id of another type", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+		existing := entity.NewTaskWithExternalID("test", testutils.ToJSON(t, testutils.TestPayload{Data: "existing"}), uuid.NewString())
+		require.NoError(t, storage.AddTask(ctx, existing))
+
+		task := entity.NewTaskWithExternalID("another test", testutils.ToJSON(t, testutils.TestPayload{Data: "new"}), existing.ExternalID)
+
+		stored, created, err := storage.AddTaskOrGet(ctx, task)
+		require.NoError(t, err)
+		require.True(t, created)
+		require.Equal(t, task.ID, stored.ID)
+	})
+
+	t.Run("failed payload", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+		task := entity.NewTaskWithExternalID("test", "invalid payload", uuid.NewString())
+
+		_, _, err := storage.AddTaskOrGet(ctx, task)
+		require.ErrorIs(t, err, entity.ErrInvalidPayloadFormat)
+	})
+
+	t.Run("within tx", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+		existing := entity.NewTaskWithExternalID("test", testutils.ToJSON(t, testutils.TestPayload{Data: "existing"}), uuid.NewString())
+		require.NoError(t, storage.AddTask(ctx, existing))
+
+		tx, err := storage.GetDB().BeginTxx(ctx, nil)
+		require.NoError(t, err)
+		defer func() { _ = tx.Rollback() }()
+
+		task := entity.NewTaskWithExternalID(existing.Type, testutils.ToJSON(t, testutils.TestPayload{Data: "new"}), existing.ExternalID)
+
+		stored, created, err := storage.AddTaskOrGet(goque.WithTx(ctx, tx), task)
+		require.NoError(t, err)
+		require.False(t, created)
+		require.Equal(t, existing.ID, stored.ID)
+	})
+}
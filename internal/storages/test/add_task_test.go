@@ -3,9 +3,11 @@ package test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/ruko1202/xlog"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap/zaptest"
 
@@ -43,6 +45,25 @@ func testAdd(t *testing.T, storage storages.AdvancedTaskStorage) {
 		require.Equal(t, goque.Metadata{"testname": t.Name()}, dbTask.Metadata)
 	})
 
+	t.Run("with limits", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+		task := entity.NewTask("test", testutils.ToJSON(t, testutils.TestPayload{Data: "test"}),
+			entity.WithTaskTimeout(90*time.Minute),
+			entity.WithTaskMaxAttempts(7),
+		)
+
+		err := storage.AddTask(ctx, task)
+		require.NoError(t, err)
+
+		dbTask, err := storage.GetTask(ctx, task.ID)
+		require.NoError(t, err)
+		testutils.EqualTask(t, task, dbTask)
+		require.Equal(t, lo.ToPtr(90*time.Minute), dbTask.Timeout)
+		require.Equal(t, lo.ToPtr[int32](7), dbTask.MaxAttempts)
+	})
+
 	t.Run("failed payload", func(t *testing.T) {
 		t.Parallel()
 		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
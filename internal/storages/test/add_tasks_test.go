@@ -0,0 +1,175 @@
+package test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/ruko1202/xlog"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/ruko1202/goque"
+	"github.com/ruko1202/goque/internal/entity"
+	"github.com/ruko1202/goque/internal/storages"
+	"github.com/ruko1202/goque/internal/storages/dbentity"
+	"github.com/ruko1202/goque/internal/utils/goquectx"
+	"github.com/ruko1202/goque/test/testutils"
+)
+
+func TestAddTasks(t *testing.T) {
+	testutils.RunMultiDBTests(t, taskStorages, testAddTasks)
+}
+
+//nolint:thelper
+func testAddTasks(t *testing.T, storage storages.AdvancedTaskStorage) {
+	t.Parallel()
+	ctx := context.Background()
+
+	newTasks := func(t *testing.T, count int) []*entity.Task {
+		t.Helper()
+
+		return lo.Times(count, func(_ int) *entity.Task {
+			return entity.NewTaskWithExternalID(t.Name(), testutils.ToJSON(t, testutils.TestPayload{Data: "test"}), uuid.NewString())
+		})
+	}
+
+	requireTasks := func(ctx context.Context, t *testing.T, expected []*entity.Task) {
+		t.Helper()
+
+		dbTasks, err := storage.GetTasks(ctx, &dbentity.GetTasksFilter{
+			TaskType: lo.ToPtr(t.Name()),
+		}, int64(len(expected)+1))
+		require.NoError(t, err)
+		require.Len(t, dbTasks, len(expected))
+
+		dbTasksByID := lo.KeyBy(dbTasks, func(task *entity.Task) uuid.UUID { return task.ID })
+		for _, task := range expected {
+			require.Contains(t, dbTasksByID, task.ID)
+			testutils.EqualTask(t, task, dbTasksByID[task.ID])
+		}
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+		ctx = goquectx.WithValue(ctx, "testname", t.Name())
+
+		tasks := newTasks(t, 3)
+
+		err := storage.AddTasks(ctx, tasks, false)
+		require.NoError(t, err)
+
+		requireTasks(ctx, t, tasks)
+	})
+
+	t.Run("large batch", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+		tasks := newTasks(t, 1200)
+
+		err := storage.AddTasks(ctx, tasks, false)
+		require.NoError(t, err)
+
+		requireTasks(ctx, t, tasks)
+	})
+
+	t.Run("empty batch", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+		err := storage.AddTasks(ctx, nil, false)
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid payload fails the batch", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+		tasks := newTasks(t, 3)
+		tasks[1].Payload = "invalid payload"
+
+		err := storage.AddTasks(ctx, tasks, true)
+		require.ErrorIs(t, err, entity.ErrInvalidPayloadFormat)
+
+		var tasksErr *entity.TasksError
+		require.ErrorAs(t, err, &tasksErr)
+		require.Len(t, tasksErr.Errors, 1)
+		require.ErrorIs(t, tasksErr.Errors[tasks[1].ID], entity.ErrInvalidPayloadFormat)
+
+		requireTasks(ctx, t, nil)
+	})
+
+	t.Run("duplicate fails the batch", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+		existing := newTasks(t, 1)
+		require.NoError(t, storage.AddTask(ctx, existing[0]))
+
+		tasks := newTasks(t, 2)
+		duplicate := entity.NewTaskWithExternalID(t.Name(), existing[0].Payload, existing[0].ExternalID)
+		tasks = append(tasks, duplicate)
+
+		err := storage.AddTasks(ctx, tasks, false)
+		require.ErrorIs(t, err, entity.ErrDuplicateTask)
+
+		requireTasks(ctx, t, existing)
+	})
+
+	t.Run("skip duplicates", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+		existing := newTasks(t, 1)
+		require.NoError(t, storage.AddTask(ctx, existing[0]))
+
+		tasks := newTasks(t, 2)
+		duplicate := entity.NewTaskWithExternalID(t.Name(), existing[0].Payload, existing[0].ExternalID)
+
+		err := storage.AddTasks(ctx, append([]*entity.Task{duplicate}, tasks...), true)
+		require.ErrorIs(t, err, entity.ErrDuplicateTask)
+
+		var tasksErr *entity.TasksError
+		require.ErrorAs(t, err, &tasksErr)
+		require.Len(t, tasksErr.Errors, 1)
+		require.ErrorIs(t, tasksErr.Errors[duplicate.ID], entity.ErrDuplicateTask)
+
+		requireTasks(ctx, t, append(existing, tasks...))
+	})
+
+	t.Run("skip duplicates within batch", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+		tasks := newTasks(t, 2)
+		duplicate := entity.NewTaskWithExternalID(t.Name(), tasks[0].Payload, tasks[0].ExternalID)
+
+		err := storage.AddTasks(ctx, append(tasks, duplicate), true)
+		var tasksErr *entity.TasksError
+		require.ErrorAs(t, err, &tasksErr)
+		require.Len(t, tasksErr.Errors, 1)
+		require.ErrorIs(t, tasksErr.Errors[duplicate.ID], entity.ErrDuplicateTask)
+
+		requireTasks(ctx, t, tasks)
+	})
+
+	t.Run("rollback discards tasks", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+		tx, err := storage.GetDB().BeginTxx(ctx, nil)
+		require.NoError(t, err)
+
+		tasks := newTasks(t, 2)
+		require.NoError(t, storage.AddTasks(goque.WithTx(ctx, tx), tasks, false))
+
+		require.NoError(t, tx.Rollback())
+
+		_, err = storage.GetTask(ctx, tasks[0].ID)
+		require.ErrorIs(t, err, sql.ErrNoRows)
+	})
+}
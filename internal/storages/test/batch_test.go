@@ -0,0 +1,190 @@
+package test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/ruko1202/xlog"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/ruko1202/goque/internal/entity"
+	"github.com/ruko1202/goque/internal/storages"
+	"github.com/ruko1202/goque/internal/storages/dbentity"
+	"github.com/ruko1202/goque/test/testutils"
+)
+
+func TestBatch(t *testing.T) {
+	testutils.RunMultiDBTests(t, taskStorages, testBatch)
+}
+
+//nolint:thelper
+func testBatch(t *testing.T, storage storages.AdvancedTaskStorage) {
+	t.Parallel()
+	ctx := context.Background()
+
+	newTask := func(t *testing.T, taskType entity.TaskType, opts ...entity.TaskOpts) *entity.Task {
+		t.Helper()
+		return entity.NewTask(taskType, testutils.ToJSON(t, &testutils.TestPayload{Data: "test"}), opts...)
+	}
+	addBatch := func(ctx context.Context, t *testing.T, tasks []*entity.Task, onComplete *entity.Task) *entity.Batch {
+		t.Helper()
+
+		batch, err := entity.NewBatch(tasks, onComplete)
+		require.NoError(t, err)
+		err = storage.AddBatch(ctx, batch, tasks, onComplete)
+		require.NoError(t, err)
+
+		return batch
+	}
+	requireStatus := func(ctx context.Context, t *testing.T, task *entity.Task, status entity.TaskStatus) *entity.Task {
+		t.Helper()
+
+		dbTask, err := storage.GetTask(ctx, task.ID)
+		require.NoError(t, err)
+		require.Equal(t, status, dbTask.Status)
+
+		return dbTask
+	}
+	finishTask := func(ctx context.Context, t *testing.T, task *entity.Task, status entity.TaskStatus) {
+		t.Helper()
+
+		task.Status = status
+		err := storage.UpdateTask(ctx, task.ID, task)
+		require.NoError(t, err)
+	}
+
+	t.Run("completion", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+		taskType := "test Batch completion " + uuid.NewString()
+		tasks := []*entity.Task{newTask(t, taskType), newTask(t, taskType), newTask(t, taskType)}
+		onComplete := newTask(t, taskType)
+		batch := addBatch(ctx, t, tasks, onComplete)
+
+		dbTask := requireStatus(ctx, t, tasks[0], entity.TaskStatusNew)
+		require.Equal(t, &batch.ID, dbTask.BatchID)
+		requireStatus(ctx, t, onComplete, entity.TaskStatusWaiting)
+
+		finishTask(ctx, t, tasks[0], entity.TaskStatusDone)
+		finishTask(ctx, t, tasks[1], entity.TaskStatusDone)
+		requireStatus(ctx, t, onComplete, entity.TaskStatusWaiting)
+
+		dbBatch, err := storage.GetBatch(ctx, batch.ID)
+		require.NoError(t, err)
+		require.EqualValues(t, 3, dbBatch.Total)
+		require.EqualValues(t, 2, dbBatch.Done)
+		require.Zero(t, dbBatch.Failed)
+		require.Equal(t, &onComplete.ID, dbBatch.CompletionTaskID)
+		require.False(t, dbBatch.IsCompleted())
+
+		finishTask(ctx, t, tasks[2], entity.TaskStatusAttemptsLeft)
+		dbOnComplete := requireStatus(ctx, t, onComplete, entity.TaskStatusNew)
+		require.Greater(t, dbOnComplete.Version, onComplete.Version)
+
+		dbBatch, err = storage.GetBatch(ctx, batch.ID)
+		require.NoError(t, err)
+		require.EqualValues(t, 2, dbBatch.Done)
+		require.EqualValues(t, 1, dbBatch.Failed)
+		require.True(t, dbBatch.IsCompleted())
+
+		// the completion task is queued once, a member finishing again doesn't touch it
+		finishTask(ctx, t, dbOnComplete, entity.TaskStatusDone)
+		retried, err := storage.RetryTasks(ctx, &dbentity.GetTasksFilter{IDs: []uuid.UUID{tasks[2].ID}})
+		require.NoError(t, err)
+		require.EqualValues(t, 1, retried)
+		finishTask(ctx, t, requireStatus(ctx, t, tasks[2], entity.TaskStatusNew), entity.TaskStatusDone)
+		requireStatus(ctx, t, onComplete, entity.TaskStatusDone)
+
+		completedBatch, err := storage.GetBatch(ctx, batch.ID)
+		require.NoError(t, err)
+		require.EqualValues(t, 3, completedBatch.Done)
+		require.Equal(t, dbBatch.CompletedAt, completedBatch.CompletedAt)
+	})
+
+	t.Run("failed tasks", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+		taskType := "test Batch failed " + uuid.NewString()
+		dead, canceled, failed := newTask(t, taskType), newTask(t, taskType), newTask(t, taskType)
+		dependent := newTask(t, taskType, entity.WithTaskDependsOn(failed.ID))
+		onComplete := newTask(t, taskType)
+		batch := addBatch(ctx, t, []*entity.Task{dead, canceled, failed, dependent}, onComplete)
+
+		dead.Status = entity.TaskStatusAttemptsLeft
+		err := storage.MoveTaskToDead(ctx, dead)
+		require.NoError(t, err)
+
+		count, err := storage.CancelTasks(ctx, &dbentity.GetTasksFilter{IDs: []uuid.UUID{canceled.ID}})
+		require.NoError(t, err)
+		require.EqualValues(t, 1, count)
+		requireStatus(ctx, t, onComplete, entity.TaskStatusWaiting)
+
+		// the dependent is canceled along with the last task, which completes the batch
+		finishTask(ctx, t, failed, entity.TaskStatusAttemptsLeft)
+		requireStatus(ctx, t, dependent, entity.TaskStatusCanceled)
+		requireStatus(ctx, t, onComplete, entity.TaskStatusNew)
+
+		dbBatch, err := storage.GetBatch(ctx, batch.ID)
+		require.NoError(t, err)
+		require.Zero(t, dbBatch.Done)
+		require.EqualValues(t, 4, dbBatch.Failed)
+		require.True(t, dbBatch.IsCompleted())
+	})
+
+	t.Run("bulk cancel", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+		taskType := "test Batch bulk cancel " + uuid.NewString()
+		onComplete := newTask(t, "test Batch bulk cancel completion "+uuid.NewString())
+		batch := addBatch(ctx, t, []*entity.Task{newTask(t, taskType), newTask(t, taskType)}, onComplete)
+
+		count, err := storage.CancelTasks(ctx, &dbentity.GetTasksFilter{TaskType: lo.ToPtr(taskType)})
+		require.NoError(t, err)
+		require.EqualValues(t, 2, count)
+		requireStatus(ctx, t, onComplete, entity.TaskStatusNew)
+
+		dbBatch, err := storage.GetBatch(ctx, batch.ID)
+		require.NoError(t, err)
+		require.EqualValues(t, 2, dbBatch.Failed)
+		require.True(t, dbBatch.IsCompleted())
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+		onComplete := newTask(t, "test Batch empty "+uuid.NewString())
+		batch := addBatch(ctx, t, nil, onComplete)
+		requireStatus(ctx, t, onComplete, entity.TaskStatusNew)
+
+		dbBatch, err := storage.GetBatch(ctx, batch.ID)
+		require.NoError(t, err)
+		require.Zero(t, dbBatch.Total)
+		require.True(t, dbBatch.IsCompleted())
+	})
+
+	t.Run("invalid task", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+		taskType := "test Batch invalid " + uuid.NewString()
+		tasks := []*entity.Task{newTask(t, taskType), entity.NewTask(taskType, "invalid")}
+		batch, err := entity.NewBatch(tasks, nil)
+		require.NoError(t, err)
+
+		err = storage.AddBatch(ctx, batch, tasks, nil)
+		require.ErrorIs(t, err, entity.ErrInvalidPayloadFormat)
+
+		_, err = storage.GetBatch(ctx, batch.ID)
+		require.ErrorIs(t, err, sql.ErrNoRows)
+		_, err = storage.GetTask(ctx, tasks[0].ID)
+		require.ErrorIs(t, err, sql.ErrNoRows)
+	})
+}
@@ -0,0 +1,144 @@
+package test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/ruko1202/xlog"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/ruko1202/goque/internal/entity"
+	"github.com/ruko1202/goque/internal/storages"
+	"github.com/ruko1202/goque/internal/storages/dbentity"
+	"github.com/ruko1202/goque/internal/storages/dbtx"
+	"github.com/ruko1202/goque/test/testutils"
+)
+
+func TestBulkTasks(t *testing.T) {
+	testutils.RunMultiDBTests(t, taskStorages, testBulkTasks)
+}
+
+//nolint:thelper
+func testBulkTasks(t *testing.T, storage storages.AdvancedTaskStorage) {
+	t.Parallel()
+	ctx := context.Background()
+
+	requireStatus := func(ctx context.Context, t *testing.T, task *entity.Task, status entity.TaskStatus) *entity.Task {
+		t.Helper()
+
+		dbTask, err := storage.GetTask(ctx, task.ID)
+		require.NoError(t, err)
+		require.Equal(t, status, dbTask.Status)
+
+		return dbTask
+	}
+
+	t.Run("cancel", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+		taskType := "test CancelTasks " + uuid.NewString()
+		newTask := makeTaskWithStatus(ctx, t, storage, taskType, entity.TaskStatusNew)
+		errorTask := makeTaskWithStatus(ctx, t, storage, taskType, entity.TaskStatusError)
+		doneTask := makeTaskWithStatus(ctx, t, storage, taskType, entity.TaskStatusDone)
+
+		canceled, err := storage.CancelTasks(ctx, &dbentity.GetTasksFilter{TaskType: lo.ToPtr(taskType)})
+		require.NoError(t, err)
+		require.EqualValues(t, 2, canceled)
+
+		requireStatus(ctx, t, newTask, entity.TaskStatusCanceled)
+		requireStatus(ctx, t, errorTask, entity.TaskStatusCanceled)
+		requireStatus(ctx, t, doneTask, entity.TaskStatusDone)
+	})
+
+	t.Run("retry", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+		taskType := "test RetryTasks " + uuid.NewString()
+		failedTask := makeTaskWithStatus(ctx, t, storage, taskType, entity.TaskStatusAttemptsLeft)
+		failedTask.Attempts = 3
+		failedTask.AddError(context.DeadlineExceeded)
+		updateTask(ctx, t, storage, failedTask)
+		canceledTask := makeTaskWithStatus(ctx, t, storage, taskType, entity.TaskStatusCanceled)
+		processingTask := makeTaskWithStatus(ctx, t, storage, taskType, entity.TaskStatusProcessing)
+		doneTask := makeTaskWithStatus(ctx, t, storage, taskType, entity.TaskStatusDone)
+		doneTask.Result = lo.ToPtr(`{"ok":true}`)
+		doneTask.Progress = entity.MaxTaskProgress
+		updateTask(ctx, t, storage, doneTask)
+
+		retried, err := storage.RetryTasks(ctx, &dbentity.GetTasksFilter{TaskType: lo.ToPtr(taskType)})
+		require.NoError(t, err)
+		require.EqualValues(t, 3, retried)
+
+		dbTask := requireStatus(ctx, t, failedTask, entity.TaskStatusNew)
+		require.Zero(t, dbTask.Attempts)
+		require.Contains(t, lo.FromPtr(dbTask.Errors), "attempt 3: context deadline exceeded")
+		require.Contains(t, lo.FromPtr(dbTask.Errors), "retry: ")
+
+		dbTask = requireStatus(ctx, t, canceledTask, entity.TaskStatusNew)
+		require.Contains(t, lo.FromPtr(dbTask.Errors), "retry: ")
+
+		dbTask = requireStatus(ctx, t, doneTask, entity.TaskStatusNew)
+		require.Nil(t, dbTask.Result)
+		require.Zero(t, dbTask.Progress)
+
+		requireStatus(ctx, t, processingTask, entity.TaskStatusProcessing)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+		taskType := "test DeleteTasksByFilter " + uuid.NewString()
+		doneTask := makeTaskWithStatus(ctx, t, storage, taskType, entity.TaskStatusDone)
+		newTask := makeTaskWithStatus(ctx, t, storage, taskType, entity.TaskStatusNew)
+
+		deleted, err := storage.DeleteTasksByFilter(ctx, &dbentity.GetTasksFilter{
+			TaskType: lo.ToPtr(taskType),
+			Status:   lo.ToPtr(entity.TaskStatusDone),
+		})
+		require.NoError(t, err)
+		require.EqualValues(t, 1, deleted)
+
+		_, err = storage.GetTask(ctx, doneTask.ID)
+		require.ErrorIs(t, err, sql.ErrNoRows)
+		requireStatus(ctx, t, newTask, entity.TaskStatusNew)
+	})
+
+	t.Run("empty filter", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+		_, err := storage.CancelTasks(ctx, &dbentity.GetTasksFilter{})
+		require.ErrorIs(t, err, dbentity.ErrEmptyFilter)
+
+		_, err = storage.RetryTasks(ctx, &dbentity.GetTasksFilter{})
+		require.ErrorIs(t, err, dbentity.ErrEmptyFilter)
+
+		_, err = storage.DeleteTasksByFilter(ctx, &dbentity.GetTasksFilter{})
+		require.ErrorIs(t, err, dbentity.ErrEmptyFilter)
+	})
+
+	t.Run("within tx", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+		taskType := "test CancelTasks tx " + uuid.NewString()
+		task := makeTask(ctx, t, storage, taskType)
+
+		tx, err := storage.GetDB().BeginTxx(ctx, nil)
+		require.NoError(t, err)
+
+		canceled, err := storage.CancelTasks(dbtx.WithTx(ctx, tx), &dbentity.GetTasksFilter{TaskType: lo.ToPtr(taskType)})
+		require.NoError(t, err)
+		require.EqualValues(t, 1, canceled)
+		require.NoError(t, tx.Rollback())
+
+		requireStatus(ctx, t, task, entity.TaskStatusNew)
+	})
+}
@@ -50,6 +50,28 @@ func testCureTasks(t *testing.T, storage storages.AdvancedTaskStorage) {
 		testutils.EqualTask(t, task, actualTask)
 	})
 
+	t.Run("scheduled task is not stuck", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+		task := entity.NewTask("test cure scheduled task"+uuid.NewString(),
+			testutils.ToJSON(t, &testutils.TestPayload{Data: "test"}),
+			entity.WithTaskDelay(24*time.Hour),
+		)
+		task.UpdatedAt = lo.ToPtr(xtime.Now().Add(-2 * time.Hour))
+		require.NoError(t, storage.AddTask(ctx, task))
+
+		tasks, err := storage.CureTasks(ctx, task.Type, []entity.TaskStatus{
+			entity.TaskStatusPending,
+		}, time.Hour, "comment")
+		require.NoError(t, err)
+		require.Empty(t, tasks)
+
+		actualTask, err := storage.GetTask(ctx, task.ID)
+		require.NoError(t, err)
+		testutils.EqualTask(t, task, actualTask)
+	})
+
 	// Pins the race fix: two concurrent CureTasks calls against the same
 	// stuck pool must, in total, return each task exactly once. Without
 	// FOR UPDATE SKIP LOCKED on MySQL the SELECT/UPDATE pair would let
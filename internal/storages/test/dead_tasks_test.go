@@ -0,0 +1,162 @@
+package test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/ruko1202/xlog"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/ruko1202/goque/internal/entity"
+	"github.com/ruko1202/goque/internal/storages"
+	"github.com/ruko1202/goque/internal/storages/dbentity"
+	"github.com/ruko1202/goque/test/testutils"
+)
+
+func TestDeadTasks(t *testing.T) {
+	testutils.RunMultiDBTests(t, taskStorages, testDeadTasks)
+}
+
+//nolint:thelper
+func testDeadTasks(t *testing.T, storage storages.AdvancedTaskStorage) {
+	t.Parallel()
+	ctx := context.Background()
+
+	makeDeadTask := func(ctx context.Context, t *testing.T, taskType entity.TaskType) *entity.Task {
+		t.Helper()
+
+		task := makeTask(ctx, t, storage, taskType)
+		task.Attempts = 3
+		task.Status = entity.TaskStatusAttemptsLeft
+		task.AddError(context.DeadlineExceeded)
+
+		err := storage.MoveTaskToDead(ctx, task)
+		require.NoError(t, err)
+
+		return task
+	}
+
+	t.Run("move to dead", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+		task := makeDeadTask(ctx, t, "test MoveTaskToDead")
+
+		_, err := storage.GetTask(ctx, task.ID)
+		require.ErrorIs(t, err, sql.ErrNoRows)
+
+		deadTasks, err := storage.GetDeadTasks(ctx, &dbentity.DeadTasksFilter{IDs: []uuid.UUID{task.ID}}, 10)
+		require.NoError(t, err)
+		require.Len(t, deadTasks, 1)
+		testutils.EqualTask(t, task, deadTasks[0])
+	})
+
+	t.Run("move canceled task", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+		task := makeTaskWithStatus(ctx, t, storage, "test MoveTaskToDead canceled", entity.TaskStatusProcessing)
+		deadTask := *task
+
+		task.Status = entity.TaskStatusCanceled
+		err := storage.UpdateTask(ctx, task.ID, task)
+		require.NoError(t, err)
+
+		deadTask.Status = entity.TaskStatusAttemptsLeft
+		err = storage.MoveTaskToDead(ctx, &deadTask)
+		require.ErrorIs(t, err, entity.ErrTaskStateConflict)
+		require.ErrorIs(t, err, entity.ErrTaskCancelRequested)
+
+		dbTask, err := storage.GetTask(ctx, task.ID)
+		require.NoError(t, err)
+		require.Equal(t, entity.TaskStatusCanceled, dbTask.Status)
+
+		deadTasks, err := storage.GetDeadTasks(ctx, &dbentity.DeadTasksFilter{IDs: []uuid.UUID{task.ID}}, 10)
+		require.NoError(t, err)
+		require.Empty(t, deadTasks)
+	})
+
+	t.Run("get by type", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+		taskType := "test GetDeadTasks " + uuid.NewString()
+		first := makeDeadTask(ctx, t, taskType)
+		second := makeDeadTask(ctx, t, taskType)
+		makeDeadTask(ctx, t, "test GetDeadTasks other")
+
+		deadTasks, err := storage.GetDeadTasks(ctx, &dbentity.DeadTasksFilter{TaskType: lo.ToPtr(taskType)}, 10)
+		require.NoError(t, err)
+		require.ElementsMatch(t,
+			[]uuid.UUID{first.ID, second.ID},
+			lo.Map(deadTasks, func(task *entity.Task, _ int) uuid.UUID { return task.ID }),
+		)
+	})
+
+	t.Run("requeue", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+		taskType := "test RequeueDeadTasks " + uuid.NewString()
+		task := makeDeadTask(ctx, t, taskType)
+
+		requeued, err := storage.RequeueDeadTasks(ctx, &dbentity.DeadTasksFilter{TaskType: lo.ToPtr(taskType)})
+		require.NoError(t, err)
+		require.Len(t, requeued, 1)
+
+		dbTask, err := storage.GetTask(ctx, task.ID)
+		require.NoError(t, err)
+		require.Equal(t, entity.TaskStatusNew, dbTask.Status)
+		require.Zero(t, dbTask.Attempts)
+		require.Contains(t, lo.FromPtr(dbTask.Errors), "attempt 3: context deadline exceeded")
+		require.Contains(t, lo.FromPtr(dbTask.Errors), "requeued from dead letters")
+
+		deadTasks, err := storage.GetDeadTasks(ctx, &dbentity.DeadTasksFilter{TaskType: lo.ToPtr(taskType)}, 10)
+		require.NoError(t, err)
+		require.Empty(t, deadTasks)
+	})
+
+	t.Run("requeue duplicate", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+		taskType := "test RequeueDeadTasks duplicate " + uuid.NewString()
+		task := makeDeadTask(ctx, t, taskType)
+
+		duplicate := entity.NewTaskWithExternalID(taskType, task.Payload, task.ExternalID)
+		require.NoError(t, storage.AddTask(ctx, duplicate))
+
+		_, err := storage.RequeueDeadTasks(ctx, &dbentity.DeadTasksFilter{TaskType: lo.ToPtr(taskType)})
+		require.ErrorIs(t, err, entity.ErrDuplicateTask)
+
+		deadTasks, err := storage.GetDeadTasks(ctx, &dbentity.DeadTasksFilter{TaskType: lo.ToPtr(taskType)}, 10)
+		require.NoError(t, err)
+		require.Len(t, deadTasks, 1)
+	})
+
+	t.Run("purge", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+		taskType := "test DeleteDeadTasks " + uuid.NewString()
+		task := makeDeadTask(ctx, t, taskType)
+		makeDeadTask(ctx, t, taskType)
+
+		deleted, err := storage.DeleteDeadTasks(ctx, &dbentity.DeadTasksFilter{IDs: []uuid.UUID{task.ID}})
+		require.NoError(t, err)
+		require.Len(t, deleted, 1)
+		require.Equal(t, task.ID, deleted[0].ID)
+
+		deleted, err = storage.DeleteDeadTasks(ctx, &dbentity.DeadTasksFilter{TaskType: lo.ToPtr(taskType)})
+		require.NoError(t, err)
+		require.Len(t, deleted, 1)
+
+		deadTasks, err := storage.GetDeadTasks(ctx, &dbentity.DeadTasksFilter{TaskType: lo.ToPtr(taskType)}, 10)
+		require.NoError(t, err)
+		require.Empty(t, deadTasks)
+	})
+}
@@ -45,7 +45,7 @@ func testGetTaskForProcessing(t *testing.T, storage storages.AdvancedTaskStorage
 			}
 		}
 
-		tasks, err := storage.GetTasksForProcessing(ctx, taskType, 10)
+		tasks, err := storage.GetTasksForProcessing(ctx, taskType, 10, testLease, 0)
 		require.NoError(t, err)
 		require.Equal(t, len(expectedTasks), len(tasks))
 
@@ -60,11 +60,130 @@ func testGetTaskForProcessing(t *testing.T, storage storages.AdvancedTaskStorage
 		}
 	})
 
+	t.Run("ordered by priority", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+		taskType := "test GetTaskForProcessing priority" + uuid.NewString()
+		payload := testutils.ToJSON(t, &testutils.TestPayload{Data: "test"})
+
+		low := entity.NewTask(taskType, payload)
+		low.NextAttemptAt = xtime.Now().Add(-2 * time.Hour)
+		high := entity.NewTask(taskType, payload, entity.WithTaskPriority(10))
+		high.NextAttemptAt = xtime.Now().Add(-time.Hour)
+		highOlder := entity.NewTask(taskType, payload, entity.WithTaskPriority(10))
+		highOlder.NextAttemptAt = xtime.Now().Add(-90 * time.Minute)
+
+		for _, task := range []*entity.Task{low, high, highOlder} {
+			require.NoError(t, storage.AddTask(ctx, task))
+		}
+
+		tasks, err := storage.GetTasksForProcessing(ctx, taskType, 2, testLease, 0)
+		require.NoError(t, err)
+		require.Len(t, tasks, 2)
+		require.Equal(t, highOlder.ID, tasks[0].ID)
+		require.Equal(t, high.ID, tasks[1].ID)
+
+		tasks, err = storage.GetTasksForProcessing(ctx, taskType, 2, testLease, 0)
+		require.NoError(t, err)
+		require.Len(t, tasks, 1)
+		require.Equal(t, low.ID, tasks[0].ID)
+	})
+
+	t.Run("scheduled tasks", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+		taskType := "test GetTaskForProcessing scheduled" + uuid.NewString()
+		payload := testutils.ToJSON(t, &testutils.TestPayload{Data: "test"})
+
+		longScheduled := entity.NewTask(taskType, payload, entity.WithTaskDelay(time.Hour))
+		require.NoError(t, storage.AddTask(ctx, longScheduled))
+		scheduled := entity.NewTask(taskType, payload, entity.WithTaskDelay(2*time.Second))
+		// a scheduled task is told apart by its next attempt time only, not by updated_at
+		scheduled.UpdatedAt = lo.ToPtr(xtime.Now())
+		require.NoError(t, storage.AddTask(ctx, scheduled))
+		require.Equal(t, entity.TaskStatusNew, scheduled.Status)
+
+		tasks, err := storage.GetTasksForProcessing(ctx, taskType, 10, testLease, 0)
+		require.NoError(t, err)
+		require.Empty(t, tasks, "tasks scheduled for the future must not be fetched")
+
+		require.Eventually(t, func() bool {
+			tasks, err = storage.GetTasksForProcessing(ctx, taskType, 10, testLease, 0)
+			require.NoError(t, err)
+			return len(tasks) > 0
+		}, 5*time.Second, 100*time.Millisecond)
+		require.Len(t, tasks, 1)
+		require.Equal(t, scheduled.ID, tasks[0].ID)
+		require.Equal(t, entity.TaskStatusPending, tasks[0].Status)
+		require.NotNil(t, tasks[0].UpdatedAt)
+
+		// the fetched task is claimed and must not be fetched again
+		tasks, err = storage.GetTasksForProcessing(ctx, taskType, 10, testLease, 0)
+		require.NoError(t, err)
+		require.Empty(t, tasks)
+	})
+
+	t.Run("concurrency limit per key", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+		taskType := "test GetTaskForProcessing concurrency key" + uuid.NewString()
+		otherType := "test GetTaskForProcessing concurrency key other" + uuid.NewString()
+		payload := testutils.ToJSON(t, &testutils.TestPayload{Data: "test"})
+		key := "customer-" + uuid.NewString()
+		otherKey := "customer-" + uuid.NewString()
+
+		// a task of another type with the same key is already in flight
+		inFlight := entity.NewTask(otherType, payload, entity.WithTaskConcurrencyKey(key))
+		require.NoError(t, storage.AddTask(ctx, inFlight))
+		tasks, err := storage.GetTasksForProcessing(ctx, otherType, 1, testLease, 0)
+		require.NoError(t, err)
+		require.Len(t, tasks, 1)
+
+		keyed := make([]*entity.Task, 0)
+		for i := range 3 {
+			task := entity.NewTask(taskType, payload, entity.WithTaskConcurrencyKey(key))
+			task.NextAttemptAt = xtime.Now().Add(-time.Duration(3-i) * time.Hour)
+			require.NoError(t, storage.AddTask(ctx, task))
+			keyed = append(keyed, task)
+		}
+		otherKeyed := entity.NewTask(taskType, payload, entity.WithTaskConcurrencyKey(otherKey))
+		require.NoError(t, storage.AddTask(ctx, otherKeyed))
+		// a scheduled task is pending, but not in flight
+		scheduled := entity.NewTask(taskType, payload, entity.WithTaskConcurrencyKey(otherKey), entity.WithTaskDelay(time.Hour))
+		require.NoError(t, storage.AddTask(ctx, scheduled))
+		unkeyed := entity.NewTask(taskType, payload)
+		require.NoError(t, storage.AddTask(ctx, unkeyed))
+
+		tasks, err = storage.GetTasksForProcessing(ctx, taskType, 10, testLease, 2)
+		require.NoError(t, err)
+		require.ElementsMatch(t,
+			[]uuid.UUID{keyed[0].ID, otherKeyed.ID, unkeyed.ID},
+			lo.Map(tasks, func(task *entity.Task, _ int) uuid.UUID { return task.ID }),
+		)
+		require.Equal(t, key, lo.FromPtr(tasks[0].ConcurrencyKey))
+
+		// the key is at the limit until one of its tasks is finished
+		tasks, err = storage.GetTasksForProcessing(ctx, taskType, 10, testLease, 2)
+		require.NoError(t, err)
+		require.Empty(t, tasks)
+
+		inFlight.Status = entity.TaskStatusDone
+		updateTask(ctx, t, storage, inFlight)
+
+		tasks, err = storage.GetTasksForProcessing(ctx, taskType, 10, testLease, 2)
+		require.NoError(t, err)
+		require.Len(t, tasks, 1)
+		require.Equal(t, keyed[1].ID, tasks[0].ID)
+	})
+
 	t.Run("not found", func(t *testing.T) {
 		t.Parallel()
 		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
 
-		tasks, err := storage.GetTasksForProcessing(ctx, "not found", 10)
+		tasks, err := storage.GetTasksForProcessing(ctx, "not found", 10, testLease, 0)
 		require.NoError(t, err)
 		require.Equal(t, 0, len(tasks))
 	})
@@ -3,6 +3,7 @@ package test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/ruko1202/xlog"
@@ -13,6 +14,7 @@ import (
 	"github.com/ruko1202/goque/internal/entity"
 	"github.com/ruko1202/goque/internal/storages"
 	"github.com/ruko1202/goque/internal/storages/dbentity"
+	"github.com/ruko1202/goque/internal/utils/goquectx"
 	"github.com/ruko1202/goque/test/testutils"
 )
 
@@ -62,6 +64,184 @@ func testGetTask(t *testing.T, storage storages.AdvancedTaskStorage) {
 		})
 	})
 
+	t.Run("by priority", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+		taskType := "test GetTask: priority" + uuid.NewString()
+		payload := testutils.ToJSON(t, &testutils.TestPayload{Data: "test"})
+
+		urgent := entity.NewTask(taskType, payload, entity.WithTaskPriority(100))
+		require.NoError(t, storage.AddTask(ctx, urgent))
+		require.NoError(t, storage.AddTask(ctx, entity.NewTask(taskType, payload)))
+
+		tasks, err := storage.GetTasks(ctx, &dbentity.GetTasksFilter{
+			TaskType: &taskType,
+			Priority: lo.ToPtr(int32(100)),
+		}, 10)
+		require.NoError(t, err)
+		require.Len(t, tasks, 1)
+		testutils.EqualTask(t, urgent, tasks[0])
+	})
+
+	t.Run("by external id", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+		taskType := "test GetTask: external id" + uuid.NewString()
+		payload := testutils.ToJSON(t, &testutils.TestPayload{Data: "test"})
+
+		task := entity.NewTaskWithExternalID(taskType, payload, uuid.NewString())
+		require.NoError(t, storage.AddTask(ctx, task))
+		require.NoError(t, storage.AddTask(ctx, entity.NewTask(taskType, payload)))
+
+		tasks, err := storage.GetTasks(ctx, &dbentity.GetTasksFilter{
+			TaskType:   &taskType,
+			ExternalID: &task.ExternalID,
+		}, 10)
+		require.NoError(t, err)
+		require.Len(t, tasks, 1)
+		testutils.EqualTask(t, task, tasks[0])
+	})
+
+	t.Run("by external id prefix", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+		taskType := "test GetTask: external id prefix" + uuid.NewString()
+		payload := testutils.ToJSON(t, &testutils.TestPayload{Data: "test"})
+
+		task := entity.NewTaskWithExternalID(taskType, payload, "order_1%-"+uuid.NewString())
+		require.NoError(t, storage.AddTask(ctx, task))
+		require.NoError(t, storage.AddTask(ctx, entity.NewTaskWithExternalID(taskType, payload, "order_12-"+uuid.NewString())))
+
+		tasks, err := storage.GetTasks(ctx, &dbentity.GetTasksFilter{
+			TaskType:         &taskType,
+			ExternalIDPrefix: lo.ToPtr("order_1%"),
+		}, 10)
+		require.NoError(t, err)
+		require.Len(t, tasks, 1)
+		testutils.EqualTask(t, task, tasks[0])
+	})
+
+	t.Run("by attempts", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+		taskType := "test GetTask: attempts" + uuid.NewString()
+		payload := testutils.ToJSON(t, &testutils.TestPayload{Data: "test"})
+
+		tasks := lo.Times(4, func(i int) *entity.Task {
+			task := entity.NewTask(taskType, payload)
+			task.Attempts = int32(i)
+			require.NoError(t, storage.AddTask(ctx, task))
+			return task
+		})
+
+		dbTasks, err := storage.GetTasks(ctx, &dbentity.GetTasksFilter{
+			TaskType:     &taskType,
+			AttemptsFrom: lo.ToPtr(int32(1)),
+			AttemptsTo:   lo.ToPtr(int32(2)),
+		}, 10)
+		require.NoError(t, err)
+		require.ElementsMatch(t,
+			[]uuid.UUID{tasks[1].ID, tasks[2].ID},
+			lo.Map(dbTasks, func(task *entity.Task, _ int) uuid.UUID { return task.ID }),
+		)
+	})
+
+	t.Run("by metadata", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+		taskType := "test GetTask: metadata" + uuid.NewString()
+		payload := testutils.ToJSON(t, &testutils.TestPayload{Data: "test"})
+		tenant := uuid.NewString()
+
+		task := entity.NewTask(taskType, payload)
+		require.NoError(t, storage.AddTask(goquectx.WithValues(ctx, entity.Metadata{"tenant": tenant, "region": "eu"}), task))
+		require.NoError(t, storage.AddTask(goquectx.WithValues(ctx, entity.Metadata{"tenant": tenant, "region": "us"}), entity.NewTask(taskType, payload)))
+		require.NoError(t, storage.AddTask(ctx, entity.NewTask(taskType, payload)))
+
+		tasks, err := storage.GetTasks(ctx, &dbentity.GetTasksFilter{
+			TaskType: &taskType,
+			Metadata: map[string]string{"tenant": tenant, "region": "eu"},
+		}, 10)
+		require.NoError(t, err)
+		require.Len(t, tasks, 1)
+		require.Equal(t, task.ID, tasks[0].ID)
+	})
+
+	t.Run("by time ranges", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+		taskType := "test GetTask: time ranges" + uuid.NewString()
+		payload := testutils.ToJSON(t, &testutils.TestPayload{Data: "test"})
+		now := time.Now().Truncate(time.Second)
+
+		tasks := lo.Times(3, func(i int) *entity.Task {
+			task := entity.NewTask(taskType, payload)
+			task.CreatedAt = now.Add(-time.Duration(i) * time.Hour)
+			task.NextAttemptAt = now.Add(time.Duration(i) * time.Hour)
+			require.NoError(t, storage.AddTask(ctx, task))
+			return task
+		})
+
+		dbTasks, err := storage.GetTasks(ctx, &dbentity.GetTasksFilter{
+			TaskType:      &taskType,
+			CreatedAtFrom: lo.ToPtr(now.Add(-90 * time.Minute)),
+			CreatedAtTo:   lo.ToPtr(now.Add(-30 * time.Minute)),
+		}, 10)
+		require.NoError(t, err)
+		require.Len(t, dbTasks, 1)
+		require.Equal(t, tasks[1].ID, dbTasks[0].ID)
+
+		dbTasks, err = storage.GetTasks(ctx, &dbentity.GetTasksFilter{
+			TaskType:          &taskType,
+			NextAttemptAtFrom: lo.ToPtr(now.Add(time.Hour)),
+		}, 10)
+		require.NoError(t, err)
+		require.ElementsMatch(t,
+			[]uuid.UUID{tasks[1].ID, tasks[2].ID},
+			lo.Map(dbTasks, func(task *entity.Task, _ int) uuid.UUID { return task.ID }),
+		)
+	})
+
+	t.Run("keyset pagination", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+		taskType := "test GetTask: pagination" + uuid.NewString()
+		for range 5 {
+			makeTask(ctx, t, storage, taskType)
+		}
+
+		for _, desc := range []bool{false, true} {
+			allTasks, err := storage.GetTasks(ctx, &dbentity.GetTasksFilter{TaskType: &taskType, Desc: desc}, 10)
+			require.NoError(t, err)
+			require.Len(t, allTasks, 5)
+
+			pagedTasks := make([]*entity.Task, 0, len(allTasks))
+			filter := &dbentity.GetTasksFilter{TaskType: &taskType, Desc: desc}
+			for {
+				page, err := storage.GetTasks(ctx, filter, 2)
+				require.NoError(t, err)
+				if len(page) == 0 {
+					break
+				}
+				pagedTasks = append(pagedTasks, page...)
+
+				cursor, err := dbentity.ParseTaskCursor(dbentity.NewTaskCursor(page[len(page)-1]).String())
+				require.NoError(t, err)
+				filter.After = cursor
+			}
+
+			taskID := func(task *entity.Task, _ int) uuid.UUID { return task.ID }
+			require.Equal(t, lo.Map(allTasks, taskID), lo.Map(pagedTasks, taskID))
+		}
+	})
+
 	t.Run("empty filter", func(t *testing.T) {
 		t.Parallel()
 		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
@@ -0,0 +1,166 @@
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ruko1202/xlog"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/ruko1202/goque/internal/entity"
+	"github.com/ruko1202/goque/internal/storages"
+	"github.com/ruko1202/goque/internal/storages/dbentity"
+	"github.com/ruko1202/goque/internal/storages/dbtx"
+	"github.com/ruko1202/goque/test/testutils"
+)
+
+func TestListenTasks(t *testing.T) {
+	testutils.RunMultiDBTests(t, taskStorages, testListenTasks)
+}
+
+//nolint:thelper
+func testListenTasks(t *testing.T, storage storages.AdvancedTaskStorage) {
+	t.Parallel()
+	ctx := context.Background()
+
+	listener, ok := storage.(storages.TaskListener)
+	if !ok {
+		t.Skip("storage doesn't support task notifications")
+	}
+
+	t.Run("notified on add task", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		taskType := "test ListenTasks" + uuid.NewString()
+		notifications, err := listener.ListenTasks(ctx, taskType)
+		require.NoError(t, err)
+
+		makeTask(ctx, t, storage, "test ListenTasks: another type"+uuid.NewString())
+		requireNoNotification(t, notifications)
+
+		makeTask(ctx, t, storage, taskType)
+		requireNotification(t, notifications)
+
+		cancel()
+		require.Eventually(t, func() bool {
+			_, open := <-notifications
+			return !open
+		}, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("scheduled task is not notified", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		taskType := "test ListenTasks: scheduled" + uuid.NewString()
+		notifications, err := listener.ListenTasks(ctx, taskType)
+		require.NoError(t, err)
+
+		task := entity.NewTask(taskType, testutils.ToJSON(t, &testutils.TestPayload{Data: "test"}), entity.WithTaskDelay(time.Hour))
+		require.NoError(t, storage.AddTask(ctx, task))
+		requireNoNotification(t, notifications)
+	})
+
+	t.Run("notified on tx commit", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		taskType := "test ListenTasks: tx" + uuid.NewString()
+		notifications, err := listener.ListenTasks(ctx, taskType)
+		require.NoError(t, err)
+
+		tx, err := storage.GetDB().BeginTxx(ctx, nil)
+		require.NoError(t, err)
+		makeTask(dbtx.WithTx(ctx, tx), t, storage, taskType)
+		requireNoNotification(t, notifications)
+
+		require.NoError(t, tx.Commit())
+		requireNotification(t, notifications)
+	})
+}
+
+func TestListenFinishedTasks(t *testing.T) {
+	testutils.RunMultiDBTests(t, taskStorages, testListenFinishedTasks)
+}
+
+//nolint:thelper
+func testListenFinishedTasks(t *testing.T, storage storages.AdvancedTaskStorage) {
+	t.Parallel()
+	ctx := xlog.ContextWithLogger(context.Background(), xlog.NewZapAdapter(zaptest.NewLogger(t)))
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	listener, ok := storage.(storages.TaskListener)
+	if !ok {
+		t.Skip("storage doesn't support task notifications")
+	}
+
+	// the channel is shared by all tasks, so notifications of the parallel tests are skipped
+	notifications, err := listener.ListenFinishedTasks(ctx)
+	require.NoError(t, err)
+	requireFinished := func(t *testing.T, taskID uuid.UUID) {
+		t.Helper()
+		timeout := time.After(5 * time.Second)
+		for {
+			select {
+			case finishedID, ok := <-notifications:
+				require.True(t, ok, "notifications channel is closed")
+				if finishedID == taskID {
+					return
+				}
+			case <-timeout:
+				t.Fatal("notification is not received")
+			}
+		}
+	}
+
+	task := makeTask(ctx, t, storage, "test ListenFinishedTasks")
+	task.Status = entity.TaskStatusProcessing
+	require.NoError(t, storage.UpdateTask(ctx, task.ID, task))
+
+	task.Status = entity.TaskStatusDone
+	require.NoError(t, storage.UpdateTask(ctx, task.ID, task))
+	requireFinished(t, task.ID)
+
+	task = makeTask(ctx, t, storage, "test ListenFinishedTasks: dead")
+	task.Status = entity.TaskStatusAttemptsLeft
+	require.NoError(t, storage.MoveTaskToDead(ctx, task))
+	requireFinished(t, task.ID)
+
+	task = makeTask(ctx, t, storage, "test ListenFinishedTasks: cancel")
+	canceled, err := storage.CancelTasks(ctx, &dbentity.GetTasksFilter{IDs: []uuid.UUID{task.ID}})
+	require.NoError(t, err)
+	require.EqualValues(t, 1, canceled)
+	requireFinished(t, task.ID)
+}
+
+func requireNotification(t *testing.T, notifications <-chan struct{}) {
+	t.Helper()
+
+	select {
+	case _, ok := <-notifications:
+		require.True(t, ok, "notifications channel is closed")
+	case <-time.After(5 * time.Second):
+		t.Fatal("notification is not received")
+	}
+}
+
+func requireNoNotification(t *testing.T, notifications <-chan struct{}) {
+	t.Helper()
+
+	select {
+	case <-notifications:
+		t.Fatal("unexpected notification")
+	case <-time.After(200 * time.Millisecond):
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"context"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/ruko1202/xlog"
 	"github.com/stretchr/testify/require"
@@ -23,6 +24,7 @@ import (
 
 var (
 	taskStorages []storages.AdvancedTaskStorage
+	testLease    = entity.TaskLease{Owner: "test", Duration: time.Minute}
 )
 
 func TestMain(m *testing.M) {
@@ -0,0 +1,76 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/ruko1202/xlog"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/ruko1202/goque/internal/storages"
+	"github.com/ruko1202/goque/test/testutils"
+)
+
+func TestPausedTaskTypes(t *testing.T) {
+	testutils.RunMultiDBTests(t, taskStorages, testPausedTaskTypes)
+}
+
+//nolint:thelper
+func testPausedTaskTypes(t *testing.T, storage storages.AdvancedTaskStorage) {
+	t.Parallel()
+	ctx := context.Background()
+
+	t.Run("pause and resume", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+		taskType := "test paused task type " + uuid.NewString()
+
+		paused, err := storage.IsTaskTypePaused(ctx, taskType)
+		require.NoError(t, err)
+		require.False(t, paused)
+
+		err = storage.PauseTaskType(ctx, taskType)
+		require.NoError(t, err)
+		// pausing a paused type is a no-op
+		err = storage.PauseTaskType(ctx, taskType)
+		require.NoError(t, err)
+
+		paused, err = storage.IsTaskTypePaused(ctx, taskType)
+		require.NoError(t, err)
+		require.True(t, paused)
+
+		pausedTypes, err := storage.GetPausedTaskTypes(ctx)
+		require.NoError(t, err)
+		require.Contains(t, pausedTypes, taskType)
+
+		err = storage.ResumeTaskType(ctx, taskType)
+		require.NoError(t, err)
+		// resuming a type that isn't paused is a no-op
+		err = storage.ResumeTaskType(ctx, taskType)
+		require.NoError(t, err)
+
+		paused, err = storage.IsTaskTypePaused(ctx, taskType)
+		require.NoError(t, err)
+		require.False(t, paused)
+
+		pausedTypes, err = storage.GetPausedTaskTypes(ctx)
+		require.NoError(t, err)
+		require.NotContains(t, pausedTypes, taskType)
+	})
+
+	t.Run("pause does not affect other types", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+		taskType := "test paused task type " + uuid.NewString()
+		otherTaskType := "test paused task type " + uuid.NewString()
+
+		err := storage.PauseTaskType(ctx, taskType)
+		require.NoError(t, err)
+
+		paused, err := storage.IsTaskTypePaused(ctx, otherTaskType)
+		require.NoError(t, err)
+		require.False(t, paused)
+	})
+}
@@ -0,0 +1,133 @@
+package test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ruko1202/xlog"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/ruko1202/goque/internal/entity"
+	"github.com/ruko1202/goque/internal/storages"
+	"github.com/ruko1202/goque/test/testutils"
+)
+
+func TestRateLimit(t *testing.T) {
+	testutils.RunMultiDBTests(t, taskStorages, testRateLimit)
+}
+
+//nolint:thelper
+func testRateLimit(t *testing.T, storage storages.AdvancedTaskStorage) {
+	t.Parallel()
+	ctx := context.Background()
+
+	t.Run("take up to burst", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+		name := "test rate limit " + uuid.NewString()
+		limit := entity.RateLimit{Tokens: 10, Interval: time.Hour, Burst: 5}
+
+		taken, err := storage.TakeRateLimitTokens(ctx, name, limit, 10)
+		require.NoError(t, err)
+		require.EqualValues(t, 5, taken)
+
+		taken, err = storage.TakeRateLimitTokens(ctx, name, limit, 10)
+		require.NoError(t, err)
+		require.EqualValues(t, 0, taken)
+	})
+
+	t.Run("take up to max tokens", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+		name := "test rate limit " + uuid.NewString()
+		limit := entity.RateLimit{Tokens: 10, Interval: time.Hour}
+
+		taken, err := storage.TakeRateLimitTokens(ctx, name, limit, 3)
+		require.NoError(t, err)
+		require.EqualValues(t, 3, taken)
+
+		taken, err = storage.TakeRateLimitTokens(ctx, name, limit, 10)
+		require.NoError(t, err)
+		require.EqualValues(t, 7, taken)
+	})
+
+	t.Run("refill", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+		name := "test rate limit " + uuid.NewString()
+		limit := entity.RateLimit{Tokens: 100, Interval: time.Second}
+
+		taken, err := storage.TakeRateLimitTokens(ctx, name, limit, 100)
+		require.NoError(t, err)
+		require.EqualValues(t, 100, taken)
+
+		time.Sleep(100 * time.Millisecond)
+
+		taken, err = storage.TakeRateLimitTokens(ctx, name, limit, 100)
+		require.NoError(t, err)
+		require.Positive(t, taken)
+		require.Less(t, taken, int64(100))
+	})
+
+	t.Run("return", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+		name := "test rate limit " + uuid.NewString()
+		limit := entity.RateLimit{Tokens: 10, Interval: time.Hour}
+
+		taken, err := storage.TakeRateLimitTokens(ctx, name, limit, 10)
+		require.NoError(t, err)
+		require.EqualValues(t, 10, taken)
+
+		err = storage.ReturnRateLimitTokens(ctx, name, limit, 3)
+		require.NoError(t, err)
+
+		taken, err = storage.TakeRateLimitTokens(ctx, name, limit, 10)
+		require.NoError(t, err)
+		require.EqualValues(t, 3, taken)
+	})
+
+	t.Run("return up to capacity", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+		name := "test rate limit " + uuid.NewString()
+		limit := entity.RateLimit{Tokens: 10, Interval: time.Hour}
+
+		err := storage.ReturnRateLimitTokens(ctx, name, limit, 5)
+		require.NoError(t, err)
+
+		taken, err := storage.TakeRateLimitTokens(ctx, name, limit, 100)
+		require.NoError(t, err)
+		require.EqualValues(t, 10, taken)
+	})
+
+	t.Run("concurrent takes share the bucket", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+		name := "test rate limit " + uuid.NewString()
+		limit := entity.RateLimit{Tokens: 5, Interval: time.Hour}
+
+		var (
+			wg    sync.WaitGroup
+			total atomic.Int64
+		)
+		for range 10 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				taken, err := storage.TakeRateLimitTokens(ctx, name, limit, 1)
+				if err == nil {
+					total.Add(taken)
+				}
+			}()
+		}
+		wg.Wait()
+
+		require.LessOrEqual(t, total.Load(), int64(5))
+	})
+}
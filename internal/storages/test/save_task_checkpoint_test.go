@@ -0,0 +1,66 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ruko1202/xlog"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/ruko1202/goque/internal/entity"
+	"github.com/ruko1202/goque/internal/storages"
+	"github.com/ruko1202/goque/test/testutils"
+)
+
+func TestSaveTaskCheckpoint(t *testing.T) {
+	testutils.RunMultiDBTests(t, taskStorages, testSaveTaskCheckpoint)
+}
+
+//nolint:thelper
+func testSaveTaskCheckpoint(t *testing.T, storage storages.AdvancedTaskStorage) {
+	t.Parallel()
+	ctx := context.Background()
+
+	t.Run("ok", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+		task := makeTaskWithStatus(ctx, t, storage, "test SaveTaskCheckpoint", entity.TaskStatusProcessing)
+		version := task.Version
+
+		task.Checkpoint = lo.ToPtr(`{"offset": 100}`)
+		task.Progress = 40
+		err := storage.SaveTaskCheckpoint(ctx, task)
+		require.NoError(t, err)
+		require.Equal(t, version+1, task.Version)
+
+		dbTask, err := storage.GetTask(ctx, task.ID)
+		require.NoError(t, err)
+		testutils.EqualTask(t, task, dbTask)
+		require.Equal(t, task.Version, dbTask.Version)
+	})
+
+	t.Run("stale version", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+		task := makeTaskWithStatus(ctx, t, storage, "test SaveTaskCheckpoint conflict", entity.TaskStatusProcessing)
+		processedTask := *task
+
+		task.Status = entity.TaskStatusCanceled
+		err := storage.UpdateTask(ctx, task.ID, task)
+		require.NoError(t, err)
+
+		processedTask.Checkpoint = lo.ToPtr(`{"offset": 100}`)
+		processedTask.Progress = 40
+		err = storage.SaveTaskCheckpoint(ctx, &processedTask)
+		require.ErrorIs(t, err, entity.ErrTaskStateConflict)
+		require.ErrorIs(t, err, entity.ErrTaskCancelRequested)
+
+		dbTask, err := storage.GetTask(ctx, task.ID)
+		require.NoError(t, err)
+		testutils.EqualTask(t, task, dbTask)
+	})
+}
@@ -0,0 +1,86 @@
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ruko1202/xlog"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/ruko1202/goque/internal/entity"
+	"github.com/ruko1202/goque/internal/storages"
+	"github.com/ruko1202/goque/internal/storages/dbentity"
+	"github.com/ruko1202/goque/test/testutils"
+)
+
+func TestStats(t *testing.T) {
+	testutils.RunMultiDBTests(t, taskStorages, testStats)
+}
+
+//nolint:thelper
+func testStats(t *testing.T, storage storages.AdvancedTaskStorage) {
+	t.Parallel()
+	ctx := context.Background()
+
+	t.Run("ok", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+		taskType := "test Stats" + uuid.NewString()
+		payload := testutils.ToJSON(t, &testutils.TestPayload{Data: "test"})
+		now := time.Now().Truncate(time.Second)
+
+		oldest := entity.NewTask(taskType, payload)
+		oldest.NextAttemptAt = now.Add(-time.Hour)
+		require.NoError(t, storage.AddTask(ctx, oldest))
+		require.NoError(t, storage.AddTask(ctx, entity.NewTask(taskType, payload)))
+		require.NoError(t, storage.AddTask(ctx, entity.NewTask(taskType, payload, entity.WithTaskDelay(time.Hour))))
+		makeTaskWithStatus(ctx, t, storage, taskType, entity.TaskStatusDone)
+
+		processing := makeTask(ctx, t, storage, taskType)
+		processing.Status = entity.TaskStatusProcessing
+		processing.UpdatedAt = lo.ToPtr(now.Add(-time.Minute))
+		processing.Progress = 30
+		updateTask(ctx, t, storage, processing)
+		processing = makeTaskWithStatus(ctx, t, storage, taskType, entity.TaskStatusProcessing)
+		processing.Progress = 60
+		require.NoError(t, storage.SaveTaskCheckpoint(ctx, processing))
+
+		stats, err := storage.Stats(ctx, &dbentity.GetTasksFilter{TaskType: &taskType})
+		require.NoError(t, err)
+		require.Len(t, stats, 1)
+
+		typeStats := stats[0]
+		require.Equal(t, taskType, typeStats.TaskType)
+		require.Equal(t, map[entity.TaskStatus]int64{
+			entity.TaskStatusNew:        3,
+			entity.TaskStatusDone:       1,
+			entity.TaskStatusProcessing: 2,
+		}, typeStats.Counts)
+		require.NotNil(t, typeStats.OldestRunnableAt)
+		require.WithinDuration(t, oldest.NextAttemptAt, *typeStats.OldestRunnableAt, time.Second)
+		require.NotNil(t, typeStats.OldestProcessingAt)
+		require.WithinDuration(t, now.Add(-time.Minute), *typeStats.OldestProcessingAt, time.Second)
+		require.InDelta(t, 45, typeStats.ProcessingProgress, 0.001)
+	})
+
+	t.Run("without filter", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+		taskType := "test Stats: without filter" + uuid.NewString()
+		makeTask(ctx, t, storage, taskType)
+
+		stats, err := storage.Stats(ctx, nil)
+		require.NoError(t, err)
+
+		typeStats, ok := lo.Find(stats, func(item *entity.TaskStats) bool { return item.TaskType == taskType })
+		require.True(t, ok)
+		require.Equal(t, int64(1), typeStats.Total())
+		require.Nil(t, typeStats.OldestProcessingAt)
+	})
+}
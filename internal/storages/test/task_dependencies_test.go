@@ -0,0 +1,189 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/ruko1202/xlog"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/ruko1202/goque/internal/entity"
+	"github.com/ruko1202/goque/internal/storages"
+	"github.com/ruko1202/goque/internal/storages/dbentity"
+	"github.com/ruko1202/goque/test/testutils"
+)
+
+func TestTaskDependencies(t *testing.T) {
+	testutils.RunMultiDBTests(t, taskStorages, testTaskDependencies)
+}
+
+//nolint:thelper
+func testTaskDependencies(t *testing.T, storage storages.AdvancedTaskStorage) {
+	t.Parallel()
+	ctx := context.Background()
+
+	newTask := func(t *testing.T, taskType entity.TaskType, dependsOn ...uuid.UUID) *entity.Task {
+		t.Helper()
+		return entity.NewTask(taskType, testutils.ToJSON(t, &testutils.TestPayload{Data: "test"}), entity.WithTaskDependsOn(dependsOn...))
+	}
+	requireStatus := func(ctx context.Context, t *testing.T, task *entity.Task, status entity.TaskStatus) *entity.Task {
+		t.Helper()
+
+		dbTask, err := storage.GetTask(ctx, task.ID)
+		require.NoError(t, err)
+		require.Equal(t, status, dbTask.Status)
+
+		return dbTask
+	}
+	finishTask := func(ctx context.Context, t *testing.T, task *entity.Task, status entity.TaskStatus) {
+		t.Helper()
+
+		task.Status = status
+		err := storage.UpdateTask(ctx, task.ID, task)
+		require.NoError(t, err)
+	}
+
+	t.Run("release", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+		taskType := "test TaskDependencies release " + uuid.NewString()
+		first := makeTask(ctx, t, storage, taskType)
+		second := makeTask(ctx, t, storage, taskType)
+
+		dependent := newTask(t, taskType, first.ID, second.ID)
+		err := storage.AddTask(ctx, dependent)
+		require.NoError(t, err)
+		requireStatus(ctx, t, dependent, entity.TaskStatusWaiting)
+
+		finishTask(ctx, t, first, entity.TaskStatusDone)
+		requireStatus(ctx, t, dependent, entity.TaskStatusWaiting)
+
+		finishTask(ctx, t, second, entity.TaskStatusDone)
+		dbTask := requireStatus(ctx, t, dependent, entity.TaskStatusNew)
+		require.Greater(t, dbTask.Version, dependent.Version)
+	})
+
+	t.Run("dependency already done", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+		taskType := "test TaskDependencies done " + uuid.NewString()
+		done := makeTaskWithStatus(ctx, t, storage, taskType, entity.TaskStatusDone)
+
+		dependent := newTask(t, taskType, done.ID)
+		err := storage.AddTask(ctx, dependent)
+		require.NoError(t, err)
+		requireStatus(ctx, t, dependent, entity.TaskStatusNew)
+	})
+
+	t.Run("invalid dependencies", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+		taskType := "test TaskDependencies invalid " + uuid.NewString()
+		canceled := makeTaskWithStatus(ctx, t, storage, taskType, entity.TaskStatusCanceled)
+
+		err := storage.AddTask(ctx, newTask(t, taskType, uuid.New()))
+		require.ErrorIs(t, err, entity.ErrTaskDependencyNotFound)
+
+		err = storage.AddTask(ctx, newTask(t, taskType, canceled.ID))
+		require.ErrorIs(t, err, entity.ErrTaskDependencyFailed)
+
+		a, b := newTask(t, taskType), newTask(t, taskType)
+		a.DependsOn, b.DependsOn = []uuid.UUID{b.ID}, []uuid.UUID{a.ID}
+		err = storage.AddTasks(ctx, []*entity.Task{a, b, newTask(t, taskType)}, false)
+		var tasksErr *entity.TasksError
+		require.True(t, errors.As(err, &tasksErr))
+		require.Len(t, tasksErr.Errors, 2)
+		require.ErrorIs(t, err, entity.ErrTaskDependencyCycle)
+
+		tasks, err := storage.GetTasks(ctx, &dbentity.GetTasksFilter{TaskType: lo.ToPtr(taskType)}, 10)
+		require.NoError(t, err)
+		require.Len(t, tasks, 1, "only the canceled task is added")
+	})
+
+	t.Run("batch", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+		taskType := "test TaskDependencies batch " + uuid.NewString()
+		root := newTask(t, taskType)
+		left, right := newTask(t, taskType, root.ID), newTask(t, taskType, root.ID)
+		join := newTask(t, taskType, left.ID, right.ID)
+		err := storage.AddTasks(ctx, []*entity.Task{join, left, right, root}, false)
+		require.NoError(t, err)
+		requireStatus(ctx, t, root, entity.TaskStatusNew)
+
+		finishTask(ctx, t, root, entity.TaskStatusDone)
+		left = requireStatus(ctx, t, left, entity.TaskStatusNew)
+		right = requireStatus(ctx, t, right, entity.TaskStatusNew)
+		requireStatus(ctx, t, join, entity.TaskStatusWaiting)
+
+		finishTask(ctx, t, left, entity.TaskStatusDone)
+		finishTask(ctx, t, right, entity.TaskStatusDone)
+		requireStatus(ctx, t, join, entity.TaskStatusNew)
+	})
+
+	t.Run("cancel cascade", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+		taskType := "test TaskDependencies cascade " + uuid.NewString()
+		root, other := newTask(t, taskType), newTask(t, taskType)
+		child := newTask(t, taskType, root.ID, other.ID)
+		grandchild := newTask(t, taskType, child.ID)
+		err := storage.AddTasks(ctx, []*entity.Task{root, other, child, grandchild}, false)
+		require.NoError(t, err)
+
+		finishTask(ctx, t, root, entity.TaskStatusAttemptsLeft)
+		dbChild := requireStatus(ctx, t, child, entity.TaskStatusCanceled)
+		require.Contains(t, dbChild.LastError(), entity.ErrTaskDependencyFailed.Error())
+		require.Contains(t, dbChild.LastError(), root.ID.String())
+		dbGrandchild := requireStatus(ctx, t, grandchild, entity.TaskStatusCanceled)
+		require.Contains(t, dbGrandchild.LastError(), child.ID.String())
+
+		// the dependencies of the canceled tasks are gone, finishing the rest changes nothing
+		finishTask(ctx, t, other, entity.TaskStatusDone)
+		requireStatus(ctx, t, child, entity.TaskStatusCanceled)
+	})
+
+	t.Run("move to dead", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+		taskType := "test TaskDependencies dead " + uuid.NewString()
+		root := makeTaskWithStatus(ctx, t, storage, taskType, entity.TaskStatusProcessing)
+		dependent := newTask(t, taskType, root.ID)
+		err := storage.AddTask(ctx, dependent)
+		require.NoError(t, err)
+
+		root.Status = entity.TaskStatusAttemptsLeft
+		err = storage.MoveTaskToDead(ctx, root)
+		require.NoError(t, err)
+		requireStatus(ctx, t, dependent, entity.TaskStatusCanceled)
+	})
+
+	t.Run("bulk cancel", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+		taskType := "test TaskDependencies bulk cancel " + uuid.NewString()
+		root := makeTask(ctx, t, storage, taskType)
+		child := newTask(t, "test TaskDependencies bulk cancel child "+uuid.NewString(), root.ID)
+		grandchild := newTask(t, child.Type, child.ID)
+		err := storage.AddTasks(ctx, []*entity.Task{child, grandchild}, false)
+		require.NoError(t, err)
+
+		canceled, err := storage.CancelTasks(ctx, &dbentity.GetTasksFilter{TaskType: lo.ToPtr(taskType)})
+		require.NoError(t, err)
+		require.EqualValues(t, 1, canceled)
+
+		requireStatus(ctx, t, child, entity.TaskStatusCanceled)
+		requireStatus(ctx, t, grandchild, entity.TaskStatusCanceled)
+	})
+}
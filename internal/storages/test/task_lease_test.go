@@ -0,0 +1,116 @@
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ruko1202/xlog"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/ruko1202/goque/internal/entity"
+	"github.com/ruko1202/goque/internal/storages"
+	"github.com/ruko1202/goque/internal/utils/xtime"
+	"github.com/ruko1202/goque/test/testutils"
+)
+
+func TestTaskLease(t *testing.T) {
+	testutils.RunMultiDBTests(t, taskStorages, testTaskLease)
+}
+
+//nolint:thelper
+func testTaskLease(t *testing.T, storage storages.AdvancedTaskStorage) {
+	t.Parallel()
+	ctx := context.Background()
+
+	fetchTask := func(ctx context.Context, t *testing.T, lease entity.TaskLease) *entity.Task {
+		t.Helper()
+
+		task := makeTask(ctx, t, storage, "test task lease "+uuid.NewString())
+		tasks, err := storage.GetTasksForProcessing(ctx, task.Type, 1, lease, 0)
+		require.NoError(t, err)
+		require.Len(t, tasks, 1)
+
+		return tasks[0]
+	}
+
+	t.Run("fetch stamps lease", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+		task := fetchTask(ctx, t, testLease)
+		require.Equal(t, testLease.Owner, lo.FromPtr(task.LockedBy))
+		testutils.AssertTimeInWithDelta(t, xtime.Now().Add(testLease.Duration), lo.FromPtr(task.LeaseExpiresAt), time.Second)
+
+		dbTask, err := storage.GetTask(ctx, task.ID)
+		require.NoError(t, err)
+		require.Equal(t, testLease.Owner, lo.FromPtr(dbTask.LockedBy))
+		testutils.AssertTimeInWithDelta(t, lo.FromPtr(task.LeaseExpiresAt), lo.FromPtr(dbTask.LeaseExpiresAt), time.Second)
+	})
+
+	t.Run("renew", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+		task := fetchTask(ctx, t, entity.TaskLease{Owner: testLease.Owner, Duration: time.Second})
+
+		err := storage.RenewTaskLeases(ctx, []uuid.UUID{task.ID}, testLease)
+		require.NoError(t, err)
+
+		dbTask, err := storage.GetTask(ctx, task.ID)
+		require.NoError(t, err)
+		testutils.AssertTimeInWithDelta(t, xtime.Now().Add(testLease.Duration), lo.FromPtr(dbTask.LeaseExpiresAt), time.Second)
+	})
+
+	t.Run("renew by another owner", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+		task := fetchTask(ctx, t, testLease)
+
+		err := storage.RenewTaskLeases(ctx, []uuid.UUID{task.ID}, entity.TaskLease{Owner: "another", Duration: time.Hour})
+		require.NoError(t, err)
+
+		dbTask, err := storage.GetTask(ctx, task.ID)
+		require.NoError(t, err)
+		require.Equal(t, testLease.Owner, lo.FromPtr(dbTask.LockedBy))
+		testutils.AssertTimeInWithDelta(t, lo.FromPtr(task.LeaseExpiresAt), lo.FromPtr(dbTask.LeaseExpiresAt), time.Second)
+	})
+
+	t.Run("healer skips active lease", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+		task := fetchTask(ctx, t, testLease)
+		task.Status = entity.TaskStatusProcessing
+		task.UpdatedAt = lo.ToPtr(xtime.Now().Add(-2 * time.Hour))
+		updateTask(ctx, t, storage, task)
+
+		tasks, err := storage.CureTasks(ctx, task.Type, []entity.TaskStatus{
+			entity.TaskStatusProcessing,
+		}, time.Hour, "comment")
+		require.NoError(t, err)
+		require.Empty(t, tasks)
+	})
+
+	t.Run("healer reclaims expired lease", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+		task := fetchTask(ctx, t, entity.TaskLease{Owner: testLease.Owner, Duration: -time.Minute})
+
+		tasks, err := storage.CureTasks(ctx, task.Type, []entity.TaskStatus{
+			entity.TaskStatusPending,
+		}, time.Hour, "comment")
+		require.NoError(t, err)
+		require.Len(t, tasks, 1)
+		require.Equal(t, task.ID, tasks[0].ID)
+
+		dbTask, err := storage.GetTask(ctx, task.ID)
+		require.NoError(t, err)
+		require.Equal(t, entity.TaskStatusError, dbTask.Status)
+	})
+}
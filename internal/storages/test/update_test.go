@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/ruko1202/xlog"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap/zaptest"
 
@@ -32,6 +33,7 @@ func testUpdateTask(t *testing.T, storage storages.AdvancedTaskStorage) {
 		task.Attempts++
 		task.Status = entity.TaskStatusPending
 		task.NextAttemptAt = task.NextAttemptAt.Add(time.Hour)
+		task.RetryDelay = lo.ToPtr(time.Hour)
 		err := storage.UpdateTask(ctx, task.ID, task)
 		require.NoError(t, err)
 
@@ -39,4 +41,93 @@ func testUpdateTask(t *testing.T, storage storages.AdvancedTaskStorage) {
 		require.NoError(t, err)
 		testutils.EqualTask(t, task, dbTask)
 	})
+	t.Run("result", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+		task := makeTask(ctx, t, storage, "test UpdateTask result")
+
+		task.Status = entity.TaskStatusDone
+		task.Result = lo.ToPtr(`{"report_url": "https://example.com/report.pdf", "pages": 3}`)
+		err := storage.UpdateTask(ctx, task.ID, task)
+		require.NoError(t, err)
+
+		dbTask, err := storage.GetTask(ctx, task.ID)
+		require.NoError(t, err)
+		testutils.EqualTask(t, task, dbTask)
+	})
+
+	t.Run("bumps version", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+		task := makeTask(ctx, t, storage, "test UpdateTask version")
+		version := task.Version
+
+		task.Status = entity.TaskStatusPending
+		err := storage.UpdateTask(ctx, task.ID, task)
+		require.NoError(t, err)
+		require.Equal(t, version+1, task.Version)
+
+		task.Status = entity.TaskStatusProcessing
+		err = storage.UpdateTask(ctx, task.ID, task)
+		require.NoError(t, err)
+		require.Equal(t, version+2, task.Version)
+
+		dbTask, err := storage.GetTask(ctx, task.ID)
+		require.NoError(t, err)
+		require.Equal(t, task.Version, dbTask.Version)
+	})
+
+	t.Run("stale version", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+		task := makeTaskWithStatus(ctx, t, storage, "test UpdateTask conflict", entity.TaskStatusProcessing)
+		staleTask := *task
+
+		task.Status = entity.TaskStatusError
+		err := storage.UpdateTask(ctx, task.ID, task)
+		require.NoError(t, err)
+
+		staleTask.Status = entity.TaskStatusDone
+		err = storage.UpdateTask(ctx, staleTask.ID, &staleTask)
+		require.ErrorIs(t, err, entity.ErrTaskStateConflict)
+		require.NotErrorIs(t, err, entity.ErrTaskCancelRequested)
+
+		dbTask, err := storage.GetTask(ctx, task.ID)
+		require.NoError(t, err)
+		testutils.EqualTask(t, task, dbTask)
+		require.Equal(t, task.Version, dbTask.Version)
+	})
+
+	t.Run("canceled concurrently", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+		task := makeTaskWithStatus(ctx, t, storage, "test UpdateTask conflict", entity.TaskStatusProcessing)
+		processedTask := *task
+
+		task.Status = entity.TaskStatusCanceled
+		err := storage.UpdateTask(ctx, task.ID, task)
+		require.NoError(t, err)
+
+		processedTask.Status = entity.TaskStatusDone
+		err = storage.UpdateTask(ctx, processedTask.ID, &processedTask)
+		require.ErrorIs(t, err, entity.ErrTaskStateConflict)
+		require.ErrorIs(t, err, entity.ErrTaskCancelRequested)
+
+		dbTask, err := storage.GetTask(ctx, task.ID)
+		require.NoError(t, err)
+		testutils.EqualTask(t, task, dbTask)
+	})
+
+	t.Run("deleted task", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+		task := entity.NewTask("test UpdateTask conflict", "{}")
+		err := storage.UpdateTask(ctx, task.ID, task)
+		require.NoError(t, err)
+	})
 }
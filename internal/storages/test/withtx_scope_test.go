@@ -154,7 +154,7 @@ func testWithTxScope(t *testing.T, storage storages.AdvancedTaskStorage) {
 		tx, err := storage.GetDB().BeginTxx(ctx, nil)
 		require.NoError(t, err)
 
-		fetched, err := storage.GetTasksForProcessing(goque.WithTx(ctx, tx), taskType, 10)
+		fetched, err := storage.GetTasksForProcessing(goque.WithTx(ctx, tx), taskType, 10, testLease, 0)
 		require.NoError(t, err)
 		require.NotEmpty(t, fetched, "must fetch the seeded task")
 
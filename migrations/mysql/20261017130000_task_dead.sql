@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE goque_task_dead (
+    id              CHAR(36)     PRIMARY KEY,
+    type            VARCHAR(255) NOT NULL,
+    external_id     VARCHAR(255) NOT NULL,
+    payload         JSON         NOT NULL,
+    status          VARCHAR(50)  NOT NULL,
+    attempts        INT          NOT NULL,
+    errors          TEXT,
+    metadata        JSON,
+    created_at      TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP    NULL,
+    next_attempt_at TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    priority        INT          NOT NULL DEFAULT 0
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX goque_task_dead_type_updated_at_idx ON goque_task_dead (type, updated_at ASC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE goque_task_dead;
+-- +goose StatementEnd
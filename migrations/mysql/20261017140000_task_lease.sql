@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE goque_task ADD COLUMN locked_by VARCHAR(255) NULL, ADD COLUMN lease_expires_at TIMESTAMP NULL;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE goque_task_dead ADD COLUMN locked_by VARCHAR(255) NULL, ADD COLUMN lease_expires_at TIMESTAMP NULL;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX goque_task_type_status_lease_expires_at_idx ON goque_task (type, status, lease_expires_at ASC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX goque_task_type_status_lease_expires_at_idx ON goque_task;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE goque_task_dead DROP COLUMN lease_expires_at, DROP COLUMN locked_by;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE goque_task DROP COLUMN lease_expires_at, DROP COLUMN locked_by;
+-- +goose StatementEnd
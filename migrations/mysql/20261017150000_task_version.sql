@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE goque_task ADD COLUMN version BIGINT NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE goque_task_dead ADD COLUMN version BIGINT NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE goque_task_dead DROP COLUMN version;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE goque_task DROP COLUMN version;
+-- +goose StatementEnd
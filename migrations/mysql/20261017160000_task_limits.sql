@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE goque_task ADD COLUMN timeout_ms BIGINT NULL, ADD COLUMN max_attempts INT NULL;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE goque_task_dead ADD COLUMN timeout_ms BIGINT NULL, ADD COLUMN max_attempts INT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE goque_task_dead DROP COLUMN max_attempts, DROP COLUMN timeout_ms;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE goque_task DROP COLUMN max_attempts, DROP COLUMN timeout_ms;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE goque_rate_limit (
+    name       VARCHAR(255) PRIMARY KEY,
+    tokens     DOUBLE       NOT NULL,
+    updated_at TIMESTAMP(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE goque_rate_limit;
+-- +goose StatementEnd
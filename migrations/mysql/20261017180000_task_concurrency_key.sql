@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE goque_task ADD COLUMN concurrency_key VARCHAR(255) NULL;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE goque_task_dead ADD COLUMN concurrency_key VARCHAR(255) NULL;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX goque_task_concurrency_key_status_idx ON goque_task (concurrency_key, status);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX goque_task_concurrency_key_status_idx ON goque_task;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE goque_task_dead DROP COLUMN concurrency_key;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE goque_task DROP COLUMN concurrency_key;
+-- +goose StatementEnd
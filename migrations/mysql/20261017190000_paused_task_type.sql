@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE goque_paused_task_type (
+    type      VARCHAR(255) PRIMARY KEY,
+    paused_at TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE goque_paused_task_type;
+-- +goose StatementEnd
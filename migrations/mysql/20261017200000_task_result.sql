@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE goque_task ADD COLUMN result JSON NULL;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE goque_task_dead ADD COLUMN result JSON NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE goque_task_dead DROP COLUMN result;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE goque_task DROP COLUMN result;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE goque_task_dependency (
+    task_id            CHAR(36) NOT NULL,
+    depends_on_task_id CHAR(36) NOT NULL,
+    PRIMARY KEY (task_id, depends_on_task_id)
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX goque_task_dependency_depends_on_task_id_idx ON goque_task_dependency (depends_on_task_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE goque_task_dependency;
+-- +goose StatementEnd
@@ -0,0 +1,47 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE goque_task_batch (
+    id                 CHAR(36)  PRIMARY KEY,
+    total              BIGINT    NOT NULL,
+    completion_task_id CHAR(36)  NULL,
+    created_at         TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    completed_at       TIMESTAMP NULL
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE goque_task ADD COLUMN batch_id CHAR(36) NULL;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE goque_task_dead ADD COLUMN batch_id CHAR(36) NULL;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX goque_task_batch_id_status_idx ON goque_task (batch_id, status);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX goque_task_dead_batch_id_idx ON goque_task_dead (batch_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX goque_task_dead_batch_id_idx ON goque_task_dead;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP INDEX goque_task_batch_id_status_idx ON goque_task;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE goque_task_dead DROP COLUMN batch_id;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE goque_task DROP COLUMN batch_id;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP TABLE goque_task_batch;
+-- +goose StatementEnd
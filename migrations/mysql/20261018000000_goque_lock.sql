@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE goque_lock (
+    name VARCHAR(255) PRIMARY KEY
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+INSERT INTO goque_lock (name) VALUES ('concurrency_keys');
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE goque_lock;
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE goque_task ADD COLUMN concurrency_key TEXT;
+ALTER TABLE goque_task_dead ADD COLUMN concurrency_key TEXT;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX goque_task_concurrency_key_status_idx ON goque_task (concurrency_key, status) WHERE concurrency_key IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX goque_task_concurrency_key_status_idx;
+ALTER TABLE goque_task_dead DROP COLUMN concurrency_key;
+ALTER TABLE goque_task DROP COLUMN concurrency_key;
+-- +goose StatementEnd
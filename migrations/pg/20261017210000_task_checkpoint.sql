@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE goque_task ADD COLUMN checkpoint JSONB;
+ALTER TABLE goque_task ADD COLUMN progress INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE goque_task_dead ADD COLUMN checkpoint JSONB;
+ALTER TABLE goque_task_dead ADD COLUMN progress INTEGER NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE goque_task_dead DROP COLUMN progress;
+ALTER TABLE goque_task_dead DROP COLUMN checkpoint;
+ALTER TABLE goque_task DROP COLUMN progress;
+ALTER TABLE goque_task DROP COLUMN checkpoint;
+-- +goose StatementEnd
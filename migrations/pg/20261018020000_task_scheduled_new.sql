@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- scheduled tasks used to be inserted as pending and never touched before the first fetch
+UPDATE goque_task SET status = 'new' WHERE status = 'pending' AND updated_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+UPDATE goque_task SET status = 'pending' WHERE status = 'new' AND updated_at IS NULL AND next_attempt_at > CURRENT_TIMESTAMP;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE goque_task ADD COLUMN priority INTEGER NOT NULL DEFAULT 0;
+DROP INDEX goque_task_type_status_next_attempt_at_idx;
+CREATE INDEX goque_task_type_status_priority_next_attempt_at_idx ON goque_task (type, status, priority DESC, next_attempt_at ASC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX goque_task_type_status_priority_next_attempt_at_idx;
+CREATE INDEX goque_task_type_status_next_attempt_at_idx ON goque_task (type, status, next_attempt_at ASC);
+ALTER TABLE goque_task DROP COLUMN priority;
+-- +goose StatementEnd
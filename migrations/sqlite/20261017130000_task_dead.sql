@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE goque_task_dead (
+    id              TEXT        PRIMARY KEY,
+    type            TEXT        NOT NULL,
+    external_id     TEXT        NOT NULL,
+    payload         TEXT        NOT NULL,
+    status          TEXT        NOT NULL,
+    attempts        INTEGER     NOT NULL,
+    errors          TEXT,
+    metadata        TEXT,
+    created_at      TEXT        NOT NULL DEFAULT (datetime('now')),
+    updated_at      TEXT,
+    next_attempt_at TEXT        NOT NULL DEFAULT (datetime('now')),
+    priority        INTEGER     NOT NULL DEFAULT 0
+);
+CREATE INDEX goque_task_dead_type_updated_at_idx ON goque_task_dead (type, updated_at ASC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE goque_task_dead;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE goque_task ADD COLUMN locked_by TEXT;
+ALTER TABLE goque_task ADD COLUMN lease_expires_at TEXT;
+ALTER TABLE goque_task_dead ADD COLUMN locked_by TEXT;
+ALTER TABLE goque_task_dead ADD COLUMN lease_expires_at TEXT;
+CREATE INDEX goque_task_type_status_lease_expires_at_idx ON goque_task (type, status, lease_expires_at ASC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX goque_task_type_status_lease_expires_at_idx;
+ALTER TABLE goque_task_dead DROP COLUMN lease_expires_at;
+ALTER TABLE goque_task_dead DROP COLUMN locked_by;
+ALTER TABLE goque_task DROP COLUMN lease_expires_at;
+ALTER TABLE goque_task DROP COLUMN locked_by;
+-- +goose StatementEnd
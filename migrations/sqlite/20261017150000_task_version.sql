@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE goque_task ADD COLUMN version INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE goque_task_dead ADD COLUMN version INTEGER NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE goque_task_dead DROP COLUMN version;
+ALTER TABLE goque_task DROP COLUMN version;
+-- +goose StatementEnd
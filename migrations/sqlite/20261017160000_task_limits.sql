@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE goque_task ADD COLUMN timeout_ms INTEGER;
+ALTER TABLE goque_task ADD COLUMN max_attempts INTEGER;
+ALTER TABLE goque_task_dead ADD COLUMN timeout_ms INTEGER;
+ALTER TABLE goque_task_dead ADD COLUMN max_attempts INTEGER;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE goque_task_dead DROP COLUMN max_attempts;
+ALTER TABLE goque_task_dead DROP COLUMN timeout_ms;
+ALTER TABLE goque_task DROP COLUMN max_attempts;
+ALTER TABLE goque_task DROP COLUMN timeout_ms;
+-- +goose StatementEnd
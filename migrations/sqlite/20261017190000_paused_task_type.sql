@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE goque_paused_task_type (
+    type      TEXT PRIMARY KEY,
+    paused_at TEXT NOT NULL DEFAULT (datetime('now'))
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE goque_paused_task_type;
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE goque_task_batch (
+    id                 TEXT    PRIMARY KEY,
+    total              INTEGER NOT NULL,
+    completion_task_id TEXT,
+    created_at         TEXT    NOT NULL DEFAULT (datetime('now')),
+    completed_at       TEXT
+);
+ALTER TABLE goque_task ADD COLUMN batch_id TEXT;
+ALTER TABLE goque_task_dead ADD COLUMN batch_id TEXT;
+CREATE INDEX goque_task_batch_id_status_idx ON goque_task (batch_id, status) WHERE batch_id IS NOT NULL;
+CREATE INDEX goque_task_dead_batch_id_idx ON goque_task_dead (batch_id) WHERE batch_id IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX goque_task_dead_batch_id_idx;
+DROP INDEX goque_task_batch_id_status_idx;
+ALTER TABLE goque_task_dead DROP COLUMN batch_id;
+ALTER TABLE goque_task DROP COLUMN batch_id;
+DROP TABLE goque_task_batch;
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE goque_task ADD COLUMN retry_delay_ms INTEGER;
+ALTER TABLE goque_task_dead ADD COLUMN retry_delay_ms INTEGER;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE goque_task_dead DROP COLUMN retry_delay_ms;
+ALTER TABLE goque_task DROP COLUMN retry_delay_ms;
+-- +goose StatementEnd
@@ -20,6 +20,8 @@ import (
 	"github.com/ruko1202/goque/test/testutils"
 )
 
+var benchLease = entity.TaskLease{Owner: "benchmark", Duration: time.Minute}
+
 // toJSONBench converts object to JSON for benchmarks.
 func toJSONBench(tb testing.TB, obj any) string {
 	tb.Helper()
@@ -127,7 +129,7 @@ func benchmarkTaskFetch(b *testing.B, storage storages.AdvancedTaskStorage) {
 	b.ReportAllocs()
 
 	for i := 0; i < b.N; i++ {
-		tasks, err := storage.GetTasksForProcessing(ctx, taskType, 1)
+		tasks, err := storage.GetTasksForProcessing(ctx, taskType, 1, benchLease, 0)
 		require.NoError(b, err)
 		if len(tasks) > 0 {
 			task := tasks[0]
@@ -194,7 +196,7 @@ func benchmarkWorkerPool(b *testing.B, storage storages.AdvancedTaskStorage, wor
 
 	// Wait for all tasks to be processed
 	require.Eventually(b, func() bool {
-		tasks, err := storage.GetTasksForProcessing(ctx, taskType, 1)
+		tasks, err := storage.GetTasksForProcessing(ctx, taskType, 1, benchLease, 0)
 		require.NoError(b, err)
 		return len(tasks) == 0
 	}, 30*time.Second, 100*time.Millisecond)
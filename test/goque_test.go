@@ -2,6 +2,9 @@ package test
 
 import (
 	"context"
+	"errors"
+	"strings"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -91,6 +94,212 @@ func testGoque(t *testing.T, storage storages.AdvancedTaskStorage) {
 		}, time.Second, time.Millisecond*50)
 	})
 
+	t.Run("result", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+		type report struct {
+			Lines int `json:"lines"`
+		}
+		taskType := "test result type" + uuid.NewString()
+		task := goque.NewTask(taskType, testutils.ToJSON(t, &testutils.TestPayload{Data: "one two three"}))
+		pushToQueue(ctx, t, queueManager, task)
+
+		goq := goque.NewGoque(storage)
+		goq.RegisterProcessor(
+			taskType,
+			goque.NewTypedResultTaskProcessor(
+				goque.TypedResultTaskProcessorFunc[testutils.TestPayload, report](
+					func(_ context.Context, task *goque.TypedTask[testutils.TestPayload]) (report, error) {
+						return report{Lines: len(strings.Fields(task.Payload.Data))}, nil
+					},
+				),
+			),
+			goque.WithTaskFetcherTick(10*time.Millisecond),
+		)
+		err := goq.Run(ctx)
+		require.NoError(t, err)
+		defer goq.Stop()
+
+		ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+		result, err := goque.GetTypedTaskResult[report](ctx, queueManager, task.ID)
+		require.NoError(t, err)
+		require.Equal(t, report{Lines: 3}, result)
+	})
+
+	t.Run("checkpoint", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+		type progress struct {
+			Offset int `json:"offset"`
+		}
+		taskType := "test checkpoint type" + uuid.NewString()
+		task := goque.NewTask(taskType, goque.NoTaskPayload)
+		pushToQueue(ctx, t, queueManager, task)
+
+		errInterrupted := errors.New("interrupted")
+		goq := goque.NewGoque(storage)
+		goq.RegisterProcessor(
+			taskType,
+			goque.NewResultTaskProcessor(goque.ResultTaskProcessorFunc(func(ctx context.Context, _ *goque.Task) (any, error) {
+				checkpoint, ok, err := goque.LoadCheckpoint[progress](ctx)
+				if err != nil {
+					return nil, err
+				}
+				if !ok {
+					if err := goque.SaveCheckpoint(ctx, progress{Offset: 50}, 50); err != nil {
+						return nil, err
+					}
+					return nil, errInterrupted
+				}
+				return checkpoint, nil
+			})),
+			goque.WithTaskFetcherTick(10*time.Millisecond),
+			goque.WithTaskProcessingBackoff(goque.BackoffStrategyFunc(func(_ *goque.Task, _ error) time.Time {
+				return time.Now()
+			})),
+		)
+		err := goq.Run(ctx)
+		require.NoError(t, err)
+		defer goq.Stop()
+
+		ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+		result, err := goque.GetTypedTaskResult[progress](ctx, queueManager, task.ID)
+		require.NoError(t, err)
+		require.Equal(t, progress{Offset: 50}, result)
+
+		dbTask, err := queueManager.GetTask(ctx, task.ID)
+		require.NoError(t, err)
+		require.Nil(t, dbTask.Checkpoint)
+		require.EqualValues(t, goque.MaxTaskProgress, dbTask.Progress)
+		require.EqualValues(t, 1, dbTask.Attempts)
+	})
+
+	t.Run("workflow", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+		taskType := "test workflow type" + uuid.NewString()
+		newTask := func(step string) *goque.Task {
+			return goque.NewTask(taskType, testutils.ToJSON(t, &testutils.TestPayload{Data: step}))
+		}
+		workflow := goque.NewWorkflow()
+		extract := workflow.Add(newTask("extract"))
+		transform := workflow.Add(newTask("transform"), extract)
+		load := workflow.Add(newTask("load"), extract, transform)
+		failed := workflow.Add(newTask("fail"), extract)
+		notify := workflow.Add(newTask("notify"), load, failed)
+		err := queueManager.AddWorkflowToQueue(ctx, workflow)
+		require.NoError(t, err)
+
+		var (
+			mu    sync.Mutex
+			steps []string
+		)
+		goq := goque.NewGoque(storage)
+		goq.RegisterProcessor(
+			taskType,
+			goque.NewTypedTaskProcessor(
+				goque.TypedTaskProcessorFunc[testutils.TestPayload](func(_ context.Context, task *goque.TypedTask[testutils.TestPayload]) error {
+					if task.Payload.Data == "fail" {
+						return goque.Permanent(errors.New("failed step"))
+					}
+					mu.Lock()
+					defer mu.Unlock()
+					steps = append(steps, task.Payload.Data)
+					return nil
+				}),
+			),
+			goque.WithTaskFetcherTick(10*time.Millisecond),
+		)
+		err = goq.Run(ctx)
+		require.NoError(t, err)
+		defer goq.Stop()
+
+		ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+		for _, task := range []*goque.Task{extract, transform, load} {
+			dbTask, err := queueManager.WaitForTask(ctx, task.ID)
+			require.NoError(t, err)
+			require.Equal(t, goque.TaskStatusDone, dbTask.Status)
+		}
+		dbTask, err := queueManager.WaitForTask(ctx, notify.ID)
+		require.NoError(t, err)
+		require.Equal(t, goque.TaskStatusCanceled, dbTask.Status)
+		require.Contains(t, dbTask.LastError(), goque.ErrTaskDependencyFailed.Error())
+
+		mu.Lock()
+		defer mu.Unlock()
+		require.Equal(t, []string{"extract", "transform", "load"}, steps)
+	})
+
+	t.Run("batch", func(t *testing.T) {
+		t.Parallel()
+		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
+
+		taskType := "test batch type" + uuid.NewString()
+		tasks := lo.Times(10, func(i int) *goque.Task {
+			data := "resize"
+			if i == 0 {
+				data = "fail"
+			}
+			return goque.NewTask(taskType, testutils.ToJSON(t, &testutils.TestPayload{Data: data}))
+		})
+		onComplete := goque.NewTask(taskType, testutils.ToJSON(t, &testutils.TestPayload{Data: "notify"}))
+		batch, err := queueManager.AddBatch(ctx, tasks, onComplete)
+		require.NoError(t, err)
+
+		var (
+			resized  atomic.Int32
+			notified atomic.Int32
+		)
+		goq := goque.NewGoque(storage)
+		goq.RegisterProcessor(
+			taskType,
+			goque.NewTypedTaskProcessor(
+				goque.TypedTaskProcessorFunc[testutils.TestPayload](func(ctx context.Context, task *goque.TypedTask[testutils.TestPayload]) error {
+					switch task.Payload.Data {
+					case "fail":
+						return goque.Permanent(errors.New("failed resize"))
+					case "notify":
+						dbBatch, err := queueManager.GetBatch(ctx, batch.ID)
+						if err != nil {
+							return err
+						}
+						if dbBatch.Finished() != dbBatch.Total {
+							return errors.New("batch is not finished")
+						}
+						notified.Add(1)
+					default:
+						resized.Add(1)
+					}
+					return nil
+				}),
+			),
+			goque.WithTaskFetcherTick(10*time.Millisecond),
+		)
+		err = goq.Run(ctx)
+		require.NoError(t, err)
+		defer goq.Stop()
+
+		ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+		dbTask, err := queueManager.WaitForTask(ctx, onComplete.ID)
+		require.NoError(t, err)
+		require.Equal(t, goque.TaskStatusDone, dbTask.Status)
+
+		dbBatch, err := queueManager.GetBatch(ctx, batch.ID)
+		require.NoError(t, err)
+		require.EqualValues(t, 9, dbBatch.Done)
+		require.EqualValues(t, 1, dbBatch.Failed)
+		require.True(t, dbBatch.IsCompleted())
+		require.EqualValues(t, 9, resized.Load())
+		require.EqualValues(t, 1, notified.Load())
+	})
+
 	t.Run("stop when in pending a lot of tasks", func(t *testing.T) {
 		t.Parallel()
 		ctx := xlog.ContextWithLogger(ctx, xlog.NewZapAdapter(zaptest.NewLogger(t)))
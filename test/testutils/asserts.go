@@ -23,8 +23,26 @@ func EqualTask(t *testing.T, expected, actual *entity.Task) {
 	require.Equal(t, expected.Type, actual.Type)
 	require.Equal(t, expected.ExternalID, actual.ExternalID)
 	require.Equal(t, FromJSON(t, expected.Payload), FromJSON(t, actual.Payload))
+	require.Equal(t, expected.Priority, actual.Priority)
+	require.Equal(t, expected.Timeout, actual.Timeout)
+	require.Equal(t, expected.MaxAttempts, actual.MaxAttempts)
+	require.Equal(t, expected.ConcurrencyKey, actual.ConcurrencyKey)
+	if expected.Result == nil {
+		require.Nil(t, actual.Result)
+	} else {
+		require.NotNil(t, actual.Result)
+		require.JSONEq(t, *expected.Result, *actual.Result)
+	}
 	require.Equal(t, expected.Status, actual.Status)
+	require.Equal(t, expected.Progress, actual.Progress)
+	if expected.Checkpoint == nil {
+		require.Nil(t, actual.Checkpoint)
+	} else {
+		require.NotNil(t, actual.Checkpoint)
+		require.JSONEq(t, *expected.Checkpoint, *actual.Checkpoint)
+	}
 	require.Equal(t, expected.Attempts, actual.Attempts)
+	require.Equal(t, expected.RetryDelay, actual.RetryDelay)
 	require.Equal(t, lo.FromPtr(expected.Errors), lo.FromPtr(actual.Errors))
 	AssertTimeInWithDelta(t, expected.CreatedAt, actual.CreatedAt, timeDelta)
 	if expected.UpdatedAt == nil {